@@ -2,19 +2,26 @@ package app
 
 import (
 	"context"
+	"crypto/tls"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
 	"os"
 	"os/signal"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
 
 	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/certs"
 	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/directory"
 	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/mail"
+	"github.com/ChausseBenjamin/rafta/internal/oidc"
 	"github.com/ChausseBenjamin/rafta/internal/pb"
 	"github.com/ChausseBenjamin/rafta/internal/secrets"
 	"github.com/ChausseBenjamin/rafta/internal/util"
@@ -22,6 +29,13 @@ import (
 	"google.golang.org/grpc"
 )
 
+var (
+	errClientCertsPlaintext = errors.New("client certificates require HTTPS")
+	errOIDCClientID         = errors.New("an OpenID Connect client ID is required along with the issuer")
+	errLDAPBaseDN           = errors.New("an LDAP base DN is required along with the directory URL")
+	errLDAPGroupRole        = errors.New("LDAP group roles must be formatted as group-DN=ROLE")
+)
+
 func action(ctx context.Context, cmd *cli.Command) error {
 	err := logging.Setup(
 		cmd.String(FlagLogLevel),
@@ -125,6 +139,7 @@ func initApp(ctx context.Context, cmd *cli.Command) (*grpc.Server, *sql.DB, *dat
 		JWTRefreshTTL: cmd.Duration(FlagRefreshTokenTTL),
 		DBCacheSize:   int(-cmd.Uint(FlagDBCacheSize)),
 		ArgonThreads:  uint(cmd.Uint(FlagArgonThreads)),
+		PushPrivate:   cmd.Bool(FlagPushPrivate),
 	}
 
 	vault, err := secrets.NewDirVault(cmd.String(FlagSecretsPath))
@@ -132,17 +147,64 @@ func initApp(ctx context.Context, cmd *cli.Command) (*grpc.Server, *sql.DB, *dat
 		return nil, nil, nil, err
 	}
 
+	if cmd.Bool(FlagDisableHTTPS) {
+		slog.WarnContext(ctx, "HTTPS is disabled, credentials will be sent in plaintext")
+	} else {
+		store, err := certs.Load(ctx, vault)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		go store.Watch(ctx, certs.ReloadInterval)
+		globalConf.TLS = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: store.GetCertificate,
+		}
+	}
+
+	if cmd.Bool(FlagClientCerts) {
+		if globalConf.TLS == nil {
+			return nil, nil, nil, errClientCertsPlaintext
+		}
+		// Registered certificates are usually self-signed, they get verified
+		// while authenticating instead.
+		globalConf.TLS.ClientAuth = tls.RequestClientCert
+		if globalConf.ClientCAs, err = certs.LoadClientCAs(vault); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	mailer, err := smtpMailer(cmd, vault)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if issuer := cmd.String(FlagOIDCIssuer); issuer != "" {
+		if cmd.String(FlagOIDCClientID) == "" {
+			return nil, nil, nil, errOIDCClientID
+		}
+		globalConf.OIDC = &oidc.Config{
+			Issuer:         issuer,
+			ClientID:       cmd.String(FlagOIDCClientID),
+			AllowedDomains: cmd.StringSlice(FlagOIDCDomains),
+			AutoProvision:  cmd.Bool(FlagOIDCProvision),
+		}
+	}
+
+	if globalConf.LDAP, err = ldapConfig(cmd, vault); err != nil {
+		return nil, nil, nil, err
+	}
+
 	db, err := database.Setup(ctx, cmd.String(FlagDBPath), globalConf)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	authMgr, err := auth.NewManager(vault, database.New(db), globalConf)
+	authMgr, err := auth.NewManager(ctx, vault, db, globalConf)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	server, queries, err := pb.Setup(ctx, authMgr, globalConf, db)
+	server, queries, err := pb.Setup(ctx, authMgr, globalConf, db, mailer)
 	if err != nil {
 		slog.ErrorContext(ctx, "Unable to setup gRPC server", logging.ErrKey, err)
 		return nil, nil, nil, err
@@ -150,3 +212,90 @@ func initApp(ctx context.Context, cmd *cli.Command) (*grpc.Server, *sql.DB, *dat
 
 	return server, db, queries, nil
 }
+
+// smtpMailer returns what sends emails through the SMTP relay (nil without
+// one). The password can be kept out of flags and the environment as a secret.
+func smtpMailer(cmd *cli.Command, vault secrets.SecretVault) (*mail.Mailer, error) {
+	host := cmd.String(FlagSMTPHost)
+	if host == "" {
+		return nil, nil
+	}
+
+	security, err := mail.ParseSecurity(cmd.String(FlagSMTPSecurity))
+	if err != nil {
+		return nil, err
+	}
+
+	password := cmd.String(FlagSMTPPassword)
+	if password == "" && cmd.String(FlagSMTPUsername) != "" {
+		secret, err := vault.Get("smtp-password")
+		if err != nil {
+			return nil, fmt.Errorf("no SMTP password given for user '%s': %w", cmd.String(FlagSMTPUsername), err)
+		}
+		password = strings.TrimSpace(secret.String())
+	}
+
+	from := cmd.String(FlagSMTPFrom)
+	if from == "" {
+		from = cmd.String(FlagSMTPUsername)
+	}
+
+	return mail.New(mail.Config{
+		Host:     host,
+		Port:     int(cmd.Uint(FlagSMTPPort)),
+		Username: cmd.String(FlagSMTPUsername),
+		Password: password,
+		From:     from,
+		Security: security,
+	})
+}
+
+// ldapConfig returns how to reach the directory checking passwords (nil
+// without one). Like the SMTP one, the bind password can be kept as a secret.
+func ldapConfig(cmd *cli.Command, vault secrets.SecretVault) (*directory.Config, error) {
+	url := cmd.String(FlagLDAPURL)
+	if url == "" {
+		return nil, nil
+	}
+	if cmd.String(FlagLDAPBaseDN) == "" {
+		return nil, errLDAPBaseDN
+	}
+
+	password := cmd.String(FlagLDAPBindPassword)
+	if password == "" && cmd.String(FlagLDAPBindDN) != "" {
+		secret, err := vault.Get("ldap-bind-password")
+		if err != nil {
+			return nil, fmt.Errorf("no LDAP password given for '%s': %w", cmd.String(FlagLDAPBindDN), err)
+		}
+		password = strings.TrimSpace(secret.String())
+	}
+
+	groupRoles := map[string]string{}
+	for _, mapping := range strings.Split(cmd.String(FlagLDAPGroupRoles), ";") {
+		if strings.TrimSpace(mapping) == "" {
+			continue
+		}
+		// DNs are full of '=' while roles have none
+		i := strings.LastIndex(mapping, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("%w: '%s'", errLDAPGroupRole, mapping)
+		}
+		group, role := strings.TrimSpace(mapping[:i]), strings.TrimSpace(mapping[i+1:])
+		if group == "" || role == "" {
+			return nil, fmt.Errorf("%w: '%s'", errLDAPGroupRole, mapping)
+		}
+		groupRoles[group] = role
+	}
+
+	return &directory.Config{
+		URL:          url,
+		StartTLS:     cmd.Bool(FlagLDAPStartTLS),
+		BindDN:       cmd.String(FlagLDAPBindDN),
+		BindPassword: password,
+		BaseDN:       cmd.String(FlagLDAPBaseDN),
+		UserFilter:   cmd.String(FlagLDAPUserFilter),
+		GroupBaseDN:  cmd.String(FlagLDAPGroupBaseDN),
+		GroupFilter:  cmd.String(FlagLDAPGroupFilter),
+		GroupRoles:   groupRoles,
+	}, nil
+}
@@ -8,7 +8,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ChausseBenjamin/rafta/internal/directory"
 	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/mail"
 	"github.com/ChausseBenjamin/rafta/internal/pb"
 	"github.com/urfave/cli/v3"
 )
@@ -18,6 +20,7 @@ const (
 	FlagConfigPath       = "config"
 	FlagDBPath           = "database"
 	FlagDisableHTTPS     = "disable-https"
+	FlagClientCerts      = "client-certificates"
 	FlagDisablePubSignup = "disable-public-signups"
 	FlagGraceTimeout     = "grace-timeout"
 	FlagListenPort       = "port"
@@ -32,6 +35,26 @@ const (
 	FlagRefreshTokenTTL  = "refresh-token-time-to-live"
 	FlagDBCacheSize      = "database-cache-size"
 	FlagArgonThreads     = "argon-threads"
+	FlagPushPrivate      = "push-allow-private-networks"
+	FlagSMTPHost         = "smtp-host"
+	FlagSMTPPort         = "smtp-port"
+	FlagSMTPUsername     = "smtp-username"
+	FlagSMTPPassword     = "smtp-password"
+	FlagSMTPFrom         = "smtp-from"
+	FlagSMTPSecurity     = "smtp-security"
+	FlagOIDCIssuer       = "oidc-issuer"
+	FlagOIDCClientID     = "oidc-client-id"
+	FlagOIDCDomains      = "oidc-allowed-domains"
+	FlagOIDCProvision    = "oidc-auto-provision"
+	FlagLDAPURL          = "ldap-url"
+	FlagLDAPStartTLS     = "ldap-start-tls"
+	FlagLDAPBindDN       = "ldap-bind-dn"
+	FlagLDAPBindPassword = "ldap-bind-password"
+	FlagLDAPBaseDN       = "ldap-base-dn"
+	FlagLDAPUserFilter   = "ldap-user-filter"
+	FlagLDAPGroupBaseDN  = "ldap-group-base-dn"
+	FlagLDAPGroupFilter  = "ldap-group-filter"
+	FlagLDAPGroupRoles   = "ldap-group-roles"
 )
 
 func flags() []cli.Flag {
@@ -69,12 +92,17 @@ func flags() []cli.Flag {
 			Sources: cli.EnvVars("LISTEN_PORT"),
 			Action:  validateListenPort,
 		},
-		&cli.BoolFlag{ // TODO: Implement https
+		&cli.BoolFlag{
 			Name:    FlagDisableHTTPS,
 			Value:   false,
-			Usage:   `Disable secure https communication. WARNING: Be very careful using this. Only do this if your server is behind a reverse proxy that already handles https for it and you trust all network communications on that network.`,
+			Usage:   `Disable secure https communication. WARNING: Be very careful using this. Only do this if your server is behind a reverse proxy that already handles https for it and you trust all network communications on that network. Otherwise, the certificate is read from the tls-cert and tls-key secrets (PEM) and reloaded when they change. A self-signed one gets generated when they don't exist.`,
 			Sources: cli.EnvVars("DISABLE_HTTPS"),
 		},
+		&cli.BoolFlag{
+			Name:    FlagClientCerts,
+			Usage:   "Let clients authenticate with TLS client certificates: the ones users register and, when the tls-client-ca secret exists, the ones it issued for the email of a user",
+			Sources: cli.EnvVars("CLIENT_CERTIFICATES"),
+		},
 		&cli.DurationFlag{
 			Name:    FlagGraceTimeout,
 			Aliases: []string{"t"},
@@ -143,6 +171,114 @@ func flags() []cli.Flag {
 			Value:   0,
 			Sources: cli.EnvVars("ARGON_THREADS"),
 		}, // }}}
+		// Push notifications {{{
+		&cli.BoolFlag{
+			Name:    FlagPushPrivate,
+			Usage:   "Let push endpoints resolve to loopback and private addresses (ex: a ntfy server on the LAN). Users can then make the server send requests inside its network",
+			Sources: cli.EnvVars("PUSH_ALLOW_PRIVATE_NETWORKS"),
+		}, // }}}
+		// Email {{{
+		&cli.StringFlag{
+			Name:    FlagSMTPHost,
+			Usage:   "SMTP relay used to email users (ex: daily digests). Emails are disabled when empty",
+			Sources: cli.EnvVars("SMTP_HOST"),
+		},
+		&cli.UintFlag{
+			Name:    FlagSMTPPort,
+			Value:   587,
+			Sources: cli.EnvVars("SMTP_PORT"),
+		},
+		&cli.StringFlag{
+			Name:    FlagSMTPUsername,
+			Usage:   "Username to authenticate with on the SMTP relay (no authentication when empty)",
+			Sources: cli.EnvVars("SMTP_USERNAME"),
+		},
+		&cli.StringFlag{
+			Name:    FlagSMTPPassword,
+			Usage:   "Password to authenticate with on the SMTP relay. Read from the smtp-password secret when empty",
+			Sources: cli.EnvVars("SMTP_PASSWORD"),
+		},
+		&cli.StringFlag{
+			Name:    FlagSMTPFrom,
+			Usage:   `Sender of the emails (ex: "Rafta <rafta@example.com>")`,
+			Sources: cli.EnvVars("SMTP_FROM"),
+		},
+		&cli.StringFlag{
+			Name:    FlagSMTPSecurity,
+			Value:   "starttls",
+			Usage:   "starttls, tls, none",
+			Sources: cli.EnvVars("SMTP_SECURITY"),
+			Action:  validateSMTPSecurity,
+		}, // }}}
+		// Single sign-on {{{
+		&cli.StringFlag{
+			Name:    FlagOIDCIssuer,
+			Usage:   "OpenID Connect provider users can log in with, written exactly as its ID tokens name it (ex: https://auth.example.com). Disabled when empty",
+			Sources: cli.EnvVars("OIDC_ISSUER"),
+		},
+		&cli.StringFlag{
+			Name:    FlagOIDCClientID,
+			Usage:   "Client ID of rafta at the OpenID Connect provider",
+			Sources: cli.EnvVars("OIDC_CLIENT_ID"),
+		},
+		&cli.StringSliceFlag{
+			Name:    FlagOIDCDomains,
+			Usage:   "Email domains allowed to log in through the OpenID Connect provider (any when empty)",
+			Sources: cli.EnvVars("OIDC_ALLOWED_DOMAINS"),
+		},
+		&cli.BoolFlag{
+			Name:    FlagOIDCProvision,
+			Usage:   "Create accounts for users of the OpenID Connect provider logging in for the first time",
+			Sources: cli.EnvVars("OIDC_AUTO_PROVISION"),
+		}, // }}}
+		// LDAP {{{
+		&cli.StringFlag{
+			Name:    FlagLDAPURL,
+			Usage:   "LDAP directory checking the passwords of users (ex: ldaps://ldap.example.com). Disabled when empty",
+			Sources: cli.EnvVars("LDAP_URL"),
+		},
+		&cli.BoolFlag{
+			Name:    FlagLDAPStartTLS,
+			Usage:   "Upgrade ldap:// connections to the directory with StartTLS",
+			Sources: cli.EnvVars("LDAP_START_TLS"),
+		},
+		&cli.StringFlag{
+			Name:    FlagLDAPBindDN,
+			Usage:   "DN of the account searching the directory (anonymous when empty)",
+			Sources: cli.EnvVars("LDAP_BIND_DN"),
+		},
+		&cli.StringFlag{
+			Name:    FlagLDAPBindPassword,
+			Usage:   "Password of the account searching the directory (read from the ldap-bind-password secret when empty)",
+			Sources: cli.EnvVars("LDAP_BIND_PASSWORD"),
+		},
+		&cli.StringFlag{
+			Name:    FlagLDAPBaseDN,
+			Usage:   "DN under which users are searched for (ex: ou=people,dc=example,dc=com)",
+			Sources: cli.EnvVars("LDAP_BASE_DN"),
+		},
+		&cli.StringFlag{
+			Name:    FlagLDAPUserFilter,
+			Value:   directory.DefaultUserFilter,
+			Usage:   "Filter finding the user a login belongs to (%s is replaced by the login)",
+			Sources: cli.EnvVars("LDAP_USER_FILTER"),
+		},
+		&cli.StringFlag{
+			Name:    FlagLDAPGroupBaseDN,
+			Usage:   "DN under which groups are searched for (the memberOf attribute of users is used when empty)",
+			Sources: cli.EnvVars("LDAP_GROUP_BASE_DN"),
+		},
+		&cli.StringFlag{
+			Name:    FlagLDAPGroupFilter,
+			Value:   directory.DefaultGroupFilter,
+			Usage:   "Filter finding the groups of a user (%s is replaced by the DN of the user)",
+			Sources: cli.EnvVars("LDAP_GROUP_FILTER"),
+		},
+		&cli.StringFlag{
+			Name:    FlagLDAPGroupRoles,
+			Usage:   "Roles granted to members of directory groups, by group DN and separated by semicolons (ex: cn=rafta-admins,ou=groups,dc=example,dc=com=ADMIN)",
+			Sources: cli.EnvVars("LDAP_GROUP_ROLES"),
+		}, // }}}
 	}
 }
 
@@ -186,6 +322,14 @@ func validateLogFormat(ctx context.Context, cmd *cli.Command, s string) error {
 	return nil
 }
 
+func validateSMTPSecurity(ctx context.Context, cmd *cli.Command, s string) error {
+	if _, err := mail.ParseSecurity(s); err != nil {
+		slog.ErrorContext(ctx, "Unknown SMTP security provided", logging.ErrKey, err)
+		return err
+	}
+	return nil
+}
+
 func validateListenPort(ctx context.Context, cmd *cli.Command, p int64) error {
 	if p < 1024 || p > 65535 {
 		slog.ErrorContext(
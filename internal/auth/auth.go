@@ -4,6 +4,7 @@ package auth
 import (
 	"context"
 	"crypto/ed25519"
+	"crypto/x509"
 	"database/sql"
 	"encoding/base64"
 	"errors"
@@ -13,16 +14,20 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ChausseBenjamin/rafta/internal/certs"
 	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/directory"
+	"github.com/ChausseBenjamin/rafta/internal/intercept"
 	"github.com/ChausseBenjamin/rafta/internal/logging"
-	"github.com/ChausseBenjamin/rafta/internal/sec"
 	"github.com/ChausseBenjamin/rafta/internal/secrets"
 	"github.com/ChausseBenjamin/rafta/internal/util"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -33,34 +38,41 @@ const (
 	AccessTokenType  tokenType = "access"
 	RefreshTokenType tokenType = "refresh"
 	BasicTokenType   tokenType = "basic"
+	// Accepted wherever access tokens are, within the scopes of the token
+	PersonalTokenType tokenType = "personal"
 )
 
 var (
 	errTokenAlg     = errors.New("received token uses an unsupported signing method")
 	errPrivKeyStore = errors.New("failed to store private key in vault")
 	errPubKeyStore  = errors.New("failed to store public key in vault")
+	errUnknownRole  = errors.New("directory groups are mapped to an unknown role")
 )
 
 type AuthManager struct {
-	pubKey  secrets.Secret
-	privKey secrets.Secret
-	db      *database.Queries
-	cfg     *util.ConfigStore
+	pubKey   secrets.Secret
+	privKey  secrets.Secret
+	db       *database.Queries
+	cfg      *util.ConfigStore
+	verifier CredentialVerifier
 }
 
 type tokenType string
 
 type Claims struct {
-	Roles []string  `json:"roles"`
-	Type  tokenType `json:"typ"`
+	Roles   []string  `json:"roles"`
+	Type    tokenType `json:"typ"`
+	Session string    `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
 // Credendials are what get sent to the protobuf server. This is done to
 // minimize parsing by already having required fields in the uuid.UUID format
 type Credendials struct {
-	Subject uuid.UUID
-	ID      uuid.UUID
+	Subject   uuid.UUID
+	ID        uuid.UUID
+	SessionID uuid.UUID // uuid.Nil outside of sessions
+	Scopes    []string  // Personal access tokens only
 	Claims
 }
 
@@ -73,29 +85,54 @@ func (a *AuthManager) Authenticating() grpc.UnaryServerInterceptor {
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (resp any, err error) {
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Errorf(codes.Unauthenticated, "missing metadata")
+		ctx, err = a.authenticate(ctx)
+		if err != nil {
+			return nil, err
 		}
+		return handler(ctx, req)
+	}
+}
 
-		tokenMetadata := md["authorization"]
-		if len(tokenMetadata) == 0 {
-			return handler(ctx, req)
+// StreamAuthenticating is the streaming counterpart of Authenticating.
+func (a *AuthManager) StreamAuthenticating() grpc.StreamServerInterceptor {
+	return func(srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := a.authenticate(ss.Context())
+		if err != nil {
+			return err
 		}
+		return handler(srv, intercept.WithContext(ss, ctx))
+	}
+}
 
-		authHeader := tokenMetadata[0]
-		switch strings.ToLower(strings.Split(tokenMetadata[0], " ")[0]) {
-		case "bearer":
-			return a.handleBearerAuth(ctx, req, handler, authHeader)
-		case "basic":
-			return a.handleBasicAuth(ctx, req, handler, authHeader)
-		default:
-			return nil, status.Errorf(codes.Unauthenticated, "unsupported authorization method")
-		}
+// authenticate returns the context of a request with the credentials it
+// carries (left as is when it carries none).
+func (a *AuthManager) authenticate(ctx context.Context) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "missing metadata")
+	}
+
+	tokenMetadata := md["authorization"]
+	if len(tokenMetadata) == 0 {
+		return a.handleCertAuth(ctx)
+	}
+
+	authHeader := tokenMetadata[0]
+	switch strings.ToLower(strings.Split(tokenMetadata[0], " ")[0]) {
+	case "bearer":
+		return a.handleBearerAuth(ctx, authHeader)
+	case "basic":
+		return a.handleBasicAuth(ctx, authHeader)
+	default:
+		return nil, status.Errorf(codes.Unauthenticated, "unsupported authorization method")
 	}
 }
 
-func (a *AuthManager) handleBasicAuth(ctx context.Context, req any, handler grpc.UnaryHandler, authHeader string) (any, error) {
+func (a *AuthManager) handleBasicAuth(ctx context.Context, authHeader string) (context.Context, error) {
 	encodedCreds := strings.TrimPrefix(authHeader, "Basic ")
 	decodedCreds, err := base64.StdEncoding.DecodeString(encodedCreds)
 	if err != nil {
@@ -108,24 +145,86 @@ func (a *AuthManager) handleBasicAuth(ctx context.Context, req any, handler grpc
 		return nil, status.Errorf(codes.Unauthenticated, "invalid basic auth format")
 	}
 
-	userSecret, err := a.db.GetUserSecretsFromEmail(ctx, credsParts[0])
+	userID, err := a.verifier.Verify(ctx, credsParts[0], credsParts[1])
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, status.Error(codes.NotFound, "User does not exist")
+		return nil, err
+	}
+
+	return a.withBasicCreds(ctx, userID)
+}
+
+// handleCertAuth authenticates requests made over a connection presenting a
+// TLS client certificate. Registered certificates are trusted as is while the
+// others must be issued by a client CA for the email of a user. Requests are
+// left unauthenticated (as without a certificate) when none of them applies
+// so that public RPCs keep working.
+func (a *AuthManager) handleCertAuth(ctx context.Context) (context.Context, error) {
+	chain := PeerCertificates(ctx)
+	if len(chain) == 0 {
+		return ctx, nil
+	}
+	cert := chain[0]
+	fingerprint := certs.Fingerprint(cert)
+	log := slog.With("fingerprint", fingerprint)
+
+	now := time.Now()
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		log.WarnContext(ctx, "Ignoring client certificate that is expired or not yet valid")
+		return ctx, nil
+	}
+
+	registered, err := a.db.GetClientCertificate(ctx, fingerprint)
+	switch {
+	case err == nil:
+		if err := a.db.TouchClientCertificate(ctx, registered.CertID); err != nil {
+			log.ErrorContext(ctx, "Failed to record client certificate use", logging.ErrKey, err)
 		}
-		return nil, status.Error(codes.Internal, "Failed to query user credentials")
+		return a.withBasicCreds(ctx, registered.UserID)
+	case !errors.Is(err, sql.ErrNoRows):
+		log.ErrorContext(ctx, "Failed to query client certificates", logging.ErrKey, err)
+		return nil, status.Error(codes.Internal, "Failed to query client certificates")
 	}
-	err = sec.ValidateCredsWithThreads(credsParts[1], userSecret.Hash, userSecret.Salt, getArgonThreads(a.cfg.ArgonThreads))
-	if err != nil {
-		if errors.Is(err, sec.ErrInvalidCreds) {
-			return nil, status.Error(codes.Unauthenticated, "Invalid credentials provided")
+
+	if a.cfg.ClientCAs == nil {
+		log.DebugContext(ctx, "Ignoring unknown client certificate")
+		return ctx, nil
+	}
+	if err := certs.VerifyClient(chain, a.cfg.ClientCAs, x509.ExtKeyUsageClientAuth); err != nil {
+		log.WarnContext(ctx, "Ignoring untrusted client certificate", logging.ErrKey, err)
+		return ctx, nil
+	}
+	for _, email := range cert.EmailAddresses {
+		user, err := a.db.GetUserFromEmail(ctx, email)
+		if err == nil {
+			return a.withBasicCreds(ctx, user.UserID)
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.Internal, "Failed to query user credentials")
 		}
-		return nil, status.Error(codes.Unauthenticated, "Invalid credentials format provided")
 	}
+	log.WarnContext(ctx, "Ignoring client certificate matching no user")
+	return ctx, nil
+}
+
+// PeerCertificates returns the certificate chain the client presented during
+// the TLS handshake of the connection a request came through (nil without
+// one). Clients proved they hold the key of its first certificate.
+func PeerCertificates(ctx context.Context) []*x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return nil
+	}
+	return tlsInfo.State.PeerCertificates
+}
 
-	// No errors mean successful hash validation, user credentials are stored in a "fake" jwt token
-	// to streamline behaviour.
-	roles, err := a.db.GetUserRoles(ctx, userSecret.UserID)
+// withBasicCreds stores the credentials of a user who proved their identity
+// in a "fake" jwt token to streamline behaviour.
+func (a *AuthManager) withBasicCreds(ctx context.Context, userID uuid.UUID) (context.Context, error) {
+	roles, err := a.db.GetUserRoles(ctx, userID)
 	if err != nil {
 		if !errors.Is(err, sql.ErrNoRows) {
 			return nil, status.Errorf(codes.Internal, "Failed to retrieve user roles")
@@ -134,19 +233,21 @@ func (a *AuthManager) handleBasicAuth(ctx context.Context, req any, handler grpc
 	}
 
 	creds := &Credendials{
-		Subject: userSecret.UserID,
+		Subject: userID,
 		Claims: Claims{
 			Roles: roles,
 			Type:  BasicTokenType,
 		},
 	}
 
-	newCtx := context.WithValue(ctx, util.CredsKey, creds)
-	return handler(newCtx, req)
+	return context.WithValue(ctx, util.CredsKey, creds), nil
 }
 
-func (a *AuthManager) handleBearerAuth(ctx context.Context, req any, handler grpc.UnaryHandler, authHeader string) (any, error) {
+func (a *AuthManager) handleBearerAuth(ctx context.Context, authHeader string) (context.Context, error) {
 	tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
+	if strings.HasPrefix(tokenStr, PersonalTokenPrefix) {
+		return a.handlePersonalToken(ctx, tokenStr)
+	}
 
 	token, err := jwt.ParseWithClaims(tokenStr, &Claims{}, func(token *jwt.Token) (any, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodEd25519); !ok {
@@ -161,7 +262,7 @@ func (a *AuthManager) handleBearerAuth(ctx context.Context, req any, handler grp
 
 	if tokenWithClaims, ok := token.Claims.(*Claims); !ok {
 		slog.WarnContext(ctx, "Unable to extract custom claims from JWT")
-		return handler(ctx, req)
+		return ctx, nil
 	} else {
 		tokenID, err := util.ParseUUID(ctx, util.ParseUUIDParams{
 			Str: tokenWithClaims.ID, Subject: "jwt_id",
@@ -170,7 +271,12 @@ func (a *AuthManager) handleBearerAuth(ctx context.Context, req any, handler grp
 		if err != nil {
 			return nil, err
 		}
-		revoked, err := a.db.TokenIsRevoked(ctx, tokenID)
+		issued, err := a.db.GetIssuedToken(ctx, tokenID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.Unauthenticated,
+				"provided token is unknown",
+			)
+		}
 		if err != nil {
 			slog.ErrorContext(ctx, "failed to ensure provided token is not revoked",
 				logging.ErrKey, err,
@@ -180,7 +286,10 @@ func (a *AuthManager) handleBearerAuth(ctx context.Context, req any, handler grp
 				"failure while ensuring token isn't revoked",
 			)
 		}
-		if revoked {
+		if issued.RevokedOn.Valid {
+			if issued.Type == string(RefreshTokenType) {
+				a.refreshTokenReused(ctx, issued.UserID, issued.FamilyID)
+			}
 			return nil, status.Error(codes.Unauthenticated,
 				"provided token has been revoked",
 			)
@@ -194,27 +303,41 @@ func (a *AuthManager) handleBearerAuth(ctx context.Context, req any, handler grp
 			return nil, err
 		}
 
+		sessionID, err := a.activeSession(ctx, tokenWithClaims.Session)
+		if err != nil {
+			return nil, err
+		}
+
 		creds := &Credendials{
-			Subject: userID,
-			ID:      tokenID,
-			Claims:  *tokenWithClaims,
+			Subject:   userID,
+			ID:        tokenID,
+			SessionID: sessionID,
+			Claims:    *tokenWithClaims,
 		}
 
-		return handler(context.WithValue(ctx, util.CredsKey, creds), req)
+		return context.WithValue(ctx, util.CredsKey, creds), nil
 	}
 }
 
-// Issue generates and returns a new access token and refresh token for the given user ID and roles.
-// It returns the access token string, refresh token string, and an error if any occurs during the process.
-func (a *AuthManager) Issue(userID uuid.UUID, roles []string) (string, string, error) {
+// issue generates, records and returns a new access token and refresh token for the given user ID
+// within a session (the family of the tokens). Roles are always read from the database so that
+// role changes can't be carried over by refreshing. It returns the access token string, refresh
+// token string, the claims of the refresh token and an error if any occurs during the process.
+func (a *AuthManager) issue(ctx context.Context, userID uuid.UUID, sessionID uuid.UUID) (string, string, *Claims, error) {
+	roles, err := a.db.GetUserRoles(ctx, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", "", nil, fmt.Errorf("failed to retrieve user roles: %v", err)
+	}
+
 	now := time.Now().UTC()
 	accessID := uuid.New()
 	refreshID := uuid.New()
 
 	// Create the accessClaims
 	accessClaims := Claims{
-		Roles: roles,
-		Type:  AccessTokenType,
+		Roles:   roles,
+		Type:    AccessTokenType,
+		Session: sessionID.String(),
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    issuer,
 			Subject:   userID.String(),
@@ -228,13 +351,14 @@ func (a *AuthManager) Issue(userID uuid.UUID, roles []string) (string, string, e
 	accessToken := jwt.NewWithClaims(jwt.SigningMethodEdDSA, accessClaims)
 	accessTokenString, err := accessToken.SignedString(ed25519.PrivateKey(a.privKey))
 	if err != nil {
-		return "", "", fmt.Errorf("failed to sign access token: %v", err)
+		return "", "", nil, fmt.Errorf("failed to sign access token: %v", err)
 	}
 
 	// Create the refresh token claims
 	refreshClaims := Claims{
-		Roles: roles,
-		Type:  RefreshTokenType,
+		Roles:   roles,
+		Type:    RefreshTokenType,
+		Session: sessionID.String(),
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   userID.String(),
 			ExpiresAt: jwt.NewNumericDate(now.Add(a.cfg.JWTRefreshTTL)),
@@ -247,10 +371,27 @@ func (a *AuthManager) Issue(userID uuid.UUID, roles []string) (string, string, e
 	refreshToken := jwt.NewWithClaims(jwt.SigningMethodEdDSA, refreshClaims)
 	refreshTokenString, err := refreshToken.SignedString(ed25519.PrivateKey(a.privKey))
 	if err != nil {
-		return "", "", fmt.Errorf("failed to sign refresh token: %v", err)
+		return "", "", nil, fmt.Errorf("failed to sign refresh token: %v", err)
+	}
+
+	issued := []struct {
+		id     uuid.UUID
+		claims Claims
+	}{{accessID, accessClaims}, {refreshID, refreshClaims}}
+	for _, token := range issued {
+		err := a.db.IssueToken(ctx, database.IssueTokenParams{
+			TokenID:  token.id,
+			FamilyID: sessionID,
+			UserID:   userID,
+			Type:     string(token.claims.Type),
+			Expiry:   token.claims.ExpiresAt.UTC(),
+		})
+		if err != nil {
+			return "", "", nil, fmt.Errorf("failed to record issued token: %v", err)
+		}
 	}
 
-	return accessTokenString, refreshTokenString, nil
+	return accessTokenString, refreshTokenString, &refreshClaims, nil
 }
 
 // Since there are some encpoints that don't require authentication (Signup)
@@ -273,6 +414,10 @@ func GetCreds(ctx context.Context, expects tokenType) (*Credendials, error) {
 		return nil, status.Error(codes.Unauthenticated,
 			"Current endpoint requires JWT authentication to proceed and found none. Operation aborted",
 		)
+	} else if creds.Type == PersonalTokenType && expects == AccessTokenType {
+		if err := scopeAllows(ctx, creds); err != nil {
+			return nil, err
+		}
 	} else {
 		if creds.Type != expects {
 			slog.WarnContext(ctx,
@@ -298,7 +443,9 @@ func getArgonThreads(configThreads uint) uint8 {
 	return uint8(configThreads)
 }
 
-func NewManager(vault secrets.SecretVault, db *database.Queries, cfg *util.ConfigStore) (*AuthManager, error) {
+func NewManager(ctx context.Context, vault secrets.SecretVault, sqlDB *sql.DB, cfg *util.ConfigStore) (*AuthManager, error) {
+	db := database.New(sqlDB)
+
 	pubkey, pubErr := vault.Get("server-pubkey")
 	privkey, privErr := vault.Get("server-privkey")
 	if pubErr != nil || privErr != nil {
@@ -320,37 +467,37 @@ func NewManager(vault secrets.SecretVault, db *database.Queries, cfg *util.Confi
 		privkey = secrets.Secret(privateKey)
 	}
 
-	return &AuthManager{
-		pubKey:  pubkey,
-		privKey: privkey,
-		db:      db,
-		cfg:     cfg,
-	}, nil
-}
-
-func (a *AuthManager) RevokeToken(ctx context.Context, token *Claims) error {
-	tokenID, err := uuid.Parse(token.ID)
-	if err != nil {
-		slog.ErrorContext(ctx, "Failed to parse token ID", logging.ErrKey, err)
-		return status.Error(codes.InvalidArgument, "Invalid token ID")
-	}
-
-	err = a.db.RevokeToken(ctx, database.RevokeTokenParams{
-		TokenID: tokenID,
-		Expiry:  token.ExpiresAt.UTC(),
-	})
-	if err != nil {
-		slog.ErrorContext(ctx, "Failed to revoke token", logging.ErrKey, err)
-		return status.Error(codes.Internal, "Token revocation failure")
-	}
-
-	err = a.db.CleanupExpiredToken(ctx, tokenID)
-	if err != nil {
-		slog.ErrorContext(ctx, "Failed to cleanup expired token", logging.ErrKey, err)
-		// client shouldn't be concerned with internal housekeeping, don't return an error
+	var verifier CredentialVerifier = &passwordVerifier{db: db, cfg: cfg}
+	if cfg.LDAP != nil {
+		dir, err := directory.New(*cfg.LDAP)
+		if err != nil {
+			return nil, err
+		}
+		for _, role := range dir.ManagedRoles() {
+			exists, err := db.RoleExists(ctx, role)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check role '%s' exists: %w", role, err)
+			}
+			if !exists {
+				return nil, fmt.Errorf("%w: '%s'", errUnknownRole, role)
+			}
+		}
+		verifier = &ldapVerifier{
+			dir:   dir,
+			sqlDB: sqlDB,
+			db:    db,
+			cfg:   cfg,
+			local: verifier,
+		}
 	}
 
-	return nil
+	return &AuthManager{
+		pubKey:   pubkey,
+		privKey:  privkey,
+		db:       db,
+		cfg:      cfg,
+		verifier: verifier,
+	}, nil
 }
 
 func (a *AuthManager) ValidatePasswd(p string) error {
@@ -0,0 +1,174 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/util"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Personal access tokens are long-lived secrets scripts authenticate with as
+// Bearer tokens. They are told apart from JWTs by their prefix.
+const PersonalTokenPrefix = "rafta_pat_"
+
+const (
+	ScopeTasksRead  = "tasks:read"
+	ScopeTasksWrite = "tasks:write"
+	ScopeAccount    = "account"
+	ScopeAdmin      = "admin"
+)
+
+var Scopes = []string{ScopeTasksRead, ScopeTasksWrite, ScopeAccount, ScopeAdmin}
+
+// methodScopes lists the endpoints personal access tokens can be used for.
+// The others (ex: changing the password or issuing more tokens) require the
+// user to log in.
+var methodScopes = map[string]string{
+	m.Rafta_GetAllTasks_FullMethodName:        ScopeTasksRead,
+	m.Rafta_GetTask_FullMethodName:            ScopeTasksRead,
+	m.Rafta_GetAssignedTasks_FullMethodName:   ScopeTasksRead,
+	m.Rafta_GetTaskAssignments_FullMethodName: ScopeTasksRead,
+	m.Rafta_ParseQuickAdd_FullMethodName:      ScopeTasksRead,
+	m.Rafta_GetTimeEntries_FullMethodName:     ScopeTasksRead,
+	m.Rafta_GetTimeTotals_FullMethodName:      ScopeTasksRead,
+	m.Rafta_GetFilters_FullMethodName:         ScopeTasksRead,
+	m.Rafta_EvaluateFilter_FullMethodName:     ScopeTasksRead,
+	m.Rafta_GetTemplates_FullMethodName:       ScopeTasksRead,
+	m.Rafta_ExportTemplate_FullMethodName:     ScopeTasksRead,
+	m.Rafta_GetCustomFields_FullMethodName:    ScopeTasksRead,
+	m.Rafta_GetAgenda_FullMethodName:          ScopeTasksRead,
+
+	m.Rafta_NewTask_FullMethodName:             ScopeTasksWrite,
+	m.Rafta_DeleteTask_FullMethodName:          ScopeTasksWrite,
+	m.Rafta_UpdateTask_FullMethodName:          ScopeTasksWrite,
+	m.Rafta_ToggleChecklistItem_FullMethodName: ScopeTasksWrite,
+	m.Rafta_QuickAddTask_FullMethodName:        ScopeTasksWrite,
+	m.Rafta_StartTimer_FullMethodName:          ScopeTasksWrite,
+	m.Rafta_StopTimer_FullMethodName:           ScopeTasksWrite,
+	m.Rafta_NewTimeEntry_FullMethodName:        ScopeTasksWrite,
+	m.Rafta_UpdateTimeEntry_FullMethodName:     ScopeTasksWrite,
+	m.Rafta_DeleteTimeEntry_FullMethodName:     ScopeTasksWrite,
+	m.Rafta_NewFilter_FullMethodName:           ScopeTasksWrite,
+	m.Rafta_UpdateFilter_FullMethodName:        ScopeTasksWrite,
+	m.Rafta_DeleteFilter_FullMethodName:        ScopeTasksWrite,
+	m.Rafta_NewTemplate_FullMethodName:         ScopeTasksWrite,
+	m.Rafta_UpdateTemplate_FullMethodName:      ScopeTasksWrite,
+	m.Rafta_DeleteTemplate_FullMethodName:      ScopeTasksWrite,
+	m.Rafta_InstantiateTemplate_FullMethodName: ScopeTasksWrite,
+	m.Rafta_ImportTemplate_FullMethodName:      ScopeTasksWrite,
+	m.Rafta_SnoozeTask_FullMethodName:          ScopeTasksWrite,
+	m.Rafta_NewCustomField_FullMethodName:      ScopeTasksWrite,
+	m.Rafta_UpdateCustomField_FullMethodName:   ScopeTasksWrite,
+	m.Rafta_DeleteCustomField_FullMethodName:   ScopeTasksWrite,
+	m.Rafta_MoveTask_FullMethodName:            ScopeTasksWrite,
+
+	m.Rafta_GetUserInfo_FullMethodName:        ScopeAccount,
+	m.Rafta_UpdateUserInfo_FullMethodName:     ScopeAccount,
+	m.Rafta_GetPreferences_FullMethodName:     ScopeAccount,
+	m.Rafta_UpdatePreferences_FullMethodName:  ScopeAccount,
+	m.Rafta_GetSettings_FullMethodName:        ScopeAccount,
+	m.Rafta_SetSettings_FullMethodName:        ScopeAccount,
+	m.Rafta_WatchSettings_FullMethodName:      ScopeAccount,
+	m.Rafta_NewPushEndpoint_FullMethodName:    ScopeAccount,
+	m.Rafta_GetPushEndpoints_FullMethodName:   ScopeAccount,
+	m.Rafta_UpdatePushEndpoint_FullMethodName: ScopeAccount,
+	m.Rafta_DeletePushEndpoint_FullMethodName: ScopeAccount,
+	m.Rafta_TestPushEndpoint_FullMethodName:   ScopeAccount,
+
+	m.Admin_GetAllUsers_FullMethodName:       ScopeAdmin,
+	m.Admin_GetUser_FullMethodName:           ScopeAdmin,
+	m.Admin_GetUserTasks_FullMethodName:      ScopeAdmin,
+	m.Admin_UpdateCredentials_FullMethodName: ScopeAdmin,
+	m.Admin_NewUser_FullMethodName:           ScopeAdmin,
+	m.Admin_DeleteUser_FullMethodName:        ScopeAdmin,
+	m.Admin_UpdateUser_FullMethodName:        ScopeAdmin,
+	m.Admin_GetUserRoles_FullMethodName:      ScopeAdmin,
+	m.Admin_UpdateUserRoles_FullMethodName:   ScopeAdmin,
+	m.Admin_ResetTOTP_FullMethodName:         ScopeAdmin,
+}
+
+// NewPersonalToken generates the secret of a personal access token along with
+// the hash to store (the secret itself is only shown once to the user).
+func NewPersonalToken() (secret, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	secret = PersonalTokenPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	return secret, hashPersonalToken(secret), nil
+}
+
+// Personal access tokens are random enough for a fast hash to do (unlike
+// passwords), which matters since it runs on every request.
+func hashPersonalToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func (a *AuthManager) handlePersonalToken(ctx context.Context, secret string) (context.Context, error) {
+	token, err := a.db.GetPersonalTokenFromHash(ctx, hashPersonalToken(secret))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.Unauthenticated, "Invalid personal access token")
+		}
+		slog.ErrorContext(ctx, "Failed to query personal access tokens", logging.ErrKey, err)
+		return nil, status.Error(codes.Internal, "Failed to query personal access tokens")
+	}
+	if token.ExpiresOn.Valid && time.Now().After(token.ExpiresOn.Time) {
+		return nil, status.Error(codes.Unauthenticated, "Personal access token has expired")
+	}
+
+	if err := a.db.TouchPersonalToken(ctx, token.TokenID); err != nil {
+		slog.ErrorContext(ctx, "Failed to record personal access token use", logging.ErrKey, err)
+	}
+
+	roles, err := a.db.GetUserRoles(ctx, token.UserID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve user roles")
+	}
+
+	creds := &Credendials{
+		Subject: token.UserID,
+		ID:      token.TokenID,
+		Scopes:  strings.Fields(token.Scopes),
+		Claims: Claims{
+			Roles: roles,
+			Type:  PersonalTokenType,
+		},
+	}
+
+	return context.WithValue(ctx, util.CredsKey, creds), nil
+}
+
+// scopeAllows checks personal access tokens used in place of access tokens
+// were granted the scope of the endpoint they are used for.
+func scopeAllows(ctx context.Context, creds *Credendials) error {
+	method, _ := ctx.Value(util.ProtoMethodKey).(string)
+	scope, ok := methodScopes[method]
+	if !ok {
+		slog.WarnContext(ctx, "Personal access token used for an endpoint requiring a login")
+		return status.Error(codes.PermissionDenied,
+			"Personal access tokens can't be used for this endpoint, log in instead",
+		)
+	}
+	if !slices.Contains(creds.Scopes, scope) {
+		slog.WarnContext(ctx, "Personal access token lacks the required scope", "scope", scope)
+		return status.Errorf(codes.PermissionDenied,
+			"Personal access token lacks the '%s' scope required by this endpoint", scope,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/util"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Clients name the device they log in from through this metadata (their user
+// agent is used otherwise).
+const DeviceMetadataKey = "device-name"
+
+const maxDeviceLen = 128
+
+// Kind of the security event recorded when a revoked refresh token is used
+const SecurityEventRefreshReuse = "refresh_token_reuse"
+
+// StartSession opens a session for a user who just proved their identity and
+// issues its first pair of tokens.
+func (a *AuthManager) StartSession(ctx context.Context, userID uuid.UUID) (string, string, error) {
+	// Housekeeping, sessions of users who never come back are left behind
+	err := a.db.DeleteExpiredUserSessions(ctx, database.DeleteExpiredUserSessionsParams{
+		UserID: userID,
+		Now:    time.Now().UTC(),
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to delete expired sessions", logging.ErrKey, err)
+	}
+
+	sessionID := uuid.New()
+	access, refresh, claims, err := a.issue(ctx, userID, sessionID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failure during JWT pair generation", logging.ErrKey, err)
+		return "", "", status.Error(codes.Internal, "Failure during JWT generation")
+	}
+
+	_, err = a.db.NewSession(ctx, database.NewSessionParams{
+		SessionID: sessionID,
+		UserID:    userID,
+		Device:    clientDevice(ctx),
+		Ip:        clientIP(ctx),
+		ExpiresOn: claims.ExpiresAt.UTC(),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to save session", logging.ErrKey, err)
+		return "", "", status.Error(codes.Internal, "Failed to open session")
+	}
+
+	slog.DebugContext(ctx, "Opened session", "session_id", sessionID)
+	return access, refresh, nil
+}
+
+// RenewSession rotates the refresh token of a session: the token making the
+// request gets revoked and a new pair of tokens is issued to the session.
+func (a *AuthManager) RenewSession(ctx context.Context, creds *Credendials) (string, string, error) {
+	revoked, err := a.db.RevokeToken(ctx, creds.ID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to revoke refresh token", logging.ErrKey, err)
+		return "", "", status.Error(codes.Internal,
+			"failed to revoke refresh token. Operation aborted",
+		)
+	}
+	if revoked == 0 {
+		// Another request rotated the token since it got authenticated
+		a.refreshTokenReused(ctx, creds.Subject, creds.SessionID)
+		return "", "", status.Error(codes.Unauthenticated, "provided token has been revoked")
+	}
+
+	access, refresh, claims, err := a.issue(ctx, creds.Subject, creds.SessionID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failure during JWT pair generation", logging.ErrKey, err)
+		return "", "", status.Error(codes.Internal, "Failure during JWT generation")
+	}
+
+	err = a.db.RenewSession(ctx, database.RenewSessionParams{
+		ExpiresOn: claims.ExpiresAt.UTC(),
+		Ip:        clientIP(ctx),
+		SessionID: creds.SessionID,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to renew session", logging.ErrKey, err)
+		return "", "", status.Error(codes.Internal, "Failed to renew session")
+	}
+
+	return access, refresh, nil
+}
+
+// refreshTokenReused reacts to a revoked refresh token being used. Either it
+// got stolen and the thief refreshed it first or the other way around: there is
+// no telling which holder is legitimate so the whole family gets revoked and
+// its session signed out. Errors are only logged, the request fails anyways.
+func (a *AuthManager) refreshTokenReused(ctx context.Context, userID, familyID uuid.UUID) {
+	log := slog.With("user_id", userID, "family_id", familyID)
+
+	revoked, err := a.db.RevokeTokenFamily(ctx, familyID)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to revoke token family", logging.ErrKey, err)
+	}
+	if err := a.db.DeleteSession(ctx, familyID); err != nil {
+		log.ErrorContext(ctx, "failed to end session of token family", logging.ErrKey, err)
+	}
+	if revoked == 0 {
+		// Replayed again, the family was already dealt with
+		return
+	}
+
+	log.WarnContext(ctx, "Revoked refresh token reused, revoked its whole family",
+		"revoked", revoked,
+	)
+	err = a.db.NewSecurityEvent(ctx, database.NewSecurityEventParams{
+		UserID: userID,
+		Kind:   SecurityEventRefreshReuse,
+		Detail: fmt.Sprintf(
+			"A refresh token was used after being rotated: session %v got signed out",
+			familyID,
+		),
+		Ip: clientIP(ctx),
+	})
+	if err != nil {
+		log.ErrorContext(ctx, "failed to record security event", logging.ErrKey, err)
+	}
+}
+
+// activeSession makes sure the session a token belongs to is still open.
+func (a *AuthManager) activeSession(ctx context.Context, sid string) (uuid.UUID, error) {
+	sessionID, err := util.ParseUUID(ctx, util.ParseUUIDParams{
+		Str: sid, Subject: "session_id",
+		Implication: codes.Unauthenticated, Critical: true,
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	active, err := a.db.SessionIsActive(ctx, database.SessionIsActiveParams{
+		SessionID: sessionID,
+		Now:       time.Now().UTC(),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to ensure session is still active",
+			logging.ErrKey, err,
+		)
+		return uuid.Nil, status.Error(codes.Internal,
+			"failure while ensuring session is still active",
+		)
+	}
+	if !active {
+		return uuid.Nil, status.Error(codes.Unauthenticated, "Session has ended")
+	}
+	return sessionID, nil
+}
+
+func clientDevice(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	device := "Unknown device"
+	for _, key := range []string{DeviceMetadataKey, "user-agent"} {
+		if values := md.Get(key); len(values) > 0 && strings.TrimSpace(values[0]) != "" {
+			device = strings.TrimSpace(values[0])
+			break
+		}
+	}
+	if len(device) > maxDeviceLen {
+		device = strings.ToValidUTF8(device[:maxDeviceLen], "")
+	}
+	return device
+}
+
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
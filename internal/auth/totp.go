@@ -0,0 +1,185 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/sec"
+	"github.com/ChausseBenjamin/rafta/internal/totp"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Users who enabled two-factor authentication send the code of their
+// authenticator app (or one of their recovery codes) through this metadata
+// when logging in.
+const OTPMetadataKey = "otp-code"
+
+const (
+	// Kind of the security event recorded when a recovery code gets used
+	SecurityEventRecoveryCode = "recovery_code_used"
+
+	RecoveryCodeCount = 10
+	recoveryCodeLen   = 10
+	// Without look-alike characters (0/o, 1/l/i) to ease typing codes back
+	recoveryAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+)
+
+// CheckSecondFactor makes users who enabled two-factor authentication prove
+// it with the code they sent as metadata.
+func (a *AuthManager) CheckSecondFactor(ctx context.Context, userID uuid.UUID) error {
+	enrolled, err := a.db.GetUserTOTP(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to retrieve two-factor authentication", logging.ErrKey, err)
+		return status.Error(codes.Internal, "Failed to retrieve two-factor authentication")
+	}
+	if !enrolled.Confirmed {
+		return nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(OTPMetadataKey)
+	if len(values) == 0 || strings.TrimSpace(values[0]) == "" {
+		return status.Errorf(codes.Unauthenticated,
+			"Two-factor authentication code required (send it as '%s' metadata)",
+			OTPMetadataKey,
+		)
+	}
+	return a.VerifySecondFactor(ctx, enrolled, values[0])
+}
+
+// VerifySecondFactor accepts either a code from the authenticator app of the
+// user, which can't be used twice, or one of their recovery codes, which gets
+// consumed.
+func (a *AuthManager) VerifySecondFactor(ctx context.Context, enrolled database.UserTotp, code string) error {
+	step, err := totp.Validate(enrolled.Secret, code, time.Now())
+	if err == nil {
+		used, err := a.db.UseTOTPStep(ctx, database.UseTOTPStepParams{
+			Step:   step,
+			UserID: enrolled.UserID,
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to record two-factor code use", logging.ErrKey, err)
+			return status.Error(codes.Internal, "Failed to verify two-factor authentication code")
+		}
+		if used == 0 {
+			slog.WarnContext(ctx, "Two-factor authentication code replayed")
+			return status.Error(codes.Unauthenticated, "Two-factor authentication code was already used")
+		}
+		return nil
+	}
+	if !errors.Is(err, totp.ErrInvalidCode) {
+		slog.ErrorContext(ctx, "Stored TOTP secret is invalid", logging.ErrKey, err)
+		return status.Error(codes.Internal, "Failed to verify two-factor authentication code")
+	}
+
+	if code := normalizeRecoveryCode(code); len(code) == recoveryCodeLen {
+		return a.useRecoveryCode(ctx, enrolled.UserID, code)
+	}
+	return status.Error(codes.Unauthenticated, "Invalid two-factor authentication code")
+}
+
+func (a *AuthManager) useRecoveryCode(ctx context.Context, userID uuid.UUID, code string) error {
+	recoveryCodes, err := a.db.GetRecoveryCodes(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to retrieve recovery codes", logging.ErrKey, err)
+		return status.Error(codes.Internal, "Failed to verify two-factor authentication code")
+	}
+
+	threads := getArgonThreads(a.cfg.ArgonThreads)
+	for _, recovery := range recoveryCodes {
+		if sec.ValidateCredsWithThreads(code, recovery.Hash, recovery.Salt, threads) != nil {
+			continue
+		}
+		deleted, err := a.db.DeleteRecoveryCode(ctx, recovery.CodeID)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to consume recovery code", logging.ErrKey, err)
+			return status.Error(codes.Internal, "Failed to verify two-factor authentication code")
+		}
+		if deleted == 0 {
+			// Consumed by a concurrent request
+			break
+		}
+
+		slog.WarnContext(ctx, "Recovery code used", "remaining", len(recoveryCodes)-1)
+		err = a.db.NewSecurityEvent(ctx, database.NewSecurityEventParams{
+			UserID: userID,
+			Kind:   SecurityEventRecoveryCode,
+			Detail: "A recovery code was used instead of a two-factor authentication code",
+			Ip:     clientIP(ctx),
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to record security event", logging.ErrKey, err)
+		}
+		return nil
+	}
+	return status.Error(codes.Unauthenticated, "Invalid two-factor authentication code")
+}
+
+// NewRecoveryCodes replaces the recovery codes of a user. The codes are
+// returned so that they can be shown to the user once, only their hashes are
+// stored.
+func (a *AuthManager) NewRecoveryCodes(ctx context.Context, db *database.Queries, userID uuid.UUID) ([]string, error) {
+	if err := db.DeleteRecoveryCodes(ctx, userID); err != nil {
+		slog.ErrorContext(ctx, "Failed to delete previous recovery codes", logging.ErrKey, err)
+		return nil, status.Error(codes.Internal, "Failed to generate recovery codes")
+	}
+
+	threads := getArgonThreads(a.cfg.ArgonThreads)
+	recoveryCodes := make([]string, RecoveryCodeCount)
+	for i := range recoveryCodes {
+		code, err := genRecoveryCode()
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to generate recovery code", logging.ErrKey, err)
+			return nil, status.Error(codes.Internal, "Failed to generate recovery codes")
+		}
+		hash, salt, err := sec.GenerateHashWithThreads(code, threads)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to hash recovery code", logging.ErrKey, err)
+			return nil, status.Error(codes.Internal, "Failed to generate recovery codes")
+		}
+		err = db.NewRecoveryCode(ctx, database.NewRecoveryCodeParams{
+			UserID: userID,
+			Salt:   salt,
+			Hash:   hash,
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to save recovery code", logging.ErrKey, err)
+			return nil, status.Error(codes.Internal, "Failed to generate recovery codes")
+		}
+		recoveryCodes[i] = code[:recoveryCodeLen/2] + "-" + code[recoveryCodeLen/2:]
+	}
+	return recoveryCodes, nil
+}
+
+func genRecoveryCode() (string, error) {
+	code := make([]byte, recoveryCodeLen)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(recoveryAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		code[i] = recoveryAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
+
+// normalizeRecoveryCode undoes the formatting of codes shown to users
+// (ex: "abcde-fghjk").
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
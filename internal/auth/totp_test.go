@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/totp"
+	"github.com/ChausseBenjamin/rafta/internal/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// enrollTOTP sets up a manager whose only user confirmed two-factor
+// authentication.
+func enrollTOTP(t *testing.T) (*AuthManager, database.UserTotp) {
+	t.Helper()
+	ctx := context.Background()
+	cfg := &util.ConfigStore{DBCacheSize: -2000, ArgonThreads: 1}
+	sqlDB, err := database.Setup(ctx, filepath.Join(t.TempDir(), "store.db"), cfg)
+	if err != nil {
+		t.Fatalf("failed to set up database: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	db := database.New(sqlDB)
+
+	user, err := db.NewUser(ctx, database.NewUserParams{Name: "Jane", Email: "jane@example.com"})
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatalf("failed to generate secret: %v", err)
+	}
+	if err := db.SetUserTOTP(ctx, database.SetUserTOTPParams{UserID: user.UserID, Secret: secret}); err != nil {
+		t.Fatalf("failed to enroll: %v", err)
+	}
+	if err := db.ConfirmUserTOTP(ctx, database.ConfirmUserTOTPParams{UserID: user.UserID}); err != nil {
+		t.Fatalf("failed to confirm: %v", err)
+	}
+	enrolled, err := db.GetUserTOTP(ctx, user.UserID)
+	if err != nil {
+		t.Fatalf("failed to retrieve enrollment: %v", err)
+	}
+	return &AuthManager{db: db, cfg: cfg}, enrolled
+}
+
+func otpCode(t *testing.T, secret string, step int64) string {
+	t.Helper()
+	code, err := totp.Code(secret, step)
+	if err != nil {
+		t.Fatalf("failed to compute code: %v", err)
+	}
+	return code
+}
+
+func TestVerifySecondFactorRefusesReplays(t *testing.T) {
+	a, enrolled := enrollTOTP(t)
+	ctx := context.Background()
+	step := totp.Step(time.Now())
+
+	current := otpCode(t, enrolled.Secret, step)
+	if err := a.VerifySecondFactor(ctx, enrolled, current); err != nil {
+		t.Fatalf("VerifySecondFactor() = %v", err)
+	}
+	if err := a.VerifySecondFactor(ctx, enrolled, current); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("VerifySecondFactor() = %v on a replay, want Unauthenticated", err)
+	}
+	// Still within the skew window, but older than the code that got used
+	previous := otpCode(t, enrolled.Secret, step-1)
+	if err := a.VerifySecondFactor(ctx, enrolled, previous); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("VerifySecondFactor() = %v with an earlier code, want Unauthenticated", err)
+	}
+	next := otpCode(t, enrolled.Secret, step+1)
+	if err := a.VerifySecondFactor(ctx, enrolled, next); err != nil {
+		t.Errorf("VerifySecondFactor() = %v with the next code", err)
+	}
+}
+
+func TestVerifySecondFactorConsumesRecoveryCodes(t *testing.T) {
+	a, enrolled := enrollTOTP(t)
+	ctx := context.Background()
+
+	recoveryCodes, err := a.NewRecoveryCodes(ctx, a.db, enrolled.UserID)
+	if err != nil || len(recoveryCodes) != RecoveryCodeCount {
+		t.Fatalf("NewRecoveryCodes() = %v, %v", recoveryCodes, err)
+	}
+	if err := a.VerifySecondFactor(ctx, enrolled, recoveryCodes[3]); err != nil {
+		t.Fatalf("VerifySecondFactor() = %v with a recovery code", err)
+	}
+	if err := a.VerifySecondFactor(ctx, enrolled, recoveryCodes[3]); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("VerifySecondFactor() = %v with a used recovery code, want Unauthenticated", err)
+	}
+	if remaining, err := a.db.GetRecoveryCodes(ctx, enrolled.UserID); err != nil || len(remaining) != RecoveryCodeCount-1 {
+		t.Errorf("%d recovery codes remain (%v), want %d", len(remaining), err, RecoveryCodeCount-1)
+	}
+}
+
+func TestCheckSecondFactor(t *testing.T) {
+	a, enrolled := enrollTOTP(t)
+	withCode := func(code string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(OTPMetadataKey, code))
+	}
+
+	if err := a.CheckSecondFactor(context.Background(), enrolled.UserID); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("CheckSecondFactor() = %v without a code, want Unauthenticated", err)
+	}
+	step := totp.Step(time.Now())
+	code := otpCode(t, enrolled.Secret, step)
+	// Any code but the ones of the skew window
+	window := []string{otpCode(t, enrolled.Secret, step-1), code, otpCode(t, enrolled.Secret, step+1)}
+	wrong := "000000"
+	for n := 1; slices.Contains(window, wrong); n++ {
+		wrong = fmt.Sprintf("%06d", n)
+	}
+	if err := a.CheckSecondFactor(withCode(wrong), enrolled.UserID); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("CheckSecondFactor() = %v with a wrong code, want Unauthenticated", err)
+	}
+	if err := a.CheckSecondFactor(withCode(code[:3]+" "+code[3:]), enrolled.UserID); err != nil {
+		t.Errorf("CheckSecondFactor() = %v", err)
+	}
+
+	// Enrollments only get enforced once confirmed
+	err := a.db.SetUserTOTP(context.Background(), database.SetUserTOTPParams{UserID: enrolled.UserID, Secret: enrolled.Secret})
+	if err != nil {
+		t.Fatalf("failed to enroll again: %v", err)
+	}
+	if err := a.CheckSecondFactor(context.Background(), enrolled.UserID); err != nil {
+		t.Errorf("CheckSecondFactor() = %v before confirmation", err)
+	}
+}
@@ -0,0 +1,238 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"slices"
+
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/directory"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/sec"
+	"github.com/ChausseBenjamin/rafta/internal/util"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CredentialVerifier checks the login and password sent through basic
+// authentication and returns the user they belong to. Errors are gRPC
+// statuses.
+type CredentialVerifier interface {
+	Verify(ctx context.Context, login, passwd string) (uuid.UUID, error)
+}
+
+// passwordVerifier checks passwords against the Argon2 hashes of the
+// user_secrets table.
+type passwordVerifier struct {
+	db  *database.Queries
+	cfg *util.ConfigStore
+}
+
+func (v *passwordVerifier) Verify(ctx context.Context, login, passwd string) (uuid.UUID, error) {
+	userSecret, err := v.db.GetUserSecretsFromEmail(ctx, login)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, status.Error(codes.NotFound, "User does not exist")
+		}
+		return uuid.Nil, status.Error(codes.Internal, "Failed to query user credentials")
+	}
+	err = sec.ValidateCredsWithThreads(passwd, userSecret.Hash, userSecret.Salt, getArgonThreads(v.cfg.ArgonThreads))
+	if err != nil {
+		if errors.Is(err, sec.ErrInvalidCreds) {
+			return uuid.Nil, status.Error(codes.Unauthenticated, "Invalid credentials provided")
+		}
+		return uuid.Nil, status.Error(codes.Unauthenticated, "Invalid credentials format provided")
+	}
+	return userSecret.UserID, nil
+}
+
+// Roles of the users administering the server, at least one of them must remain
+var adminRoles = []string{"ADMIN"}
+
+// userDirectory finds the users of a directory and the roles their groups
+// grant them (see directory.Directory).
+type userDirectory interface {
+	Authenticate(login, passwd string) (directory.Entry, error)
+	Roles(entry directory.Entry) []string
+	ManagedRoles() []string
+}
+
+// ldapVerifier checks passwords against an LDAP directory. Users of the
+// directory get an account the first time they log in and the roles mapped
+// to their groups are synced on every login. Logins the directory doesn't know
+// fall back to local passwords so that accounts such as the default admin
+// keep working.
+type ldapVerifier struct {
+	dir   userDirectory
+	sqlDB *sql.DB
+	db    *database.Queries
+	cfg   *util.ConfigStore
+	local CredentialVerifier
+}
+
+func (v *ldapVerifier) Verify(ctx context.Context, login, passwd string) (uuid.UUID, error) {
+	entry, err := v.dir.Authenticate(login, passwd)
+	switch {
+	case errors.Is(err, directory.ErrUserNotFound):
+		return v.local.Verify(ctx, login, passwd)
+	case errors.Is(err, directory.ErrInvalidCreds):
+		return uuid.Nil, status.Error(codes.Unauthenticated, "Invalid credentials provided")
+	case err != nil:
+		slog.ErrorContext(ctx, "Failed to authenticate against directory", logging.ErrKey, err)
+		return uuid.Nil, status.Error(codes.Unavailable, "Failed to authenticate against the directory")
+	}
+
+	tx, err := v.sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to begin transaction", logging.ErrKey, err)
+		return uuid.Nil, status.Error(codes.Internal, "Failed to sync directory user")
+	}
+	defer tx.Rollback()
+	db := v.db.WithTx(tx)
+
+	userID, err := v.directoryUser(ctx, db, entry)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if err := v.syncRoles(ctx, db, userID, v.dir.Roles(entry)); err != nil {
+		return uuid.Nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx, "Failed to commit directory user", logging.ErrKey, err)
+		return uuid.Nil, status.Error(codes.Internal, "Failed to sync directory user")
+	}
+	return userID, nil
+}
+
+// directoryUser returns the account of a directory user (matched by email),
+// creating it when they log in for the first time.
+func (v *ldapVerifier) directoryUser(ctx context.Context, db *database.Queries, entry directory.Entry) (uuid.UUID, error) {
+	user, err := db.GetUserFromEmail(ctx, entry.Email)
+	if err == nil {
+		return user.UserID, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		slog.ErrorContext(ctx, "Failed to retrieve directory user", logging.ErrKey, err)
+		return uuid.Nil, status.Error(codes.Internal, "Failed to sync directory user")
+	}
+
+	if v.cfg.MaxUsers > 0 {
+		userCount, err := db.GetUserCount(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to determine the platforms user count", logging.ErrKey, err)
+			return uuid.Nil, status.Error(codes.Internal, "Failed to sync directory user")
+		}
+		if userCount >= int64(v.cfg.MaxUsers) {
+			slog.WarnContext(ctx, "Blocked directory user as server has reached max-user capacity")
+			return uuid.Nil, status.Error(codes.FailedPrecondition, "Server has reached its maximum user capacity")
+		}
+	}
+
+	name := entry.Name
+	if name == "" {
+		name = entry.Email
+	}
+	user, err = db.NewUser(ctx, database.NewUserParams{
+		Name:  name,
+		Email: entry.Email,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create directory user", logging.ErrKey, err)
+		return uuid.Nil, status.Error(codes.Internal, "Failed to create new user")
+	}
+
+	// Directory users never log in with it, the directory checks their password
+	passwd, _, _, err := sec.GenPassword()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to generate password", logging.ErrKey, err)
+		return uuid.Nil, status.Error(codes.Internal, "Failed to create new user")
+	}
+	hash, salt, err := sec.GenerateHashWithThreads(passwd, getArgonThreads(v.cfg.ArgonThreads))
+	if err != nil {
+		slog.ErrorContext(ctx, "Failure to hash user password", logging.ErrKey, err)
+		return uuid.Nil, status.Error(codes.Internal, "Failed to create new user")
+	}
+	err = db.NewUserSecret(ctx, database.NewUserSecretParams{
+		UserID: user.UserID,
+		Salt:   salt,
+		Hash:   hash,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "User credentials insertion failure", logging.ErrKey, err)
+		return uuid.Nil, status.Error(codes.Internal, "Failed to create new user")
+	}
+
+	slog.InfoContext(ctx, "Created account for directory user", "user_id", user.UserID)
+	return user.UserID, nil
+}
+
+// syncRoles grants the roles mapped to the groups of a user and revokes the
+// mapped roles they lost. Roles not mapped to any group are left alone and so
+// is the role of the last admin: the server must keep one.
+func (v *ldapVerifier) syncRoles(ctx context.Context, db *database.Queries, userID uuid.UUID, granted []string) error {
+	current, err := db.GetUserRoles(ctx, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		slog.ErrorContext(ctx, "Failed to retrieve user roles", logging.ErrKey, err)
+		return status.Error(codes.Internal, "Failed to sync directory user")
+	}
+
+	changed := false
+	for _, role := range v.dir.ManagedRoles() {
+		has, wants := slices.Contains(current, role), slices.Contains(granted, role)
+		switch {
+		case wants && !has:
+			err = db.AppendUserRole(ctx, database.AppendUserRoleParams{
+				UserID: userID,
+				Role:   role,
+			})
+		case has && !wants:
+			err = db.RevokeUserRole(ctx, database.RevokeUserRoleParams{
+				UserID: userID,
+				Role:   role,
+			})
+			if err == nil && slices.Contains(adminRoles, role) {
+				var kept bool
+				if kept, err = v.keepLastAdmin(ctx, db, userID, role); kept && err == nil {
+					continue
+				}
+			}
+		default:
+			continue
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to sync user role", "role", role, logging.ErrKey, err)
+			return status.Error(codes.Internal, "Failed to sync directory user roles")
+		}
+		changed = true
+	}
+
+	if changed {
+		// Access tokens carry the roles they were issued with
+		if err := db.RevokeUserAccessTokens(ctx, userID); err != nil {
+			slog.ErrorContext(ctx, "Failed to revoke access tokens", logging.ErrKey, err)
+			return status.Error(codes.Internal, "Failed to sync directory user roles")
+		}
+	}
+	return nil
+}
+
+// keepLastAdmin grants an admin role back to a user who just lost it when no
+// admin is left, telling whether it did.
+func (v *ldapVerifier) keepLastAdmin(ctx context.Context, db *database.Queries, userID uuid.UUID, role string) (bool, error) {
+	adminLeft, err := db.AdminExists(ctx, adminRoles)
+	if err != nil || adminLeft {
+		return false, err
+	}
+	slog.WarnContext(ctx, "Kept the role of the last admin although they left its directory group",
+		"user_id", userID,
+		"role", role,
+	)
+	return true, db.AppendUserRole(ctx, database.AppendUserRoleParams{
+		UserID: userID,
+		Role:   role,
+	})
+}
@@ -0,0 +1,210 @@
+package auth
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/directory"
+	"github.com/ChausseBenjamin/rafta/internal/sec"
+	"github.com/ChausseBenjamin/rafta/internal/util"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultAdminEmail = "admin@localhost" // Created by database.Setup
+
+// fakeDirectory knows a single user, who belongs to the groups in groups.
+type fakeDirectory struct {
+	entry    directory.Entry
+	password string
+	groups   []string
+	err      error // Returned instead when set
+}
+
+func (d *fakeDirectory) Authenticate(login, passwd string) (directory.Entry, error) {
+	switch {
+	case d.err != nil:
+		return directory.Entry{}, d.err
+	case login != d.entry.Email:
+		return directory.Entry{}, directory.ErrUserNotFound
+	case passwd == "" || passwd != d.password:
+		return directory.Entry{}, directory.ErrInvalidCreds
+	}
+	entry := d.entry
+	entry.Groups = d.groups
+	return entry, nil
+}
+
+func (d *fakeDirectory) Roles(entry directory.Entry) []string {
+	if slices.Contains(entry.Groups, "admins") {
+		return []string{"ADMIN"}
+	}
+	return []string{}
+}
+
+func (d *fakeDirectory) ManagedRoles() []string {
+	return []string{"ADMIN"}
+}
+
+func testVerifier(t *testing.T) (*ldapVerifier, *fakeDirectory) {
+	t.Helper()
+	ctx := context.Background()
+	cfg := &util.ConfigStore{DBCacheSize: -2000, ArgonThreads: 1}
+	sqlDB, err := database.Setup(ctx, filepath.Join(t.TempDir(), "store.db"), cfg)
+	if err != nil {
+		t.Fatalf("failed to set up database: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	db := database.New(sqlDB)
+
+	dir := &fakeDirectory{
+		entry:    directory.Entry{DN: "uid=jane,ou=people,dc=example,dc=com", Email: "jane@example.com", Name: "Jane"},
+		password: "jane-secret",
+	}
+	return &ldapVerifier{
+		dir:   dir,
+		sqlDB: sqlDB,
+		db:    db,
+		cfg:   cfg,
+		local: &passwordVerifier{db: db, cfg: cfg},
+	}, dir
+}
+
+func code(err error) codes.Code {
+	return status.Code(err)
+}
+
+func roles(t *testing.T, v *ldapVerifier, userID uuid.UUID) []string {
+	t.Helper()
+	roles, err := v.db.GetUserRoles(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("failed to retrieve roles: %v", err)
+	}
+	return roles
+}
+
+func TestLDAPVerifyProvisionsUsers(t *testing.T) {
+	v, _ := testVerifier(t)
+	ctx := context.Background()
+
+	userID, err := v.Verify(ctx, "jane@example.com", "jane-secret")
+	if err != nil {
+		t.Fatalf("Verify() = %v", err)
+	}
+	user, err := v.db.GetUserFromEmail(ctx, "jane@example.com")
+	if err != nil || user.UserID != userID || user.Name != "Jane" {
+		t.Fatalf("provisioned %+v (%v), want Jane as %v", user, err, userID)
+	}
+
+	again, err := v.Verify(ctx, "jane@example.com", "jane-secret")
+	if err != nil || again != userID {
+		t.Errorf("Verify() = %v, %v on the next login, want %v", again, err, userID)
+	}
+}
+
+func TestLDAPVerifyRejectsInvalidCredentials(t *testing.T) {
+	v, dir := testVerifier(t)
+	ctx := context.Background()
+
+	for _, passwd := range []string{"john-secret", ""} {
+		if _, err := v.Verify(ctx, "jane@example.com", passwd); code(err) != codes.Unauthenticated {
+			t.Errorf("Verify(%q) = %v, want Unauthenticated", passwd, err)
+		}
+	}
+
+	dir.err = directory.ErrInvalidCreds
+	if _, err := v.Verify(ctx, "jane@example.com", "jane-secret"); code(err) != codes.Unauthenticated {
+		t.Errorf("Verify() = %v, want Unauthenticated", err)
+	}
+}
+
+func TestLDAPVerifyUnavailableDirectory(t *testing.T) {
+	v, dir := testVerifier(t)
+	dir.err = context.DeadlineExceeded
+
+	if _, err := v.Verify(context.Background(), "jane@example.com", "jane-secret"); code(err) != codes.Unavailable {
+		t.Errorf("Verify() = %v, want Unavailable", err)
+	}
+}
+
+func TestLDAPVerifyFallsBackToLocalPasswords(t *testing.T) {
+	v, _ := testVerifier(t)
+	ctx := context.Background()
+
+	user, err := v.db.NewUser(ctx, database.NewUserParams{Name: "John", Email: "john@example.com"})
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	hash, salt, err := sec.GenerateHashWithThreads("john-secret", 1)
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+	err = v.db.NewUserSecret(ctx, database.NewUserSecretParams{UserID: user.UserID, Salt: salt, Hash: hash})
+	if err != nil {
+		t.Fatalf("failed to store password: %v", err)
+	}
+
+	userID, err := v.Verify(ctx, "john@example.com", "john-secret")
+	if err != nil || userID != user.UserID {
+		t.Errorf("Verify() = %v, %v, want %v", userID, err, user.UserID)
+	}
+	if _, err := v.Verify(ctx, "john@example.com", "jane-secret"); code(err) != codes.Unauthenticated {
+		t.Errorf("Verify() = %v with a wrong password, want Unauthenticated", err)
+	}
+	if _, err := v.Verify(ctx, "nobody@example.com", "secret"); code(err) != codes.NotFound {
+		t.Errorf("Verify() = %v for an unknown user, want NotFound", err)
+	}
+}
+
+func TestLDAPVerifySyncsRoles(t *testing.T) {
+	v, dir := testVerifier(t)
+	ctx := context.Background()
+
+	dir.groups = []string{"admins"}
+	userID, err := v.Verify(ctx, "jane@example.com", "jane-secret")
+	if err != nil {
+		t.Fatalf("Verify() = %v", err)
+	}
+	if got := roles(t, v, userID); !slices.Equal(got, []string{"ADMIN"}) {
+		t.Errorf("roles = %v after joining the group, want [ADMIN]", got)
+	}
+
+	dir.groups = nil
+	if _, err := v.Verify(ctx, "jane@example.com", "jane-secret"); err != nil {
+		t.Fatalf("Verify() = %v", err)
+	}
+	if got := roles(t, v, userID); len(got) != 0 {
+		t.Errorf("roles = %v after leaving the group, want none", got)
+	}
+}
+
+func TestLDAPVerifyKeepsLastAdmin(t *testing.T) {
+	v, dir := testVerifier(t)
+	ctx := context.Background()
+
+	dir.groups = []string{"admins"}
+	userID, err := v.Verify(ctx, "jane@example.com", "jane-secret")
+	if err != nil {
+		t.Fatalf("Verify() = %v", err)
+	}
+	admin, err := v.db.GetUserFromEmail(ctx, defaultAdminEmail)
+	if err != nil {
+		t.Fatalf("failed to retrieve default admin: %v", err)
+	}
+	err = v.db.RevokeUserRole(ctx, database.RevokeUserRoleParams{UserID: admin.UserID, Role: "ADMIN"})
+	if err != nil {
+		t.Fatalf("failed to revoke default admin: %v", err)
+	}
+
+	dir.groups = nil
+	if _, err := v.Verify(ctx, "jane@example.com", "jane-secret"); err != nil {
+		t.Fatalf("Verify() = %v", err)
+	}
+	if got := roles(t, v, userID); !slices.Equal(got, []string{"ADMIN"}) {
+		t.Errorf("roles = %v, want the last admin to stay one", got)
+	}
+}
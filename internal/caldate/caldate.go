@@ -0,0 +1,185 @@
+// caldate represents task dates the way people express them rather than as
+// instants:
+//
+//   - All-day dates (`2026-10-23`) designate a calendar day wherever the user
+//     happens to be.
+//   - Floating times (`2026-10-23T09:00:00`) happen at a wall-clock time
+//     wherever the user happens to be.
+//   - Absolute times (`2026-10-23T17:00:00[America/Montreal]`) are pinned to
+//     a time zone and therefore designate a single instant.
+//
+// The text representations above are what String returns and Parse accepts.
+// They are lossless so that dates can be stored as-is.
+package caldate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Kind uint8
+
+const (
+	AllDay Kind = iota
+	Floating
+	Absolute
+)
+
+const (
+	dateLayout     = "2006-01-02"
+	dateTimeLayout = "2006-01-02T15:04:05"
+)
+
+var ErrInvalidDate = errors.New("invalid date")
+
+// Date is a calendar date with an optional time of day and time zone.
+type Date struct {
+	Kind   Kind
+	Year   int
+	Month  time.Month
+	Day    int
+	Hour   int    // Unused by all-day dates.
+	Minute int    // Unused by all-day dates.
+	Second int    // Unused by all-day dates.
+	Zone   string // IANA time zone of absolute dates.
+}
+
+// New validates the components of a date. Absolute dates require a zone and
+// other dates must not have one.
+func New(kind Kind, year int, month time.Month, day, hour, min, sec int, zone string) (Date, error) {
+	d := Date{Kind: kind, Year: year, Month: month, Day: day, Zone: zone}
+	if kind != AllDay {
+		d.Hour, d.Minute, d.Second = hour, min, sec
+	}
+
+	civil := time.Date(year, month, day, d.Hour, d.Minute, d.Second, 0, time.UTC)
+	cy, cmo, cd := civil.Date()
+	ch, cmin, cs := civil.Clock()
+	if cy != year || cmo != month || cd != day || ch != d.Hour || cmin != d.Minute || cs != d.Second {
+		return Date{}, fmt.Errorf("%w: %04d-%02d-%02d %02d:%02d:%02d doesn't exist",
+			ErrInvalidDate, year, month, day, hour, min, sec,
+		)
+	}
+
+	switch {
+	case kind == Absolute && zone == "":
+		return Date{}, fmt.Errorf("%w: absolute dates require a time zone", ErrInvalidDate)
+	case kind != Absolute && zone != "":
+		return Date{}, fmt.Errorf("%w: only absolute dates have a time zone", ErrInvalidDate)
+	case kind == Absolute:
+		if _, err := location(zone); err != nil {
+			return Date{}, fmt.Errorf("%w: unknown time zone '%s'", ErrInvalidDate, zone)
+		}
+	case kind > Absolute:
+		return Date{}, fmt.Errorf("%w: unknown kind %d", ErrInvalidDate, kind)
+	}
+	return d, nil
+}
+
+// At returns the absolute date of an instant in the time zone it is
+// expressed in.
+func At(t time.Time) Date {
+	y, mo, d := t.Date()
+	h, min, s := t.Clock()
+	return Date{
+		Kind: Absolute, Year: y, Month: mo, Day: d,
+		Hour: h, Minute: min, Second: s,
+		Zone: t.Location().String(),
+	}
+}
+
+// Parse reads the text representation of a date (see String).
+func Parse(s string) (Date, error) {
+	value, zone, zoned := strings.Cut(s, "[")
+	if zoned {
+		if !strings.HasSuffix(zone, "]") {
+			return Date{}, fmt.Errorf("%w '%s': unterminated time zone", ErrInvalidDate, s)
+		}
+		zone = strings.TrimSuffix(zone, "]")
+	}
+
+	kind := AllDay
+	layout := dateLayout
+	if strings.Contains(value, "T") {
+		kind, layout = Floating, dateTimeLayout
+		if zoned {
+			kind = Absolute
+		}
+	}
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		return Date{}, fmt.Errorf("%w '%s': %w", ErrInvalidDate, s, err)
+	}
+
+	y, mo, d := t.Date()
+	h, min, sec := t.Clock()
+	return New(kind, y, mo, d, h, min, sec, zone)
+}
+
+// WholeDay returns the all-day date of the day d falls on.
+func (d Date) WholeDay() Date {
+	return Date{Kind: AllDay, Year: d.Year, Month: d.Month, Day: d.Day}
+}
+
+// String returns the lossless text representation of the date.
+func (d Date) String() string {
+	civil := d.civil()
+	switch d.Kind {
+	case AllDay:
+		return civil.Format(dateLayout)
+	case Absolute:
+		return civil.Format(dateTimeLayout) + "[" + d.Zone + "]"
+	default:
+		return civil.Format(dateTimeLayout)
+	}
+}
+
+// Start returns the instant the date begins at for someone located in loc.
+// Absolute dates ignore loc.
+func (d Date) Start(loc *time.Location) time.Time {
+	if d.Kind == Absolute {
+		// Zones are validated upon creation
+		loc, _ = location(d.Zone)
+	}
+	return time.Date(d.Year, d.Month, d.Day, d.Hour, d.Minute, d.Second, 0, loc)
+}
+
+// End returns the instant the date is over for someone located in loc: the
+// following midnight for all-day dates, Start otherwise.
+func (d Date) End(loc *time.Location) time.Time {
+	if d.Kind == AllDay {
+		return time.Date(d.Year, d.Month, d.Day+1, 0, 0, 0, 0, loc)
+	}
+	return d.Start(loc)
+}
+
+// LocalDate returns the calendar day the date falls on for someone located in
+// loc. Only absolute dates can fall on another day than their own.
+func (d Date) LocalDate(loc *time.Location) (int, time.Month, int) {
+	if d.Kind == Absolute {
+		return d.Start(loc).In(loc).Date()
+	}
+	return d.Year, d.Month, d.Day
+}
+
+func (d Date) civil() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, d.Hour, d.Minute, d.Second, 0, time.UTC)
+}
+
+// Loading a location parses its tz database entry every time
+var locations sync.Map
+
+func location(name string) (*time.Location, error) {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, loc)
+	return loc, nil
+}
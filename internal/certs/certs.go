@@ -0,0 +1,245 @@
+// certs provides the TLS certificate the server identifies itself with. The
+// certificate and its key are PEM files kept in the secret vault (tls-cert and
+// tls-key) so that they can be swapped for ones issued by a real CA (ex: by a
+// certbot hook). A self-signed pair gets generated when there are none.
+//
+// The vault is polled for changes: renewed certificates are picked up by new
+// connections without restarting the server.
+//
+// Clients can also authenticate with certificates: either ones users register
+// (identified by their fingerprint) or ones issued for their email by the CAs
+// in the tls-client-ca secret.
+package certs
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"math/big"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/secrets"
+)
+
+const (
+	CertKey     = "tls-cert"
+	KeyKey      = "tls-key"
+	ClientCAKey = "tls-client-ca"
+
+	// How often the vault is checked for a renewed certificate
+	ReloadInterval = 30 * time.Second
+	// Self-signed certificates are meant to get pinned by clients, renewing
+	// them often would only get in the way.
+	selfSignedValidity = 10 * 365 * 24 * time.Hour
+)
+
+// Store holds the current certificate of the server.
+type Store struct {
+	vault secrets.SecretVault
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// Load reads the certificate from the vault, generating a self-signed one
+// when the vault has none. Having only one of the certificate and its key is
+// an error: replacing the other could discard a CA issued one.
+func Load(ctx context.Context, vault secrets.SecretVault) (*Store, error) {
+	s := &Store{vault: vault}
+
+	certPEM, certErr := vault.Get(CertKey)
+	keyPEM, keyErr := vault.Get(KeyKey)
+	certMissing, keyMissing := errors.Is(certErr, fs.ErrNotExist), errors.Is(keyErr, fs.ErrNotExist)
+	switch {
+	case certErr != nil && !certMissing:
+		return nil, fmt.Errorf("failed to read TLS certificate: %w", certErr)
+	case keyErr != nil && !keyMissing:
+		return nil, fmt.Errorf("failed to read TLS key: %w", keyErr)
+	case certMissing && !keyMissing:
+		return nil, fmt.Errorf("found the %s secret without %s", KeyKey, CertKey)
+	case keyMissing && !certMissing:
+		return nil, fmt.Errorf("found the %s secret without %s", CertKey, KeyKey)
+	case certMissing && keyMissing:
+		slog.WarnContext(ctx, "No TLS certificate found, generating a self-signed one")
+		var err error
+		if certPEM, keyPEM, err = selfSigned(); err != nil {
+			return nil, fmt.Errorf("failed to generate self-signed certificate: %w", err)
+		}
+		if err := vault.Set(KeyKey, keyPEM); err != nil {
+			return nil, fmt.Errorf("failed to store TLS key: %w", err)
+		}
+		if err := vault.Set(CertKey, certPEM); err != nil {
+			return nil, fmt.Errorf("failed to store TLS certificate: %w", err)
+		}
+	}
+
+	if err := s.update(certPEM.Bytes(), keyPEM.Bytes()); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// GetCertificate is meant for tls.Config.GetCertificate.
+func (s *Store) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cert, nil
+}
+
+// Watch reloads the certificate whenever it changes in the vault until ctx is
+// done. Invalid certificates are ignored so that a half-written renewal
+// doesn't break the server.
+func (s *Store) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			certPEM, certErr := s.vault.Get(CertKey)
+			keyPEM, keyErr := s.vault.Get(KeyKey)
+			if certErr != nil || keyErr != nil {
+				slog.WarnContext(ctx, "Failed to read TLS certificate, keeping the current one",
+					logging.ErrKey, errors.Join(certErr, keyErr),
+				)
+				continue
+			}
+
+			s.mu.RLock()
+			changed := !bytes.Equal(certPEM.Bytes(), s.certPEM) || !bytes.Equal(keyPEM.Bytes(), s.keyPEM)
+			s.mu.RUnlock()
+			if !changed {
+				continue
+			}
+
+			if err := s.update(certPEM.Bytes(), keyPEM.Bytes()); err != nil {
+				slog.WarnContext(ctx, "Ignoring invalid TLS certificate", logging.ErrKey, err)
+				continue
+			}
+			slog.InfoContext(ctx, "Reloaded TLS certificate")
+		}
+	}
+}
+
+func (s *Store) update(certPEM, keyPEM []byte) error {
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return fmt.Errorf("invalid TLS certificate: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cert, s.certPEM, s.keyPEM = &cert, certPEM, keyPEM
+	return nil
+}
+
+// selfSigned generates a certificate for the host name of the machine and
+// for localhost.
+func selfSigned() (certPEM, keyPEM secrets.Secret, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return "", "", err
+	}
+
+	names := []string{"localhost"}
+	if host, err := os.Hostname(); err == nil && host != "localhost" {
+		names = append([]string{host}, names...)
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: names[0], Organization: []string{"Rafta"}},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(selfSignedValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		DNSNames:              names,
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return "", "", err
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", "", err
+	}
+
+	certPEM = secrets.Secret(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	keyPEM = secrets.Secret(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}))
+	return certPEM, keyPEM, nil
+}
+
+// Fingerprint identifies a certificate: the SHA-256 of its DER encoding (hex).
+func Fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// ParseCertificate decodes a single PEM encoded certificate.
+func ParseCertificate(s string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// VerifyClient checks that the first certificate of a chain, as presented
+// during a TLS handshake, was issued by one of the client CAs for usage.
+func VerifyClient(chain []*x509.Certificate, roots *x509.CertPool, usage x509.ExtKeyUsage) error {
+	if len(chain) == 0 {
+		return errors.New("no client certificate")
+	}
+	intermediates := x509.NewCertPool()
+	for _, c := range chain[1:] {
+		intermediates.AddCert(c)
+	}
+	_, err := chain[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{usage},
+	})
+	return err
+}
+
+// LoadClientCAs reads the CAs trusted to issue client certificates from the
+// tls-client-ca secret (PEM). There are none when the secret doesn't exist.
+func LoadClientCAs(vault secrets.SecretVault) (*x509.CertPool, error) {
+	caPEM, err := vault.Get(ClientCAKey)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CAs: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM.Bytes()) {
+		return nil, errors.New("no PEM encoded certificate found in client CAs")
+	}
+	return pool, nil
+}
@@ -6,79 +6,42 @@ import (
 	"time"
 
 	"github.com/ChausseBenjamin/rafta/internal/logging"
-	"github.com/google/uuid"
 )
 
-// revocationCacheGrace sets how long a revoked token should remain in the database
-// even though it is expired.
-const revocationCacheGrace = 30 * time.Second
+const (
+	// revocationCacheGrace sets how long an issued token should remain in the
+	// database even though it is expired.
+	revocationCacheGrace = 30 * time.Second
+	// tokenCleanupInterval sets how often expired tokens get swept.
+	tokenCleanupInterval = 10 * time.Minute
+)
 
-// CleanupExpiredToken cleans up a revoked token if it is expired.
-// It sets a timer to clean up the token after its expiry plus a grace period.
-// The idea here is that even a valid (non-revoked) token would get denied if it's
-// expired so it's useless to keep it in the database.
-func (q *Queries) CleanupExpiredToken(ctx context.Context, tokenID uuid.UUID) error {
-	token, err := q.GetRevokedToken(ctx, tokenID)
+// cleanupExpiredTokens removes the issued tokens that expired (plus a grace
+// period). Even a valid (non-revoked) token would get denied if it's expired
+// so it's useless to keep it in the database.
+func (q *Queries) cleanupExpiredTokens(ctx context.Context) error {
+	removed, err := q.DeleteExpiredTokens(ctx, time.Now().Add(-revocationCacheGrace).UTC())
 	if err != nil {
+		slog.ErrorContext(ctx, "An error occurred cleaning up expired tokens", logging.ErrKey, err)
 		return err
 	}
-
-	log := slog.With(
-		"token_id", token.TokenID,
-		"expiry", token.Expiry,
-	)
-	log.DebugContext(ctx, "Token scheduled for cleanup after expiry")
-
-	// Just cleanup now if the token is already expired (good for after a reboot)
-	if token.Expiry.Add(revocationCacheGrace).Before(time.Now()) {
-		err := q.CleanRevokedToken(ctx, tokenID)
-		if err != nil {
-			log.ErrorContext(ctx,
-				"An error occurred cleaning up an expired token",
-				logging.ErrKey, err,
-			)
-			return err
-		} else {
-			log.InfoContext(ctx, "cleaned up already expired token")
-		}
+	if removed > 0 {
+		slog.DebugContext(ctx, "Removed expired tokens from database", "count", removed)
 	}
-
-	timer := time.NewTimer(time.Until(token.Expiry) + revocationCacheGrace)
-	go func() {
-		defer timer.Stop()
-		<-timer.C
-		// cleanup SQL command isn't context dependent (shouldn't fail if context
-		// is expired). Context is only used to know where a deletetion request
-		// originated from in logs.
-		err := q.CleanRevokedToken(context.Background(), tokenID)
-		if err != nil {
-			log.ErrorContext(ctx,
-				"An error occurred cleaning up an expired token",
-				logging.ErrKey, err,
-			)
-			return
-		}
-		log.InfoContext(ctx, "Revoked token now expired: removed it from database")
-	}()
 	return nil
 }
 
-// TokenCleanupProcess retrieves all revoked tokens and runs
-// CleanupExpiredToken for each one. This is meant to be run at startup to
-// ensure the database doesn't get clogged up with revoked tokens that are
-// expired anyways.
-func (q *Queries) tokenCleanupProcess(ctx context.Context) error {
-	tokens, err := q.GetAllRevokedTokens(ctx)
-	if err != nil {
-		return err
-	}
-
-	for _, token := range tokens {
-		err := q.CleanupExpiredToken(ctx, token.TokenID)
-		if err != nil {
-			return err
+// tokenCleanupProcess sweeps expired tokens periodically until ctx is done.
+// Failed sweeps are only logged, the next one catches up.
+func (q *Queries) tokenCleanupProcess(ctx context.Context) {
+	ticker := time.NewTicker(tokenCleanupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			q.cleanupExpiredTokens(ctx) //nolint:errcheck
 		}
 	}
-
-	return nil
 }
@@ -127,7 +127,8 @@ func Setup(ctx context.Context, path string, cfg *util.ConfigStore) (*sql.DB, er
 	}
 
 	if err == nil {
-		cleanupErr := New(db).tokenCleanupProcess(ctx)
+		// Gets rid of the tokens that expired while the server was down
+		cleanupErr := New(db).cleanupExpiredTokens(ctx)
 		if cleanupErr != nil {
 			err = cleanupErr
 		}
@@ -137,6 +138,8 @@ func Setup(ctx context.Context, path string, cfg *util.ConfigStore) (*sql.DB, er
 		return nil, err
 	}
 
+	go New(db).tokenCleanupProcess(ctx)
+
 	return db, nil
 }
 
@@ -13,22 +13,154 @@ CREATE TABLE user_secrets (
   FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
 );
 
+CREATE TABLE user_preferences (
+  user_id UUID PRIMARY KEY,
+  urgency BLOB, -- protobuf encoded UrgencyCoefficients (NULL for defaults)
+  updated_on TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE TABLE user_settings (
+  user_id UUID NOT NULL,
+  namespace TEXT NOT NULL, -- Client owning the setting ('rafta' for the server)
+  key TEXT NOT NULL,
+  value TEXT NOT NULL,
+  updated_on TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (user_id, namespace, key),
+  FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE TABLE push_endpoints (
+  endpoint_id UUID NOT NULL UNIQUE PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-' || '4' || substr(hex(randomblob(2)),2) || '-' || substr('89ab',abs(random()) % 4 + 1, 1) || substr(hex(randomblob(2)),2) || '-' || hex(randomblob(6)))),
+  user_id UUID NOT NULL,
+  name TEXT NOT NULL,
+  kind INTEGER NOT NULL, -- PushEndpointKind
+  url TEXT NOT NULL,
+  token TEXT, -- ntfy access token
+  events INTEGER NOT NULL DEFAULT 0, -- Bitmask of PushEvent (0 for all of them)
+  enabled BOOLEAN NOT NULL DEFAULT TRUE,
+  failures INTEGER NOT NULL DEFAULT 0, -- Consecutive failed deliveries
+  last_error TEXT,
+  last_delivery TIMESTAMP,
+  created_on TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_on TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (user_id, name),
+  FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE TABLE client_certificates (
+  cert_id UUID NOT NULL UNIQUE PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-' || '4' || substr(hex(randomblob(2)),2) || '-' || substr('89ab',abs(random()) % 4 + 1, 1) || substr(hex(randomblob(2)),2) || '-' || hex(randomblob(6)))),
+  user_id UUID NOT NULL,
+  name TEXT NOT NULL,
+  fingerprint TEXT NOT NULL UNIQUE, -- SHA-256 of the DER certificate (hex)
+  subject TEXT NOT NULL,
+  not_after TIMESTAMP NOT NULL,
+  created_on TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  last_used TIMESTAMP,
+  UNIQUE (user_id, name),
+  FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE TABLE personal_tokens (
+  token_id UUID NOT NULL UNIQUE PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-' || '4' || substr(hex(randomblob(2)),2) || '-' || substr('89ab',abs(random()) % 4 + 1, 1) || substr(hex(randomblob(2)),2) || '-' || hex(randomblob(6)))),
+  user_id UUID NOT NULL,
+  name TEXT NOT NULL,
+  hash TEXT NOT NULL UNIQUE, -- SHA-256 of the token (hex)
+  scopes TEXT NOT NULL, -- Space separated (ex: 'tasks:read tasks:write')
+  expires_on TIMESTAMP, -- Never when NULL
+  created_on TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  last_used TIMESTAMP,
+  UNIQUE (user_id, name),
+  FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
 CREATE TABLE tasks (
   task_id UUID NOT NULL UNIQUE PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-' || '4' || substr(hex(randomblob(2)),2) || '-' || substr('89ab',abs(random()) % 4 + 1, 1) || substr(hex(randomblob(2)),2) || '-' || hex(randomblob(6)))),
   title TEXT NOT NULL,
   state INTEGER NOT NULL DEFAULT 0,
   priority INTEGER NOT NULL DEFAULT 0,
   description TEXT,
-  due_date TIMESTAMP,
-  do_date TIMESTAMP,
+  due_date TEXT, -- internal/caldate representation (ex: 2026-10-23)
+  do_date TEXT, -- internal/caldate representation (ex: 2026-10-23T09:00:00)
+  hidden_until TIMESTAMP, -- Task is left out of listings until then
   recurrence_pattern TEXT,
   recurrence_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+  checklist_done INTEGER NOT NULL DEFAULT 0,
+  checklist_total INTEGER NOT NULL DEFAULT 0,
+  created_on TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_on TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  owner UUID NOT NULL,
+  assignee UUID,
+  FOREIGN KEY (owner) REFERENCES users(user_id) ON DELETE CASCADE,
+  FOREIGN KEY (assignee) REFERENCES users(user_id) ON DELETE SET NULL
+);
+
+CREATE TABLE task_assignments (
+  assignment_id INTEGER PRIMARY KEY AUTOINCREMENT,
+  task_id UUID NOT NULL,
+  assignee UUID,
+  assigned_by UUID,
+  assigned_on TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (task_id) REFERENCES tasks(task_id) ON DELETE CASCADE,
+  FOREIGN KEY (assignee) REFERENCES users(user_id) ON DELETE SET NULL,
+  FOREIGN KEY (assigned_by) REFERENCES users(user_id) ON DELETE SET NULL
+);
+
+CREATE TABLE time_entries (
+  entry_id UUID NOT NULL UNIQUE PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-' || '4' || substr(hex(randomblob(2)),2) || '-' || substr('89ab',abs(random()) % 4 + 1, 1) || substr(hex(randomblob(2)),2) || '-' || hex(randomblob(6)))),
+  task_id UUID NOT NULL,
+  user_id UUID NOT NULL,
+  started_on TIMESTAMP NOT NULL,
+  ended_on TIMESTAMP, -- NULL while the timer is running
+  note TEXT,
+  FOREIGN KEY (task_id) REFERENCES tasks(task_id) ON DELETE CASCADE,
+  FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+  CHECK (ended_on IS NULL OR ended_on >= started_on)
+);
+
+CREATE TABLE saved_filters (
+  filter_id UUID NOT NULL UNIQUE PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-' || '4' || substr(hex(randomblob(2)),2) || '-' || substr('89ab',abs(random()) % 4 + 1, 1) || substr(hex(randomblob(2)),2) || '-' || hex(randomblob(6)))),
+  owner UUID NOT NULL,
+  name TEXT NOT NULL,
+  definition BLOB NOT NULL, -- protobuf encoded TaskFilter
+  created_on TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_on TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (owner, name),
+  FOREIGN KEY (owner) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE TABLE templates (
+  template_id UUID NOT NULL UNIQUE PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-' || '4' || substr(hex(randomblob(2)),2) || '-' || substr('89ab',abs(random()) % 4 + 1, 1) || substr(hex(randomblob(2)),2) || '-' || hex(randomblob(6)))),
+  owner UUID NOT NULL,
+  name TEXT NOT NULL,
+  definition BLOB NOT NULL, -- protobuf encoded TemplateData
   created_on TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
   updated_on TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (owner, name),
+  FOREIGN KEY (owner) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE TABLE custom_fields (
+  field_id UUID NOT NULL UNIQUE PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-' || '4' || substr(hex(randomblob(2)),2) || '-' || substr('89ab',abs(random()) % 4 + 1, 1) || substr(hex(randomblob(2)),2) || '-' || hex(randomblob(6)))),
   owner UUID NOT NULL,
+  name TEXT NOT NULL,
+  kind INTEGER NOT NULL, -- CustomFieldKind
+  options TEXT, -- JSON array of the values allowed by enum fields
+  created_on TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_on TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (owner, name),
   FOREIGN KEY (owner) REFERENCES users(user_id) ON DELETE CASCADE
 );
 
+CREATE TABLE task_field_values (
+  task_id UUID NOT NULL,
+  field_id UUID NOT NULL,
+  value TEXT NOT NULL, -- canonical text representation based on the field kind
+  PRIMARY KEY (task_id, field_id),
+  FOREIGN KEY (task_id) REFERENCES tasks(task_id) ON DELETE CASCADE,
+  FOREIGN KEY (field_id) REFERENCES custom_fields(field_id) ON DELETE CASCADE
+);
+
 CREATE TABLE tags (
   tag_id INTEGER PRIMARY KEY AUTOINCREMENT,
   name TEXT NOT NULL UNIQUE
@@ -42,9 +174,74 @@ CREATE TABLE task_tags (
   FOREIGN KEY (tag_id) REFERENCES Tags(tag_id) ON DELETE CASCADE
 );
 
-CREATE TABLE revoked_tokens (
+CREATE TABLE task_positions (
+  task_id UUID NOT NULL,
+  scope TEXT NOT NULL, -- '' for the main list, otherwise the tag of a tag view
+  sort_key TEXT NOT NULL, -- fractional index (see internal/fracindex)
+  PRIMARY KEY (task_id, scope),
+  FOREIGN KEY (task_id) REFERENCES tasks(task_id) ON DELETE CASCADE
+);
+
+-- Accounts of users at the OpenID Connect provider, identified by their
+-- subject as emails can change
+CREATE TABLE oidc_identities (
+  issuer TEXT NOT NULL,
+  subject TEXT NOT NULL,
+  user_id UUID NOT NULL,
+  created_on TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  last_used TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (issuer, subject),
+  FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE TABLE user_totp (
+  user_id UUID PRIMARY KEY,
+  secret TEXT NOT NULL, -- Base32
+  confirmed BOOLEAN NOT NULL DEFAULT FALSE, -- Only enforced at login once confirmed
+  last_step INTEGER NOT NULL DEFAULT 0, -- Latest time step used so that codes can't be replayed
+  created_on TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE TABLE recovery_codes (
+  code_id UUID NOT NULL UNIQUE PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-' || '4' || substr(hex(randomblob(2)),2) || '-' || substr('89ab',abs(random()) % 4 + 1, 1) || substr(hex(randomblob(2)),2) || '-' || hex(randomblob(6)))),
+  user_id UUID NOT NULL,
+  salt TEXT NOT NULL,
+  hash TEXT NOT NULL, -- Codes are deleted once used
+  FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE TABLE sessions (
+  session_id UUID NOT NULL UNIQUE PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-' || '4' || substr(hex(randomblob(2)),2) || '-' || substr('89ab',abs(random()) % 4 + 1, 1) || substr(hex(randomblob(2)),2) || '-' || hex(randomblob(6)))),
+  user_id UUID NOT NULL,
+  device TEXT NOT NULL, -- Name given by the client (its user agent by default)
+  ip TEXT NOT NULL, -- Address the session was last used from
+  expires_on TIMESTAMP NOT NULL, -- Expiry of the latest refresh token issued to the session
+  created_on TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  last_used TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+-- Tokens refreshed from one another form a family: the session they were
+-- issued to. Rows are kept until their token expires.
+CREATE TABLE issued_tokens (
   token_id UUID PRIMARY KEY,
-  expiry TIMESTAMP NOT NULL
+  family_id UUID NOT NULL,
+  user_id UUID NOT NULL,
+  type TEXT NOT NULL CHECK (type IN ('access', 'refresh')),
+  expiry TIMESTAMP NOT NULL,
+  revoked_on TIMESTAMP, -- NULL while the token is usable
+  FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE TABLE security_events (
+  event_id UUID NOT NULL UNIQUE PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-' || '4' || substr(hex(randomblob(2)),2) || '-' || substr('89ab',abs(random()) % 4 + 1, 1) || substr(hex(randomblob(2)),2) || '-' || hex(randomblob(6)))),
+  user_id UUID NOT NULL,
+  kind TEXT NOT NULL, -- ex: refresh_token_reuse
+  detail TEXT NOT NULL,
+  ip TEXT NOT NULL, -- Address the event originated from
+  created_on TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
 );
 
 CREATE TABLE roles (
@@ -0,0 +1,246 @@
+// directory lets users log in with the credentials of an LDAP directory
+// (ex: OpenLDAP, Active Directory) instead of a password stored by rafta.
+// Users are found with a service account, their password is checked by
+// binding as them and the groups they belong to can grant them roles.
+package directory
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	DefaultUserFilter  = "(mail=%s)"
+	DefaultGroupFilter = "(member=%s)"
+
+	dialTimeout    = 10 * time.Second
+	requestTimeout = 10 * time.Second
+)
+
+var (
+	ErrUserNotFound  = errors.New("user not found in directory")
+	ErrInvalidCreds  = errors.New("invalid directory credentials")
+	errAmbiguousUser = errors.New("several directory entries match the login")
+	errMissingEmail  = errors.New("directory entry has no email")
+)
+
+// Config describes how to reach the directory and find users in it.
+type Config struct {
+	URL          string // ldap:// or ldaps://
+	StartTLS     bool
+	BindDN       string // Service account searching the directory (anonymous when empty)
+	BindPassword string
+	BaseDN       string
+	UserFilter   string // %s is replaced by the login (DefaultUserFilter when empty)
+	// Groups are searched for under GroupBaseDN when set, otherwise they're
+	// read from the memberOf attribute of users
+	GroupBaseDN string
+	GroupFilter string            // %s is replaced by the DN of the user (DefaultGroupFilter when empty)
+	GroupRoles  map[string]string // Role granted by each group, by DN
+}
+
+// Entry is a user as found in the directory.
+type Entry struct {
+	DN     string
+	Email  string
+	Name   string
+	Groups []string // DNs
+}
+
+type Directory struct {
+	cfg Config
+	// Groups are matched by DN rather than by name so that a group created
+	// anywhere in the directory can't grant roles by reusing a name
+	groupRoles []groupRole
+}
+
+type groupRole struct {
+	group *ldap.DN
+	role  string
+}
+
+func New(cfg Config) (*Directory, error) {
+	if cfg.UserFilter == "" {
+		cfg.UserFilter = DefaultUserFilter
+	}
+	if cfg.GroupFilter == "" {
+		cfg.GroupFilter = DefaultGroupFilter
+	}
+	d := &Directory{cfg: cfg}
+	for group, role := range cfg.GroupRoles {
+		dn, err := ldap.ParseDN(group)
+		if err != nil || len(dn.RDNs) == 0 {
+			return nil, fmt.Errorf("invalid group DN '%s': %w", group, err)
+		}
+		d.groupRoles = append(d.groupRoles, groupRole{group: dn, role: strings.ToUpper(role)})
+	}
+	return d, nil
+}
+
+// Authenticate finds the user a login belongs to and checks their password
+// by binding as them. ErrUserNotFound is returned when the directory doesn't
+// know the login so that other accounts can be tried.
+func (d *Directory) Authenticate(login, passwd string) (Entry, error) {
+	conn, err := d.connect()
+	if err != nil {
+		return Entry{}, err
+	}
+	defer conn.Close()
+
+	entry, err := d.findUser(conn, login)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	// Directories treat binds without a password as anonymous ones, which
+	// always succeed
+	if passwd == "" {
+		return Entry{}, ErrInvalidCreds
+	}
+	if err := conn.Bind(entry.DN, passwd); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return Entry{}, ErrInvalidCreds
+		}
+		return Entry{}, fmt.Errorf("failed to bind as '%s': %w", entry.DN, err)
+	}
+
+	if d.cfg.GroupBaseDN != "" {
+		// Back to the service account, users can't always list groups
+		if err := d.bind(conn); err != nil {
+			return Entry{}, err
+		}
+		if entry.Groups, err = d.searchGroups(conn, entry.DN); err != nil {
+			return Entry{}, err
+		}
+	}
+	return entry, nil
+}
+
+// Roles returns the roles the groups of a user grant them.
+func (d *Directory) Roles(entry Entry) []string {
+	roles := []string{}
+	for _, group := range entry.Groups {
+		dn, err := ldap.ParseDN(group)
+		if err != nil {
+			continue
+		}
+		for _, mapped := range d.groupRoles {
+			if mapped.group.EqualFold(dn) {
+				roles = append(roles, mapped.role)
+			}
+		}
+	}
+	return roles
+}
+
+// ManagedRoles are the roles granted through groups. Users lose them when
+// they leave the groups while other roles are left alone.
+func (d *Directory) ManagedRoles() []string {
+	roles := make([]string, 0, len(d.groupRoles))
+	for _, mapped := range d.groupRoles {
+		roles = append(roles, mapped.role)
+	}
+	// Several groups can grant the same role
+	slices.Sort(roles)
+	return slices.Compact(roles)
+}
+
+func (d *Directory) connect() (*ldap.Conn, error) {
+	conn, err := ldap.DialURL(d.cfg.URL,
+		ldap.DialWithDialer(&net.Dialer{Timeout: dialTimeout}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach directory: %w", err)
+	}
+	conn.SetTimeout(requestTimeout)
+
+	if d.cfg.StartTLS {
+		host := d.cfg.URL
+		if i := strings.Index(host, "://"); i >= 0 {
+			host = host[i+3:]
+		}
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if err := conn.StartTLS(&tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to start TLS with directory: %w", err)
+		}
+	}
+
+	if err := d.bind(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (d *Directory) bind(conn *ldap.Conn) error {
+	if d.cfg.BindDN == "" {
+		return nil
+	}
+	if err := conn.Bind(d.cfg.BindDN, d.cfg.BindPassword); err != nil {
+		return fmt.Errorf("failed to bind service account: %w", err)
+	}
+	return nil
+}
+
+func (d *Directory) findUser(conn *ldap.Conn, login string) (Entry, error) {
+	result, err := conn.Search(ldap.NewSearchRequest(
+		d.cfg.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		2, int(requestTimeout.Seconds()), false,
+		fmt.Sprintf(d.cfg.UserFilter, ldap.EscapeFilter(login)),
+		[]string{"mail", "displayName", "cn", "memberOf"},
+		nil,
+	))
+	// Hitting the size limit means the login is ambiguous, handled below
+	if err != nil && (result == nil || !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded)) {
+		return Entry{}, fmt.Errorf("failed to search users: %w", err)
+	}
+	switch {
+	case len(result.Entries) == 0:
+		return Entry{}, ErrUserNotFound
+	case len(result.Entries) > 1:
+		return Entry{}, errAmbiguousUser
+	}
+
+	found := result.Entries[0]
+	entry := Entry{
+		DN:    found.DN,
+		Email: strings.ToLower(strings.TrimSpace(found.GetEqualFoldAttributeValue("mail"))),
+		Name:  found.GetEqualFoldAttributeValue("displayName"),
+	}
+	if entry.Email == "" {
+		return Entry{}, fmt.Errorf("%w: '%s'", errMissingEmail, entry.DN)
+	}
+	if entry.Name == "" {
+		entry.Name = found.GetEqualFoldAttributeValue("cn")
+	}
+	entry.Groups = found.GetEqualFoldAttributeValues("memberOf")
+	return entry, nil
+}
+
+func (d *Directory) searchGroups(conn *ldap.Conn, userDN string) ([]string, error) {
+	result, err := conn.Search(ldap.NewSearchRequest(
+		d.cfg.GroupBaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		0, int(requestTimeout.Seconds()), false,
+		fmt.Sprintf(d.cfg.GroupFilter, ldap.EscapeFilter(userDN)),
+		[]string{"1.1"}, // No attributes, only DNs
+		nil,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search groups: %w", err)
+	}
+	groups := make([]string, 0, len(result.Entries))
+	for _, group := range result.Entries {
+		groups = append(groups, group.DN)
+	}
+	return groups, nil
+}
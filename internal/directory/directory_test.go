@@ -0,0 +1,314 @@
+package directory
+
+import (
+	"errors"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+)
+
+const (
+	serviceDN = "cn=rafta,ou=services,dc=example,dc=com"
+	adminsDN  = "cn=rafta-admins,ou=groups,dc=example,dc=com"
+)
+
+type testEntry struct {
+	dn       string
+	password string
+	attrs    map[string][]string
+}
+
+// testServer is a directory answering binds and searches with a single
+// equality or presence filter, which is all Directory sends.
+type testServer struct {
+	ln      net.Listener
+	entries []testEntry
+
+	mu      sync.Mutex
+	filters []string // Searched for, in order
+	binds   []string // DNs bound as, in order
+}
+
+func newServer(t *testing.T) *testServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	s := &testServer{ln: ln, entries: []testEntry{
+		{dn: serviceDN, password: "service-secret"},
+		{dn: "uid=jane,ou=people,dc=example,dc=com", password: "jane-secret", attrs: map[string][]string{
+			"mail":        {"Jane@Example.com"},
+			"cn":          {"Jane Doe"},
+			"displayName": {"Jane"},
+			"memberOf":    {"CN=Rafta-Admins,OU=Groups,DC=Example,DC=Com", "cn=rafta-admins,ou=elsewhere,dc=example,dc=com"},
+		}},
+		{dn: "uid=john,ou=people,dc=example,dc=com", password: "john-secret", attrs: map[string][]string{
+			"mail": {"john@example.com"},
+			"cn":   {"John Doe"},
+		}},
+		{dn: "uid=nomail,ou=people,dc=example,dc=com", password: "secret", attrs: map[string][]string{
+			"cn": {"No Mail"},
+		}},
+		{dn: adminsDN, attrs: map[string][]string{
+			"cn":     {"rafta-admins"},
+			"member": {"uid=jane,ou=people,dc=example,dc=com"},
+		}},
+		{dn: "cn=rafta-admins,ou=elsewhere,dc=example,dc=com", attrs: map[string][]string{
+			"cn":     {"rafta-admins"},
+			"member": {"uid=jane,ou=people,dc=example,dc=com", "uid=john,ou=people,dc=example,dc=com"},
+		}},
+	}}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *testServer) config() Config {
+	return Config{
+		URL:          "ldap://" + s.ln.Addr().String(),
+		BindDN:       serviceDN,
+		BindPassword: "service-secret",
+		BaseDN:       "ou=people,dc=example,dc=com",
+		GroupRoles:   map[string]string{adminsDN: "admin"},
+	}
+}
+
+func (s *testServer) serve(conn net.Conn) {
+	defer conn.Close()
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		id := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			dn, password := op.Children[1].Value.(string), op.Children[2].Data.String()
+			code := int64(ldap.LDAPResultInvalidCredentials)
+			for _, e := range s.entries {
+				if e.dn == dn && e.password != "" && e.password == password {
+					code = ldap.LDAPResultSuccess
+				}
+			}
+			s.mu.Lock()
+			s.binds = append(s.binds, dn)
+			s.mu.Unlock()
+			conn.Write(response(id, ldap.ApplicationBindResponse, code).Bytes()) //nolint:errcheck
+
+		case ldap.ApplicationSearchRequest:
+			base := op.Children[0].Value.(string)
+			filter, err := ldap.DecompileFilter(op.Children[6])
+			if err != nil {
+				conn.Write(response(id, ldap.ApplicationSearchResultDone, ldap.LDAPResultProtocolError).Bytes()) //nolint:errcheck
+				continue
+			}
+			s.mu.Lock()
+			s.filters = append(s.filters, filter)
+			s.mu.Unlock()
+
+			attr, value, _ := strings.Cut(strings.TrimSuffix(strings.TrimPrefix(filter, "("), ")"), "=")
+			for _, e := range s.entries {
+				if !strings.HasSuffix(strings.ToLower(e.dn), strings.ToLower(base)) {
+					continue
+				}
+				values := e.attrs[attr]
+				if (value == "*" && len(values) > 0) || slices.ContainsFunc(values, func(v string) bool {
+					return strings.EqualFold(v, value)
+				}) {
+					conn.Write(searchEntry(id, e).Bytes()) //nolint:errcheck
+				}
+			}
+			conn.Write(response(id, ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess).Bytes()) //nolint:errcheck
+
+		case ldap.ApplicationUnbindRequest:
+			return
+		}
+	}
+}
+
+func message(id int64, op *ber.Packet) *ber.Packet {
+	p := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, ""))
+	p.AppendChild(op)
+	return p
+}
+
+func response(id int64, tag ber.Tag, code int64) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+	op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, ""))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	return message(id, op)
+}
+
+func searchEntry(id int64, e testEntry) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "")
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.dn, ""))
+	attrs := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+	for name, values := range e.attrs {
+		attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+		attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, ""))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+		for _, v := range values {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, ""))
+		}
+		attr.AppendChild(set)
+		attrs.AppendChild(attr)
+	}
+	op.AppendChild(attrs)
+	return message(id, op)
+}
+
+func newDirectory(t *testing.T, cfg Config) *Directory {
+	t.Helper()
+	d, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New() = %v", err)
+	}
+	return d
+}
+
+func TestAuthenticate(t *testing.T) {
+	s := newServer(t)
+	d := newDirectory(t, s.config())
+
+	entry, err := d.Authenticate("jane@example.com", "jane-secret")
+	if err != nil {
+		t.Fatalf("Authenticate() = %v", err)
+	}
+	if entry.DN != "uid=jane,ou=people,dc=example,dc=com" || entry.Email != "jane@example.com" || entry.Name != "Jane" {
+		t.Errorf("Authenticate() = %+v", entry)
+	}
+	if !slices.Contains(s.binds, entry.DN) {
+		t.Errorf("never bound as the user (binds: %v)", s.binds)
+	}
+}
+
+func TestAuthenticateWrongPassword(t *testing.T) {
+	s := newServer(t)
+	d := newDirectory(t, s.config())
+
+	if _, err := d.Authenticate("jane@example.com", "john-secret"); !errors.Is(err, ErrInvalidCreds) {
+		t.Errorf("Authenticate() = %v, want ErrInvalidCreds", err)
+	}
+}
+
+func TestAuthenticateEmptyPassword(t *testing.T) {
+	s := newServer(t)
+	d := newDirectory(t, s.config())
+
+	if _, err := d.Authenticate("jane@example.com", ""); !errors.Is(err, ErrInvalidCreds) {
+		t.Errorf("Authenticate() = %v, want ErrInvalidCreds", err)
+	}
+	// Directories accept binds without a password (anonymous ones)
+	if slices.Contains(s.binds, "uid=jane,ou=people,dc=example,dc=com") {
+		t.Error("bound as the user without a password")
+	}
+}
+
+func TestAuthenticateUnknownUser(t *testing.T) {
+	s := newServer(t)
+	d := newDirectory(t, s.config())
+
+	if _, err := d.Authenticate("admin@localhost", "password"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Authenticate() = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestAuthenticateEscapesLogin(t *testing.T) {
+	s := newServer(t)
+	d := newDirectory(t, s.config())
+
+	// Unescaped, it would match every user with an email
+	if _, err := d.Authenticate("*", "jane-secret"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Authenticate() = %v, want ErrUserNotFound", err)
+	}
+	if _, err := d.Authenticate("jane@example.com)(uid=*", "jane-secret"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Authenticate() = %v, want ErrUserNotFound", err)
+	}
+	want := []string{`(mail=\2a)`, `(mail=jane@example.com\29\28uid=\2a)`}
+	if !slices.Equal(s.filters, want) {
+		t.Errorf("searched for %q, want %q", s.filters, want)
+	}
+}
+
+func TestAuthenticateMissingEmail(t *testing.T) {
+	s := newServer(t)
+	cfg := s.config()
+	cfg.UserFilter = "(cn=%s)"
+	d := newDirectory(t, cfg)
+
+	if _, err := d.Authenticate("No Mail", "secret"); !errors.Is(err, errMissingEmail) {
+		t.Errorf("Authenticate() = %v, want errMissingEmail", err)
+	}
+}
+
+func TestRolesFromMemberOf(t *testing.T) {
+	s := newServer(t)
+	d := newDirectory(t, s.config())
+
+	entry, err := d.Authenticate("jane@example.com", "jane-secret")
+	if err != nil {
+		t.Fatalf("Authenticate() = %v", err)
+	}
+	// The group of the same name elsewhere grants nothing
+	if roles := d.Roles(entry); !slices.Equal(roles, []string{"ADMIN"}) {
+		t.Errorf("Roles() = %v, want [ADMIN]", roles)
+	}
+}
+
+func TestRolesFromGroupSearch(t *testing.T) {
+	s := newServer(t)
+	cfg := s.config()
+	cfg.GroupBaseDN = "dc=example,dc=com"
+	d := newDirectory(t, cfg)
+
+	for login, want := range map[string][]string{
+		"jane@example.com": {"ADMIN"},
+		"john@example.com": {},
+	} {
+		entry, err := d.Authenticate(login, strings.Split(login, "@")[0]+"-secret")
+		if err != nil {
+			t.Fatalf("Authenticate(%s) = %v", login, err)
+		}
+		if roles := d.Roles(entry); !slices.Equal(roles, want) {
+			t.Errorf("Roles(%s) = %v, want %v", login, roles, want)
+		}
+	}
+	// Groups are searched for as the service account
+	if last := s.binds[len(s.binds)-1]; last != serviceDN {
+		t.Errorf("last bound as %s, want the service account", last)
+	}
+}
+
+func TestManagedRoles(t *testing.T) {
+	d := newDirectory(t, Config{GroupRoles: map[string]string{
+		adminsDN:                                  "admin",
+		"cn=owners,ou=groups,dc=example,dc=com":   "ADMIN",
+		"cn=auditors,ou=groups,dc=example,dc=com": "auditor",
+	}})
+	if roles := d.ManagedRoles(); !slices.Equal(roles, []string{"ADMIN", "AUDITOR"}) {
+		t.Errorf("ManagedRoles() = %v, want [ADMIN AUDITOR]", roles)
+	}
+}
+
+func TestNewInvalidGroupDN(t *testing.T) {
+	if _, err := New(Config{GroupRoles: map[string]string{"rafta-admins": "ADMIN"}}); err == nil {
+		t.Error("New() accepted a group name instead of a DN")
+	}
+}
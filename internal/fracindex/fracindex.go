@@ -0,0 +1,126 @@
+// fracindex implements fractional indexing: keys that sort lexicographically
+// and between which a new key can always be generated. Moving an item in a
+// manually ordered list therefore only requires updating that item's key.
+//
+// Keys are the digits of a base 62 fraction in [0, 1) (without the leading
+// "0."). They never end with the zero digit so that every fraction has a
+// single representation and byte-wise comparison matches numeric order.
+package fracindex
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const digits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+const base = len(digits)
+
+var ErrInvalidKey = errors.New("invalid fractional index key")
+
+// Between returns a key sorting strictly after a and strictly before b. An
+// empty a means the start of the list and an empty b its end.
+func Between(a, b string) (string, error) {
+	for _, key := range []string{a, b} {
+		if err := validate(key); err != nil {
+			return "", err
+		}
+	}
+	if b != "" && a >= b {
+		return "", fmt.Errorf("%w: '%s' doesn't sort before '%s'", ErrInvalidKey, a, b)
+	}
+	return midpoint(a, b), nil
+}
+
+// After returns a key sorting strictly after a (empty for an empty list).
+// Unlike Between(a, ""), which halves the space left after a, it increments a
+// digit of a so that the keys of items appended one after the other stay
+// short.
+func After(a string) (string, error) {
+	if err := validate(a); err != nil {
+		return "", err
+	}
+	if a == "" {
+		return midpoint("", ""), nil
+	}
+	for i := range len(a) {
+		if d := strings.IndexByte(digits, a[i]); d < base-1 {
+			return a[:i] + string(digits[d+1]), nil
+		}
+	}
+	return a + digits[1:2], nil
+}
+
+// Spread returns n evenly spaced keys in ascending order, leaving room to
+// insert keys between each of them.
+func Spread(n int) []string {
+	// Aim for gaps of at least one full digit between consecutive keys
+	width, space := 1, base
+	for space/(n+1) < base {
+		width++
+		space *= base
+	}
+
+	keys := make([]string, n)
+	for i := range keys {
+		v := (i + 1) * (space / (n + 1))
+		key := make([]byte, width)
+		for j := width - 1; j >= 0; j-- {
+			key[j] = digits[v%base]
+			v /= base
+		}
+		keys[i] = strings.TrimRight(string(key), digits[:1])
+	}
+	return keys
+}
+
+func validate(key string) error {
+	if strings.HasSuffix(key, digits[:1]) {
+		return fmt.Errorf("%w '%s': trailing zero", ErrInvalidKey, key)
+	}
+	for _, c := range key {
+		if !strings.ContainsRune(digits, c) {
+			return fmt.Errorf("%w '%s': unexpected character '%c'", ErrInvalidKey, key, c)
+		}
+	}
+	return nil
+}
+
+// midpoint returns a key between a and b (b empty meaning unbounded). Both
+// keys must be valid and a must sort before b.
+func midpoint(a, b string) string {
+	if b != "" {
+		// Keep the common prefix (a being padded with zeros)
+		n := 0
+		for n < len(b) && digitAt(a, n) == strings.IndexByte(digits, b[n]) {
+			n++
+		}
+		if n > 0 {
+			return b[:n] + midpoint(a[min(n, len(a)):], b[n:])
+		}
+	}
+
+	digitA, digitB := digitAt(a, 0), base
+	if b != "" {
+		digitB = strings.IndexByte(digits, b[0])
+	}
+	if digitB-digitA > 1 {
+		return string(digits[(digitA+digitB+1)/2])
+	}
+
+	// Consecutive digits: truncating b is enough when it has more digits,
+	// otherwise extend a past its first digit.
+	if len(b) > 1 {
+		return b[:1]
+	}
+	return string(digits[digitA]) + midpoint(a[min(1, len(a)):], "")
+}
+
+// digitAt returns the value of the ith digit of a key (zero past its end).
+func digitAt(key string, i int) int {
+	if i >= len(key) {
+		return 0
+	}
+	return strings.IndexByte(digits, key[i])
+}
@@ -0,0 +1,22 @@
+package intercept
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// WithContext replaces the context of a stream so that stream interceptors
+// can pass values down the line like unary ones do.
+func WithContext(ss grpc.ServerStream, ctx context.Context) grpc.ServerStream {
+	return &contextStream{ServerStream: ss, ctx: ctx}
+}
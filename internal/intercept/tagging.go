@@ -12,13 +12,21 @@ import (
 
 // gRPC interceptor to tag requests with a unique identifier and other unique attributes to ease logging
 func Tagging(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(tag(ctx, info.Server, info.FullMethod), req)
+}
+
+// StreamTagging is the streaming counterpart of Tagging.
+func StreamTagging(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, WithContext(ss, tag(ss.Context(), srv, info.FullMethod)))
+}
+
+func tag(ctx context.Context, server any, method string) context.Context {
 	id, err := uuid.GenerateUUID()
 	if err != nil {
 		slog.ErrorContext(ctx, "Unable to generate UUID for request", logging.ErrKey, err)
 	}
 	ctx = context.WithValue(ctx, util.ReqIDKey, id)
-	ctx = context.WithValue(ctx, util.ProtoServerKey, info.Server)
-	ctx = context.WithValue(ctx, util.ProtoMethodKey, info.FullMethod)
-
-	return handler(ctx, req)
+	ctx = context.WithValue(ctx, util.ProtoServerKey, server)
+	ctx = context.WithValue(ctx, util.ProtoMethodKey, method)
+	return ctx
 }
@@ -0,0 +1,91 @@
+package mail
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"text/template"
+	"time"
+)
+
+// DigestTask is a task as listed in a digest.
+type DigestTask struct {
+	Title string
+	When  string // ex: "All day", "14:30", "Oct 17"
+}
+
+// Digest sums up the day of a user.
+type Digest struct {
+	Name    string
+	Day     time.Time
+	Overdue []DigestTask
+	Due     []DigestTask // Due on Day
+	Do      []DigestTask // Planned for Day
+}
+
+func (d Digest) Empty() bool {
+	return len(d.Overdue) == 0 && len(d.Due) == 0 && len(d.Do) == 0
+}
+
+var digestText = template.Must(template.New("digest").Parse(
+	`Hi {{.Name}},
+
+Here is your day for {{.Day.Format "Monday, January 2"}}.
+{{- define "tasks"}}{{range .}}
+  - {{.Title}} ({{.When}}){{end}}{{end}}
+{{- if .Overdue}}
+
+Overdue:{{template "tasks" .Overdue}}{{end}}
+{{- if .Due}}
+
+Due today:{{template "tasks" .Due}}{{end}}
+{{- if .Do}}
+
+Planned for today:{{template "tasks" .Do}}{{end}}
+
+--
+Rafta. Clear your daily digest time in your preferences to stop these emails.
+`))
+
+var digestHTML = htmltemplate.Must(htmltemplate.New("digest").Parse(
+	`<!DOCTYPE html>
+<html>
+<body style="font-family: sans-serif; line-height: 1.4;">
+<p>Hi {{.Name}},</p>
+<p>Here is your day for <strong>{{.Day.Format "Monday, January 2"}}</strong>.</p>
+{{- define "tasks"}}
+<ul>
+{{- range .}}
+  <li>{{.Title}} <span style="color: #666;">({{.When}})</span></li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .Overdue}}
+<h3 style="color: #b00020;">Overdue</h3>{{template "tasks" .Overdue}}
+{{- end}}
+{{- if .Due}}
+<h3>Due today</h3>{{template "tasks" .Due}}
+{{- end}}
+{{- if .Do}}
+<h3>Planned for today</h3>{{template "tasks" .Do}}
+{{- end}}
+<p style="color: #666; font-size: small;">Rafta. Clear your daily digest time in your preferences to stop these emails.</p>
+</body>
+</html>
+`))
+
+// Message renders the digest as an email.
+func (d Digest) Message(to string) (Message, error) {
+	var text, html bytes.Buffer
+	if err := digestText.Execute(&text, d); err != nil {
+		return Message{}, err
+	}
+	if err := digestHTML.Execute(&html, d); err != nil {
+		return Message{}, err
+	}
+	return Message{
+		To:      to,
+		Subject: "Your tasks for " + d.Day.Format("Monday, January 2"),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
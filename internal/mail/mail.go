@@ -0,0 +1,203 @@
+// mail sends the emails rafta writes to its users (ex: daily digests)
+// through an SMTP relay. Messages carry both a plain text and an HTML
+// version so that any mail client can display them.
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Security uint8
+
+const (
+	StartTLS    Security = iota // Upgrade a plain connection (usually port 587)
+	ImplicitTLS                 // TLS from the start (usually port 465)
+	NoTLS                       // Local relays and test sinks only
+)
+
+const dialTimeout = 30 * time.Second
+
+var ErrUnknownSecurity = errors.New("unknown SMTP security (expected starttls, tls or none)")
+
+func ParseSecurity(s string) (Security, error) {
+	switch strings.ToLower(s) {
+	case "starttls":
+		return StartTLS, nil
+	case "tls":
+		return ImplicitTLS, nil
+	case "none":
+		return NoTLS, nil
+	default:
+		return 0, fmt.Errorf("%w: '%s'", ErrUnknownSecurity, s)
+	}
+}
+
+// Config describes how to reach the SMTP relay. Authentication is skipped
+// without a username.
+type Config struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string // ex: "Rafta <rafta@example.com>"
+	Security Security
+}
+
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+type Mailer struct {
+	cfg Config
+}
+
+func New(cfg Config) (*Mailer, error) {
+	if _, err := mail.ParseAddress(cfg.From); err != nil {
+		return nil, fmt.Errorf("invalid sender address '%s': %w", cfg.From, err)
+	}
+	return &Mailer{cfg: cfg}, nil
+}
+
+// Send delivers a message through the relay.
+func (m *Mailer) Send(ctx context.Context, msg Message) error {
+	from, _ := mail.ParseAddress(m.cfg.From) // Validated by New
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address '%s': %w", msg.To, err)
+	}
+
+	body, err := m.compose(from, to, msg)
+	if err != nil {
+		return err
+	}
+
+	c, err := m.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if m.cfg.Security == StartTLS {
+		if err := c.StartTLS(&tls.Config{ServerName: m.cfg.Host}); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+	if m.cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)); err != nil {
+			return fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+
+	if err := c.Mail(from.Address); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to.Address); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func (m *Mailer) dial(ctx context.Context) (*smtp.Client, error) {
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+	ctx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+
+	var (
+		conn net.Conn
+		err  error
+	)
+	if m.cfg.Security == ImplicitTLS {
+		d := &tls.Dialer{Config: &tls.Config{ServerName: m.cfg.Host}}
+		conn, err = d.DialContext(ctx, "tcp", addr)
+	} else {
+		var d net.Dialer
+		conn, err = d.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach SMTP relay: %w", err)
+	}
+	// Bounds the whole conversation with the relay
+	conn.SetDeadline(time.Now().Add(dialTimeout)) //nolint:errcheck
+
+	c, err := smtp.NewClient(conn, m.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// compose builds a multipart/alternative message out of the text and HTML
+// versions.
+func (m *Mailer) compose(from, to *mail.Address, msg Message) ([]byte, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+
+	id := make([]byte, 16)
+	rand.Read(id) //nolint:errcheck
+	domain := from.Address[strings.LastIndex(from.Address, "@")+1:]
+
+	headers := []string{
+		"From: " + from.String(),
+		"To: " + to.String(),
+		"Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"Message-ID: <" + hex.EncodeToString(id) + "@" + domain + ">",
+		"MIME-Version: 1.0",
+		"Content-Type: multipart/alternative; boundary=" + w.Boundary(),
+	}
+	buf.WriteString(strings.Join(headers, "\r\n") + "\r\n\r\n")
+
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", msg.Text},
+		{"text/html; charset=utf-8", msg.HTML},
+	} {
+		pw, err := w.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(pw)
+		if _, err := qp.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
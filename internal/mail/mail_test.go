@@ -0,0 +1,243 @@
+package mail
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// received is what a sink got from a single SMTP session.
+type received struct {
+	from, to string
+	auth     string // Decoded AUTH PLAIN response
+	data     string
+}
+
+// sink is a local SMTP relay accepting everything except recipients
+// starting with "reject".
+type sink struct {
+	ln       net.Listener
+	sessions chan received
+}
+
+func newSink(t *testing.T) *sink {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	s := &sink{ln: ln, sessions: make(chan received, 1)}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *sink) config() Config {
+	host, port, _ := net.SplitHostPort(s.ln.Addr().String())
+	p, _ := strconv.Atoi(port)
+	return Config{Host: host, Port: p, From: "Rafta <rafta@example.com>", Security: NoTLS}
+}
+
+func (s *sink) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { fmt.Fprintf(conn, "%s\r\n", line) }
+
+	var got received
+	reply("220 sink ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			reply("250-sink")
+			reply("250 AUTH PLAIN")
+		case "AUTH":
+			_, resp, _ := strings.Cut(arg, " ")
+			decoded, _ := base64.StdEncoding.DecodeString(resp)
+			got.auth = string(decoded)
+			reply("235 Authenticated")
+		case "MAIL":
+			got.from = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+			reply("250 OK")
+		case "RCPT":
+			got.to = strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>")
+			if strings.HasPrefix(got.to, "reject") {
+				reply("550 No such user")
+				continue
+			}
+			reply("250 OK")
+		case "DATA":
+			reply("354 Go ahead")
+			var data strings.Builder
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(line, "."))
+			}
+			got.data = data.String()
+			reply("250 Queued")
+		case "QUIT":
+			reply("221 Bye")
+			s.sessions <- got
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func (s *sink) session(t *testing.T) received {
+	t.Helper()
+	select {
+	case got := <-s.sessions:
+		return got
+	case <-time.After(5 * time.Second):
+		t.Fatal("sink never got a message")
+		return received{}
+	}
+}
+
+func TestSend(t *testing.T) {
+	s := newSink(t)
+	m, err := New(s.config())
+	if err != nil {
+		t.Fatalf("New() = %v", err)
+	}
+
+	err = m.Send(context.Background(), Message{
+		To:      "Jane <jane@example.com>",
+		Subject: "Tâches du jour",
+		Text:    "Plain version",
+		HTML:    "<p>HTML version</p>",
+	})
+	if err != nil {
+		t.Fatalf("Send() = %v", err)
+	}
+
+	got := s.session(t)
+	if got.from != "rafta@example.com" || got.to != "jane@example.com" {
+		t.Errorf("envelope from %q to %q", got.from, got.to)
+	}
+	if got.auth != "" {
+		t.Errorf("authenticated without a username (%q)", got.auth)
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(got.data))
+	if err != nil {
+		t.Fatalf("failed to parse message: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || subject != "Tâches du jour" {
+		t.Errorf("subject = %q (%v)", subject, err)
+	}
+	if id := msg.Header.Get("Message-ID"); !strings.HasSuffix(id, "@example.com>") {
+		t.Errorf("Message-ID = %q", id)
+	}
+
+	_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("failed to parse content type: %v", err)
+	}
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	for _, want := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", "Plain version"},
+		{"text/html; charset=utf-8", "<p>HTML version</p>"},
+	} {
+		part, err := parts.NextPart() // Decodes quoted-printable
+		if err != nil {
+			t.Fatalf("missing %s part: %v", want.contentType, err)
+		}
+		content, _ := io.ReadAll(part)
+		if ct := part.Header.Get("Content-Type"); ct != want.contentType || string(content) != want.content {
+			t.Errorf("part %s = %q, want %s %q", ct, content, want.contentType, want.content)
+		}
+	}
+}
+
+func TestSendAuthenticates(t *testing.T) {
+	s := newSink(t)
+	cfg := s.config()
+	cfg.Username, cfg.Password = "rafta", "hunter2"
+	m, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New() = %v", err)
+	}
+
+	if err := m.Send(context.Background(), Message{To: "jane@example.com", Subject: "Hi"}); err != nil {
+		t.Fatalf("Send() = %v", err)
+	}
+	if got := s.session(t); got.auth != "\x00rafta\x00hunter2" {
+		t.Errorf("AUTH PLAIN = %q", got.auth)
+	}
+}
+
+func TestSendRejectedRecipient(t *testing.T) {
+	s := newSink(t)
+	m, err := New(s.config())
+	if err != nil {
+		t.Fatalf("New() = %v", err)
+	}
+
+	err = m.Send(context.Background(), Message{To: "rejected@example.com", Subject: "Hi"})
+	if err == nil || !strings.Contains(err.Error(), "550") {
+		t.Errorf("Send() = %v, want the relay's rejection", err)
+	}
+}
+
+func TestSendUnreachableRelay(t *testing.T) {
+	s := newSink(t)
+	cfg := s.config()
+	s.ln.Close()
+	m, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New() = %v", err)
+	}
+
+	if err := m.Send(context.Background(), Message{To: "jane@example.com"}); err == nil {
+		t.Error("Send() succeeded without a relay")
+	}
+}
+
+func TestNewInvalidSender(t *testing.T) {
+	if _, err := New(Config{From: "not an address"}); err == nil {
+		t.Error("New() accepted an invalid sender")
+	}
+}
+
+func TestSendInvalidRecipient(t *testing.T) {
+	m, err := New(Config{From: "rafta@example.com"})
+	if err != nil {
+		t.Fatalf("New() = %v", err)
+	}
+	if err := m.Send(context.Background(), Message{To: "jane"}); err == nil {
+		t.Error("Send() accepted an invalid recipient")
+	}
+}
@@ -0,0 +1,245 @@
+// markdown handles the little bit of markdown parsing rafta needs to perform
+// on task descriptions (ex: GitHub-style task lists). It is not a renderer and
+// only understands enough of CommonMark to avoid false positives (code blocks,
+// block quotes, etc...).
+package markdown
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var ErrItemOutOfRange = errors.New("checklist item index is out of range")
+
+// taskItem matches a list item (bullet or ordered) starting with a checkbox.
+// The checkbox must be followed by whitespace or the end of the line.
+var taskItem = regexp.MustCompile(`^[ \t]*(?:[-*+]|[0-9]{1,9}[.)])[ \t]+\[([ xX])\](?:[ \t]|$)`)
+
+// ChecklistItem is a single `- [ ]` / `- [x]` entry found in a document.
+type ChecklistItem struct {
+	// Offset is the byte offset of the checkbox state character (the space or
+	// 'x' between brackets) within the original document.
+	Offset  int
+	Checked bool
+}
+
+// fence keeps track of an open fenced code block.
+type fence struct {
+	char   byte
+	size   int
+	depth  int // block quote depth the fence was opened in
+	column int // content column of the list item the fence was opened in
+}
+
+// Checklist returns every task list item of a markdown document in order of
+// appearance. Items nested in block quotes are included, while anything
+// inside fenced or indented code blocks is ignored.
+func Checklist(src string) []ChecklistItem {
+	var (
+		items   []ChecklistItem
+		open    *fence
+		inList  bool // the current block is part of a list
+		listCol int  // content column of the latest list item
+		offset  int
+	)
+
+	for _, line := range strings.SplitAfter(src, "\n") {
+		lineStart := offset
+		offset += len(line)
+		line = strings.TrimRight(line, "\r\n")
+
+		depth, content, contentStart := stripQuotes(line)
+
+		// A fence opened in a block quote (or a list item) is closed once the
+		// quote (or the item) ends.
+		if open != nil && (depth < open.depth ||
+			(strings.TrimSpace(content) != "" && indentWidth(content) < open.column)) {
+			open = nil
+		}
+
+		if open != nil {
+			if closesFence(content, open) {
+				open = nil
+			}
+			continue
+		}
+
+		if strings.TrimSpace(content) == "" {
+			continue
+		}
+
+		// Outside of lists, indented lines are either code blocks or the
+		// continuation of a paragraph. Neither can hold a task list item.
+		indent := indentWidth(content)
+		if indent >= 4 && !inList {
+			continue
+		}
+
+		// Fences nested in list items are indented relative to the content
+		// of the item rather than to the margin.
+		column := 0
+		if inList && indent >= listCol {
+			column = listCol
+		}
+		if f := opensFence(content, column); f != nil {
+			f.depth = depth
+			f.column = column
+			open = f
+			if indent == 0 {
+				inList = false
+			}
+			continue
+		}
+
+		match := taskItem.FindStringSubmatchIndex(content)
+		if match != nil {
+			inList = true
+			listCol = contentColumn(content)
+			items = append(items, ChecklistItem{
+				Offset:  lineStart + contentStart + match[2],
+				Checked: content[match[2]] != ' ',
+			})
+			continue
+		}
+
+		if isListItem(content) {
+			inList = true
+			listCol = contentColumn(content)
+		} else if indent == 0 {
+			inList = false
+		}
+	}
+
+	return items
+}
+
+// Progress returns the amount of checked items and the total amount of items
+// of a document's task list.
+func Progress(src string) (done uint32, total uint32) {
+	for _, item := range Checklist(src) {
+		total++
+		if item.Checked {
+			done++
+		}
+	}
+	return done, total
+}
+
+// ToggleItem flips the state of the nth (0-indexed) task list item of a
+// document. It returns the updated document and the new state of the item.
+func ToggleItem(src string, n int) (string, bool, error) {
+	items := Checklist(src)
+	if n < 0 || n >= len(items) {
+		return src, false, ErrItemOutOfRange
+	}
+	item := items[n]
+	state := byte('x')
+	if item.Checked {
+		state = ' '
+	}
+	return src[:item.Offset] + string(state) + src[item.Offset+1:], !item.Checked, nil
+}
+
+// stripQuotes removes the block quote markers of a line. It returns the quote
+// depth, the remaining content and the byte offset of that content.
+func stripQuotes(line string) (int, string, int) {
+	depth, start := 0, 0
+	for {
+		rest := line[start:]
+		trimmed := strings.TrimLeft(rest, " ")
+		if len(rest)-len(trimmed) > 3 || !strings.HasPrefix(trimmed, ">") {
+			return depth, rest, start
+		}
+		start += len(rest) - len(trimmed) + 1
+		if strings.HasPrefix(line[start:], " ") {
+			start++
+		}
+		depth++
+	}
+}
+
+// opensFence reports the fence a line opens. column is the content column of
+// the list item the line belongs to (0 outside of lists).
+func opensFence(content string, column int) *fence {
+	trimmed := strings.TrimLeft(content, " \t")
+	if indentWidth(content)-column > 3 || len(trimmed) < 3 {
+		return nil
+	}
+	char := trimmed[0]
+	if char != '`' && char != '~' {
+		return nil
+	}
+	size := len(trimmed) - len(strings.TrimLeft(trimmed, string(char)))
+	if size < 3 {
+		return nil
+	}
+	// Backtick fences can't contain backticks in their info string
+	if char == '`' && strings.Contains(trimmed[size:], "`") {
+		return nil
+	}
+	return &fence{char: char, size: size}
+}
+
+func closesFence(content string, f *fence) bool {
+	trimmed := strings.TrimLeft(content, " \t")
+	if indentWidth(content)-f.column > 3 {
+		return false
+	}
+	size := len(trimmed) - len(strings.TrimLeft(trimmed, string(f.char)))
+	return size >= f.size && strings.TrimSpace(trimmed[size:]) == ""
+}
+
+func isListItem(content string) bool {
+	trimmed := strings.TrimLeft(content, " \t")
+	if len(trimmed) == 0 {
+		return false
+	}
+	switch trimmed[0] {
+	case '-', '*', '+':
+		return len(trimmed) == 1 || trimmed[1] == ' ' || trimmed[1] == '\t'
+	}
+	digits := len(trimmed) - len(strings.TrimLeft(trimmed, "0123456789"))
+	if digits == 0 || digits > 9 || digits == len(trimmed) {
+		return false
+	}
+	if trimmed[digits] != '.' && trimmed[digits] != ')' {
+		return false
+	}
+	return digits+1 == len(trimmed) || trimmed[digits+1] == ' ' || trimmed[digits+1] == '\t'
+}
+
+// contentColumn returns the column the content of a list item starts at
+// (ex: 2 for "- item", 5 for "  1. item").
+func contentColumn(item string) int {
+	indent := indentWidth(item)
+	rest := strings.TrimLeft(item, " \t")
+	marker := len(rest) - len(strings.TrimLeft(rest, "0123456789"))
+	if marker == 0 {
+		marker = 1 // Bullet
+	} else {
+		marker++ // Delimiter of ordered items
+	}
+	spaces := indentWidth(rest[marker:])
+	// Items whose content starts with an indented code block (or without any
+	// content) only count a single space
+	if spaces == 0 || spaces > 4 || strings.TrimSpace(rest[marker:]) == "" {
+		spaces = 1
+	}
+	return indent + marker + spaces
+}
+
+func indentWidth(content string) int {
+	width := 0
+	for _, r := range content {
+		switch r {
+		case ' ':
+			width++
+		case '\t':
+			width += 4 - width%4
+		default:
+			return width
+		}
+	}
+	return width
+}
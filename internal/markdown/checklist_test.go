@@ -0,0 +1,178 @@
+package markdown
+
+import (
+	"errors"
+	"testing"
+)
+
+var checklistTests = []struct {
+	name        string
+	src         string
+	done, total uint32
+}{
+	{
+		name:  "bullets",
+		src:   "- [ ] one\n* [x] two\n+ [X] three\n",
+		done:  2,
+		total: 3,
+	},
+	{
+		name:  "ordered items",
+		src:   "1. [ ] one\n2) [x] two\n",
+		done:  1,
+		total: 2,
+	},
+	{
+		name:  "not task items",
+		src:   "[ ] no marker\n-[ ] no space\n- [ ]no space after\n- [-] unknown state\n- [] empty\n",
+		done:  0,
+		total: 0,
+	},
+	{
+		name:  "empty item",
+		src:   "- [ ]\n- [x]",
+		done:  1,
+		total: 2,
+	},
+	{
+		name:  "backtick fence",
+		src:   "- [ ] before\n```\n- [ ] code\n```\n- [x] after\n",
+		done:  1,
+		total: 2,
+	},
+	{
+		name:  "tilde fence",
+		src:   "~~~md\n- [ ] code\n```\n- [ ] still code\n~~~\n- [ ] after\n",
+		done:  0,
+		total: 1,
+	},
+	{
+		name:  "longer closing fence",
+		src:   "````\n```\n- [ ] code\n`````\n- [ ] after\n",
+		done:  0,
+		total: 1,
+	},
+	{
+		name:  "unclosed fence",
+		src:   "- [x] before\n```\n- [ ] code\n",
+		done:  1,
+		total: 1,
+	},
+	{
+		name:  "backticks in info string",
+		src:   "``` a`b\n- [ ] not a fence\n",
+		done:  0,
+		total: 1,
+	},
+	{
+		name:  "indented code",
+		src:   "text\n\n    - [ ] code\n\t- [ ] code\n- [ ] item\n",
+		done:  0,
+		total: 1,
+	},
+	{
+		name:  "block quotes",
+		src:   "> - [ ] quoted\n>> - [x] nested quote\n>- [ ] no space\n",
+		done:  1,
+		total: 3,
+	},
+	{
+		name:  "fence ends with its block quote",
+		src:   "> ```\n> - [ ] code\n- [ ] after\n",
+		done:  0,
+		total: 1,
+	},
+	{
+		name:  "CRLF line endings",
+		src:   "- [ ] one\r\n```\r\n- [ ] code\r\n```\r\n- [x]\r\n",
+		done:  1,
+		total: 2,
+	},
+	{
+		name:  "tabs",
+		src:   "-\t[ ] one\n- [x]\ttwo\n\t- [ ] nested\n",
+		done:  1,
+		total: 3,
+	},
+	{
+		name:  "nested lists",
+		src:   "- [ ] parent\n  - [x] child\n    - [ ] grandchild\n1. item\n   - [x] child\n",
+		done:  2,
+		total: 4,
+	},
+	{
+		name:  "fence in a list item",
+		src:   "- [ ] parent\n  ```\n  - [ ] code\n  ```\n  - [x] child\n",
+		done:  1,
+		total: 2,
+	},
+	{
+		name:  "fence ends with its list item",
+		src:   "- [ ] parent\n  ```\n  - [ ] code\n- [x] sibling\n",
+		done:  1,
+		total: 2,
+	},
+}
+
+func TestProgress(t *testing.T) {
+	for _, tt := range checklistTests {
+		t.Run(tt.name, func(t *testing.T) {
+			done, total := Progress(tt.src)
+			if done != tt.done || total != tt.total {
+				t.Errorf("Progress(%q) = %d/%d, want %d/%d", tt.src, done, total, tt.done, tt.total)
+			}
+		})
+	}
+}
+
+func TestToggleItem(t *testing.T) {
+	for _, tt := range checklistTests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := Checklist(tt.src)
+			for n, item := range items {
+				got, checked, err := ToggleItem(tt.src, n)
+				if err != nil {
+					t.Fatalf("ToggleItem(%q, %d) = %v", tt.src, n, err)
+				}
+				if checked == item.Checked {
+					t.Errorf("ToggleItem(%q, %d) left the item checked = %v", tt.src, n, checked)
+				}
+
+				// Only the checkbox of the toggled item changes
+				if len(got) != len(tt.src) || got[:item.Offset] != tt.src[:item.Offset] ||
+					got[item.Offset+1:] != tt.src[item.Offset+1:] {
+					t.Errorf("ToggleItem(%q, %d) = %q", tt.src, n, got)
+				}
+				after := Checklist(got)
+				if len(after) != len(items) || after[n].Checked != checked {
+					t.Errorf("ToggleItem(%q, %d) = %q, which has items %v", tt.src, n, got, after)
+				}
+
+				// Items checked with an upper case X come back with a lower case one
+				back, _, err := ToggleItem(got, n)
+				if err != nil || (back != tt.src && tt.src[item.Offset] != 'X') {
+					t.Errorf("toggling item %d twice gave %q (%v), want %q", n, back, err, tt.src)
+				}
+			}
+
+			for _, n := range []int{-1, len(items)} {
+				if _, _, err := ToggleItem(tt.src, n); !errors.Is(err, ErrItemOutOfRange) {
+					t.Errorf("ToggleItem(%q, %d) = %v, want ErrItemOutOfRange", tt.src, n, err)
+				}
+			}
+		})
+	}
+}
+
+func TestToggleItemStates(t *testing.T) {
+	src := "> - [ ] quoted\r\n- [X] upper case\r\n"
+
+	got, checked, err := ToggleItem(src, 0)
+	if err != nil || !checked || got != "> - [x] quoted\r\n- [X] upper case\r\n" {
+		t.Errorf("ToggleItem(%q, 0) = %q, %v, %v", src, got, checked, err)
+	}
+	got, checked, err = ToggleItem(src, 1)
+	if err != nil || checked || got != "> - [ ] quoted\r\n- [ ] upper case\r\n" {
+		t.Errorf("ToggleItem(%q, 1) = %q, %v, %v", src, got, checked, err)
+	}
+}
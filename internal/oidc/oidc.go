@@ -0,0 +1,315 @@
+// oidc lets users log in through an OpenID Connect provider (single sign-on).
+// Clients go through the authorization code flow (with PKCE) of the provider
+// themselves and hand rafta the ID token they get, which is verified against
+// the keys the provider publishes.
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/mail"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+	fetchTimeout  = 10 * time.Second
+	// Keys are fetched again when a token is signed with an unknown one (the
+	// provider rotated them) but not more often than this
+	minKeyRefresh = time.Minute
+	// Clock drift tolerated with the provider
+	leeway = 30 * time.Second
+)
+
+var (
+	ErrInvalidToken       = errors.New("invalid ID token")
+	ErrEmailNotVerified   = errors.New("the provider didn't verify the email")
+	ErrDomainNotAllowed   = errors.New("email domain isn't allowed")
+	errUnknownKey         = errors.New("unknown signing key")
+	errIssuerMismatch     = errors.New("discovered issuer doesn't match the configured one")
+	errUnsupportedKeyType = errors.New("unsupported key type")
+
+	signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+)
+
+// Config describes the provider and which of its users can log in.
+type Config struct {
+	Issuer         string
+	ClientID       string
+	AllowedDomains []string // Email domains allowed to log in (any when empty)
+	AutoProvision  bool     // Create accounts for unknown users
+}
+
+// Identity is who an ID token was issued to.
+type Identity struct {
+	Issuer  string
+	Subject string
+	Email   string
+	Name    string
+}
+
+type idClaims struct {
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	Nonce         string `json:"nonce"`
+	AuthorizedBy  string `json:"azp"`
+	jwt.RegisteredClaims
+}
+
+// Provider verifies the ID tokens of the provider. Its discovery document and
+// keys are fetched on first use.
+type Provider struct {
+	cfg    Config
+	client *http.Client
+
+	mu        sync.Mutex
+	jwksURI   string
+	keys      map[string]any // By key ID
+	fetchedOn time.Time
+}
+
+// New returns the provider of an issuer, which is kept as configured: ID
+// tokens must name it exactly.
+func New(cfg Config) *Provider {
+	domains := make([]string, 0, len(cfg.AllowedDomains))
+	for _, domain := range cfg.AllowedDomains {
+		if domain = strings.ToLower(strings.TrimSpace(domain)); domain != "" {
+			domains = append(domains, domain)
+		}
+	}
+	cfg.AllowedDomains = domains
+	return &Provider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: fetchTimeout},
+	}
+}
+
+func (p *Provider) AutoProvision() bool {
+	return p.cfg.AutoProvision
+}
+
+// Verify checks an ID token was issued by the provider for rafta and returns
+// the identity it asserts. The nonce the client sent to the provider is
+// checked when given.
+func (p *Provider) Verify(ctx context.Context, rawToken, nonce string) (Identity, error) {
+	claims := &idClaims{}
+	_, err := jwt.ParseWithClaims(rawToken, claims,
+		func(token *jwt.Token) (any, error) {
+			kid, _ := token.Header["kid"].(string)
+			return p.key(ctx, kid)
+		},
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithIssuer(p.cfg.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(leeway),
+	)
+	if errors.Is(err, jwt.ErrTokenUnverifiable) && !errors.Is(err, errUnknownKey) {
+		// The keys of the provider couldn't be fetched
+		return Identity{}, err
+	}
+	if err != nil {
+		return Identity{}, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	// Tokens meant for several clients name the one they were issued to
+	if len(claims.Audience) > 1 && claims.AuthorizedBy != p.cfg.ClientID {
+		return Identity{}, fmt.Errorf("%w: issued to '%s'", ErrInvalidToken, claims.AuthorizedBy)
+	}
+	if nonce != "" && claims.Nonce != nonce {
+		return Identity{}, fmt.Errorf("%w: nonce mismatch", ErrInvalidToken)
+	}
+	if claims.Subject == "" || claims.Email == "" {
+		return Identity{}, fmt.Errorf("%w: missing subject or email", ErrInvalidToken)
+	}
+	if !claims.EmailVerified {
+		return Identity{}, ErrEmailNotVerified
+	}
+
+	email, err := validateEmail(claims.Email)
+	if err != nil {
+		return Identity{}, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	if len(p.cfg.AllowedDomains) > 0 {
+		domain := email[strings.LastIndex(email, "@")+1:]
+		if !slices.Contains(p.cfg.AllowedDomains, domain) {
+			return Identity{}, fmt.Errorf("%w: '%s'", ErrDomainNotAllowed, domain)
+		}
+	}
+
+	return Identity{
+		Issuer:  p.cfg.Issuer,
+		Subject: claims.Subject,
+		Email:   email,
+		Name:    claims.Name,
+	}, nil
+}
+
+// validateEmail makes sure the email claim is a bare address (ex: no display
+// name) and returns it in lowercase.
+func validateEmail(email string) (string, error) {
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return "", fmt.Errorf("invalid email '%s'", email)
+	}
+	return strings.ToLower(email), nil
+}
+
+// key returns the public key of a key ID, fetching the keys of the provider
+// when it's unknown.
+func (p *Provider) key(ctx context.Context, kid string) (any, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+	if time.Since(p.fetchedOn) < minKeyRefresh {
+		return nil, errUnknownKey
+	}
+	if err := p.fetchKeys(ctx); err != nil {
+		return nil, err
+	}
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+	// Providers with a single key don't always name it
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, nil
+		}
+	}
+	return nil, errUnknownKey
+}
+
+func (p *Provider) fetchKeys(ctx context.Context) error {
+	p.fetchedOn = time.Now()
+
+	if p.jwksURI == "" {
+		var discovery struct {
+			Issuer  string `json:"issuer"`
+			JWKSURI string `json:"jwks_uri"`
+		}
+		discoveryURL := strings.TrimSuffix(p.cfg.Issuer, "/") + discoveryPath
+		if err := p.getJSON(ctx, discoveryURL, &discovery); err != nil {
+			return fmt.Errorf("failed to discover provider: %w", err)
+		}
+		if discovery.Issuer != p.cfg.Issuer {
+			return fmt.Errorf("%w: '%s'", errIssuerMismatch, discovery.Issuer)
+		}
+		p.jwksURI = discovery.JWKSURI
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := p.getJSON(ctx, p.jwksURI, &set); err != nil {
+		return fmt.Errorf("failed to fetch provider keys: %w", err)
+	}
+
+	keys := make(map[string]any, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			// Other keys can still be used
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	p.keys = keys
+	return nil
+}
+
+func (p *Provider) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s answered with status %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// jsonWebKey is a public key as published by providers (RFC 7517).
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("%w: curve '%s'", errUnsupportedKeyType, k.Crv)
+		}
+		x, err := decodeInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("%w: '%s'", errUnsupportedKeyType, k.Kty)
+	}
+}
+
+func decodeInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
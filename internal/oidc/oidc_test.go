@@ -0,0 +1,265 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testClientID = "rafta"
+
+// testIssuer is a provider publishing its discovery document and keys.
+type testIssuer struct {
+	srv    *httptest.Server
+	issuer string
+
+	mu   sync.Mutex
+	keys map[string]*rsa.PrivateKey // Published keys, by key ID
+}
+
+// newIssuer starts a provider whose issuer is its URL followed by suffix.
+func newIssuer(t *testing.T, suffix string) *testIssuer {
+	t.Helper()
+	ti := &testIssuer{keys: map[string]*rsa.PrivateKey{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+discoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{ //nolint:errcheck
+			"issuer":   ti.issuer,
+			"jwks_uri": ti.srv.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("GET /keys", func(w http.ResponseWriter, r *http.Request) {
+		ti.mu.Lock()
+		defer ti.mu.Unlock()
+		keys := []map[string]string{}
+		for kid, key := range ti.keys {
+			keys = append(keys, map[string]string{
+				"kty": "RSA",
+				"kid": kid,
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"keys": keys}) //nolint:errcheck
+	})
+	ti.srv = httptest.NewServer(mux)
+	t.Cleanup(ti.srv.Close)
+	ti.issuer = ti.srv.URL + suffix
+	ti.rotate(t, "key-1")
+	return ti
+}
+
+// rotate replaces the published keys with a new one.
+func (ti *testIssuer) rotate(t *testing.T, kid string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+	ti.keys = map[string]*rsa.PrivateKey{kid: key}
+}
+
+// token signs an ID token for jane with a published key after letting edit
+// change its claims.
+func (ti *testIssuer) token(t *testing.T, kid string, edit func(c *idClaims)) string {
+	t.Helper()
+	now := time.Now()
+	claims := &idClaims{
+		Email:         "Jane@Example.com",
+		EmailVerified: true,
+		Name:          "Jane",
+		Nonce:         "n-0S6_WzA2Mj",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    ti.issuer,
+			Subject:   "248289761001",
+			Audience:  jwt.ClaimStrings{testClientID},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+	}
+	if edit != nil {
+		edit(claims)
+	}
+	ti.mu.Lock()
+	key := ti.keys[kid]
+	ti.mu.Unlock()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed
+}
+
+func (ti *testIssuer) provider(domains ...string) *Provider {
+	return New(Config{Issuer: ti.issuer, ClientID: testClientID, AllowedDomains: domains})
+}
+
+func TestVerify(t *testing.T) {
+	ti := newIssuer(t, "")
+	p := ti.provider()
+
+	identity, err := p.Verify(context.Background(), ti.token(t, "key-1", nil), "n-0S6_WzA2Mj")
+	if err != nil {
+		t.Fatalf("Verify() = %v", err)
+	}
+	want := Identity{Issuer: ti.issuer, Subject: "248289761001", Email: "jane@example.com", Name: "Jane"}
+	if identity != want {
+		t.Errorf("Verify() = %+v, want %+v", identity, want)
+	}
+}
+
+func TestVerifyIssuerWithTrailingSlash(t *testing.T) {
+	ti := newIssuer(t, "/")
+	p := ti.provider()
+
+	identity, err := p.Verify(context.Background(), ti.token(t, "key-1", nil), "")
+	if err != nil {
+		t.Fatalf("Verify() = %v", err)
+	}
+	if identity.Issuer != ti.issuer {
+		t.Errorf("issuer = %q, want %q", identity.Issuer, ti.issuer)
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		edit    func(c *idClaims)
+		nonce   string
+		domains []string
+		want    error
+	}{
+		{
+			name: "wrong audience",
+			edit: func(c *idClaims) { c.Audience = jwt.ClaimStrings{"someone-else"} },
+			want: ErrInvalidToken,
+		},
+		{
+			name: "other authorized party",
+			edit: func(c *idClaims) {
+				c.Audience = jwt.ClaimStrings{testClientID, "someone-else"}
+				c.AuthorizedBy = "someone-else"
+			},
+			want: ErrInvalidToken,
+		},
+		{
+			name: "wrong issuer",
+			edit: func(c *idClaims) { c.Issuer += "/" },
+			want: ErrInvalidToken,
+		},
+		{
+			name: "expired",
+			edit: func(c *idClaims) { c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour)) },
+			want: ErrInvalidToken,
+		},
+		{
+			name:  "nonce mismatch",
+			nonce: "another-nonce",
+			want:  ErrInvalidToken,
+		},
+		{
+			name: "unverified email",
+			edit: func(c *idClaims) { c.EmailVerified = false },
+			want: ErrEmailNotVerified,
+		},
+		{
+			name: "invalid email",
+			edit: func(c *idClaims) { c.Email = "jane" },
+			want: ErrInvalidToken,
+		},
+		{
+			name: "email with a display name",
+			edit: func(c *idClaims) { c.Email = "Jane <jane@example.com>" },
+			want: ErrInvalidToken,
+		},
+		{
+			name:    "domain not allowed",
+			domains: []string{"example.org"},
+			want:    ErrDomainNotAllowed,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ti := newIssuer(t, "")
+			p := ti.provider(tt.domains...)
+
+			_, err := p.Verify(context.Background(), ti.token(t, "key-1", tt.edit), tt.nonce)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Verify() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyAllowedDomain(t *testing.T) {
+	ti := newIssuer(t, "")
+	p := ti.provider(" Example.com ", "example.org")
+
+	if _, err := p.Verify(context.Background(), ti.token(t, "key-1", nil), ""); err != nil {
+		t.Errorf("Verify() = %v", err)
+	}
+}
+
+func TestVerifyDiscoveredIssuerMismatch(t *testing.T) {
+	ti := newIssuer(t, "")
+	p := New(Config{Issuer: ti.issuer + "/", ClientID: testClientID})
+
+	_, err := p.Verify(context.Background(), ti.token(t, "key-1", nil), "")
+	if !errors.Is(err, errIssuerMismatch) {
+		t.Errorf("Verify() = %v, want errIssuerMismatch", err)
+	}
+}
+
+func TestVerifyKeyRotation(t *testing.T) {
+	ti := newIssuer(t, "")
+	p := ti.provider()
+	ctx := context.Background()
+
+	if _, err := p.Verify(ctx, ti.token(t, "key-1", nil), ""); err != nil {
+		t.Fatalf("Verify() = %v", err)
+	}
+
+	ti.rotate(t, "key-2")
+	// Keys were just fetched, unknown ones can't make the provider get hammered
+	rotated := ti.token(t, "key-2", nil)
+	if _, err := p.Verify(ctx, rotated, ""); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("Verify() = %v, want ErrInvalidToken before keys are refreshed", err)
+	}
+
+	p.mu.Lock()
+	p.fetchedOn = time.Now().Add(-minKeyRefresh)
+	p.mu.Unlock()
+	if _, err := p.Verify(ctx, rotated, ""); err != nil {
+		t.Fatalf("Verify() = %v after the keys got refreshed", err)
+	}
+	if _, err := p.Verify(ctx, ti.token(t, "key-2", nil), ""); err != nil {
+		t.Errorf("Verify() = %v with a known key", err)
+	}
+}
+
+func TestVerifyUnreachableProvider(t *testing.T) {
+	ti := newIssuer(t, "")
+	p := ti.provider()
+	token := ti.token(t, "key-1", nil)
+	ti.srv.Close()
+
+	_, err := p.Verify(context.Background(), token, "")
+	if err == nil || errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Verify() = %v, want an error other than ErrInvalidToken", err)
+	}
+}
@@ -42,22 +42,9 @@ func (s *adminServer) GetUserTasks(ctx context.Context, id *m.UUID) (*m.TaskList
 		)
 	}
 
-	tasksPb := make([]*m.Task, len(tasks))
-
-	for i, task := range tasks {
-		tags, err := s.db.GetTaskTags(ctx, task.TaskID)
-		if err != nil {
-			slog.ErrorContext(ctx,
-				"Failed to query tags for a specific task",
-				"task_id", task.TaskID,
-				logging.ErrKey, err,
-			)
-			return nil, status.Errorf(codes.Internal,
-				"Failed to query tags for task '%v'", task.TaskID,
-			)
-		}
-
-		tasksPb[i] = taskToPb(task, tags)
+	tasksPb, err := s.tasksToPb(ctx, userID, tasks)
+	if err != nil {
+		return nil, err
 	}
 
 	slog.InfoContext(ctx, "success", "user_id", creds.Subject)
@@ -19,6 +19,10 @@ func (s *adminServer) NewUser(ctx context.Context, req *m.UserSignupRequest) (*e
 		return nil, err
 	}
 
+	if err := s.auth.ValidatePasswd(req.UserSecret); err != nil {
+		return nil, err
+	}
+
 	_, err = s.newUser(ctx, req)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,46 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/util"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *adminServer) ResetTOTP(ctx context.Context, id *m.UUID) (*emptypb.Empty, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.hasAdminRights(ctx, creds); err != nil {
+		return nil, err
+	}
+
+	userID, err := util.ParseUUID(ctx, util.ParseUUIDParams{
+		Str: id.GetValue(), Subject: "user_id",
+		Critical: true, Implication: codes.InvalidArgument,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	had, err := s.disableTOTP(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !had {
+		slog.WarnContext(ctx, "user doesn't have two-factor authentication", "user_id", userID)
+		return nil, status.Errorf(codes.NotFound,
+			"user '%v' doesn't have two-factor authentication", userID,
+		)
+	}
+
+	slog.InfoContext(ctx, "success", "user_id", creds.Subject)
+	return &emptypb.Empty{}, nil
+}
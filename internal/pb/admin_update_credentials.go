@@ -7,6 +7,7 @@ import (
 	"github.com/ChausseBenjamin/rafta/internal/auth"
 	"github.com/ChausseBenjamin/rafta/internal/util"
 	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
@@ -29,7 +30,7 @@ func (s *adminServer) UpdateCredentials(ctx context.Context, req *m.ChangePasswd
 		return nil, err
 	}
 
-	if _, err := s.updateUserCredentials(ctx, userID, req.Secret); err != nil {
+	if _, err := s.updateUserCredentials(ctx, userID, req.Secret, uuid.Nil); err != nil {
 		return nil, err
 	}
 
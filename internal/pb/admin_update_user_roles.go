@@ -0,0 +1,100 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/util"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *adminServer) UpdateUserRoles(ctx context.Context, req *m.UpdateUserRolesRequest) (*emptypb.Empty, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.hasAdminRights(ctx, creds); err != nil {
+		return nil, err
+	}
+
+	userID, err := util.ParseUUID(ctx, util.ParseUUIDParams{
+		Str: req.GetUserId().GetValue(), Subject: "user_id",
+		Critical: true, Implication: codes.InvalidArgument,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := s.db.UserExists(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to ensure user exists", logging.ErrKey, err)
+		return nil, status.Error(codes.Internal, "failed to retrieve user")
+	}
+	if !exists {
+		slog.WarnContext(ctx, "user not found", "user_id", userID)
+		return nil, status.Errorf(codes.NotFound, "user '%v' not found", userID)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to begin transaction", logging.ErrKey, err)
+		return nil, status.Error(codes.Internal, "failed to start roles update")
+	}
+	defer tx.Rollback()
+	db := s.db.WithTx(tx)
+
+	if err := db.ClearUserRoles(ctx, userID); err != nil {
+		slog.ErrorContext(ctx, "failed to clear user roles", logging.ErrKey, err)
+		return nil, status.Error(codes.Internal, "failed to update roles")
+	}
+	for _, role := range req.GetRoles() {
+		role = strings.TrimSpace(role)
+		err := db.AppendUserRole(ctx, database.AppendUserRoleParams{
+			UserID: userID,
+			Role:   role,
+		})
+		if err != nil && strings.Contains(err.Error(), "FOREIGN KEY constraint") {
+			slog.WarnContext(ctx, "received unknown role", "role", role)
+			return nil, status.Errorf(codes.InvalidArgument, "unknown role '%v'", role)
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to add user role", logging.ErrKey, err)
+			return nil, status.Error(codes.Internal, "failed to update roles")
+		}
+	}
+
+	adminLeft, err := db.AdminExists(ctx, adminRoles)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to ensure an admin remains", logging.ErrKey, err)
+		return nil, status.Error(codes.Internal, "failed to update roles")
+	}
+	if !adminLeft {
+		slog.WarnContext(ctx, "refused to revoke the role of the last admin")
+		return nil, status.Error(codes.FailedPrecondition,
+			"the server must keep at least one admin",
+		)
+	}
+
+	// Access tokens carry the roles they were issued with, refreshing reads
+	// the new ones
+	if err := db.RevokeUserAccessTokens(ctx, userID); err != nil {
+		slog.ErrorContext(ctx, "failed to revoke access tokens", logging.ErrKey, err)
+		return nil, status.Error(codes.Internal, "failed to update roles")
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx, "failed to commit roles update", logging.ErrKey, err)
+		return nil, status.Error(codes.Internal, "failed to complete roles update")
+	}
+
+	slog.InfoContext(ctx, "success", "user_id", creds.Subject)
+	return &emptypb.Empty{}, nil
+}
@@ -7,7 +7,6 @@ import (
 	"log/slog"
 
 	"github.com/ChausseBenjamin/rafta/internal/auth"
-	"github.com/ChausseBenjamin/rafta/internal/logging"
 	m "github.com/ChausseBenjamin/rafta/pkg/model"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -20,6 +19,10 @@ func (s *authServer) Login(ctx context.Context, _ *emptypb.Empty) (*m.LoginRespo
 		return nil, err
 	}
 
+	if err := s.auth.CheckSecondFactor(ctx, creds.Subject); err != nil {
+		return nil, err
+	}
+
 	user, err := s.db.GetUser(ctx, creds.Subject)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -28,13 +31,9 @@ func (s *authServer) Login(ctx context.Context, _ *emptypb.Empty) (*m.LoginRespo
 		return nil, status.Error(codes.Internal, "Failed to retrieve user info")
 	}
 
-	access, refresh, err := s.auth.Issue(creds.Subject, creds.Roles)
+	access, refresh, err := s.auth.StartSession(ctx, creds.Subject)
 	if err != nil {
-		slog.ErrorContext(ctx,
-			"Failure during JWT pair generation",
-			logging.ErrKey, err,
-		)
-		return nil, status.Error(codes.Internal, "Failure during JWT generation")
+		return nil, err
 	}
 
 	slog.InfoContext(ctx, "success", "user_id", user.UserID)
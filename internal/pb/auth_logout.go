@@ -0,0 +1,28 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *authServer) Logout(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tokens of ended sessions stop working
+	if err := s.db.DeleteSession(ctx, creds.SessionID); err != nil {
+		slog.ErrorContext(ctx, "failed to end session", logging.ErrKey, err)
+		return nil, status.Error(codes.Internal, "failed to end session")
+	}
+
+	slog.InfoContext(ctx, "success", "user_id", creds.Subject)
+	return &emptypb.Empty{}, nil
+}
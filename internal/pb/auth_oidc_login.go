@@ -0,0 +1,62 @@
+package pb
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/oidc"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *authServer) OIDCLogin(ctx context.Context, req *m.OIDCLoginRequest) (*m.LoginResponse, error) {
+	if s.oidc == nil {
+		slog.WarnContext(ctx, "OIDC login attempted while single sign-on is disabled")
+		return nil, status.Error(codes.FailedPrecondition, "single sign-on isn't enabled on this server")
+	}
+
+	identity, err := s.oidc.Verify(ctx, req.GetIdToken(), req.GetNonce())
+	switch {
+	case errors.Is(err, oidc.ErrDomainNotAllowed):
+		slog.WarnContext(ctx, "OIDC login from a domain that isn't allowed", logging.ErrKey, err)
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, oidc.ErrInvalidToken), errors.Is(err, oidc.ErrEmailNotVerified):
+		slog.WarnContext(ctx, "received invalid ID token", logging.ErrKey, err)
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	case err != nil:
+		slog.ErrorContext(ctx, "failed to verify ID token", logging.ErrKey, err)
+		return nil, status.Error(codes.Unavailable, "failed to reach the identity provider")
+	}
+
+	userID, err := s.oidcUser(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.auth.CheckSecondFactor(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.db.GetUser(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to retrieve user", logging.ErrKey, err)
+		return nil, status.Error(codes.Internal, "Failed to retrieve user info")
+	}
+
+	access, refresh, err := s.auth.StartSession(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "success", "user_id", userID)
+	return &m.LoginResponse{
+		User: userToPb(user),
+		Tokens: &m.JWT{
+			Access:  access,
+			Refresh: refresh,
+		},
+	}, nil
+}
@@ -21,25 +21,12 @@ func (s *authServer) Signup(ctx context.Context, req *m.UserSignupRequest) (*m.L
 		return nil, status.Error(codes.FailedPrecondition, msgNoNewUser)
 	}
 
-	// 0 implies no user limit
-	if s.cfg.MaxUsers > 0 {
-		userCount, err := s.db.GetUserCount(ctx)
-		if err != nil {
-			slog.ErrorContext(ctx,
-				"Failed to determine the platforms user count to limit signups",
-				logging.ErrKey, err,
-			)
-			return nil, status.Error(codes.Internal,
-				"Failed to determine if the platform accepts new users",
-			)
-		}
+	if err := s.checkUserCapacity(ctx); err != nil {
+		return nil, err
+	}
 
-		if userCount >= int64(s.cfg.MaxUsers) {
-			slog.WarnContext(ctx,
-				"Blocked signup attempt as server has reached max-user capacity",
-			)
-			return nil, status.Error(codes.FailedPrecondition, msgNoNewUser)
-		}
+	if err := s.auth.ValidatePasswd(req.UserSecret); err != nil {
+		return nil, err
 	}
 
 	user, err := s.newUser(ctx, req)
@@ -59,14 +46,9 @@ func (s *authServer) Signup(ctx context.Context, req *m.UserSignupRequest) (*m.L
 		)
 	}
 
-	// Publicly signed up users don't have roles such as admin
-	acess, refresh, err := s.auth.Issue(userID, []string{})
+	acess, refresh, err := s.auth.StartSession(ctx, userID)
 	if err != nil {
-		slog.ErrorContext(ctx,
-			"Failed to generate JWT pair",
-			logging.ErrKey, err,
-		)
-		return nil, status.Error(codes.Internal, "Failure during token generation")
+		return nil, err
 	}
 
 	slog.InfoContext(ctx, "success", "user_id", user.Id)
@@ -0,0 +1,76 @@
+package pb
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/totp"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *raftaServer) ConfirmTOTP(ctx context.Context, req *m.TOTPCode) (*m.RecoveryCodes, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	enrolled, err := s.getUserTOTP(ctx, creds.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if enrolled.Confirmed {
+		slog.WarnContext(ctx, "two-factor authentication is already enabled")
+		return nil, status.Error(codes.FailedPrecondition,
+			"two-factor authentication is already enabled",
+		)
+	}
+
+	step, err := totp.Validate(enrolled.Secret, req.GetCode(), time.Now())
+	if errors.Is(err, totp.ErrInvalidCode) {
+		slog.WarnContext(ctx, "received invalid TOTP code")
+		return nil, status.Error(codes.InvalidArgument, "invalid two-factor authentication code")
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "stored TOTP secret is invalid", logging.ErrKey, err)
+		return nil, status.Error(codes.Internal, "failed to verify code")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to begin transaction", logging.ErrKey, err)
+		return nil, status.Error(codes.Internal, "failed to enable two-factor authentication")
+	}
+	defer tx.Rollback()
+	db := s.db.WithTx(tx)
+
+	// The code used to confirm can't be used to log in
+	err = db.ConfirmUserTOTP(ctx, database.ConfirmUserTOTPParams{
+		LastStep: step,
+		UserID:   creds.Subject,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to confirm two-factor authentication", logging.ErrKey, err)
+		return nil, status.Error(codes.Internal, "failed to enable two-factor authentication")
+	}
+	recoveryCodes, err := s.auth.NewRecoveryCodes(ctx, db, creds.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx, "failed to commit two-factor authentication",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to enable two-factor authentication")
+	}
+
+	slog.InfoContext(ctx, "success", "user_id", creds.Subject)
+	return &m.RecoveryCodes{Codes: recoveryCodes}, nil
+}
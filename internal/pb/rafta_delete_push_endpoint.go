@@ -0,0 +1,50 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/util"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *raftaServer) DeletePushEndpoint(ctx context.Context, id *m.UUID) (*emptypb.Empty, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	endpointID, err := util.ParseUUID(ctx, util.ParseUUIDParams{
+		Str: id.GetValue(), Subject: "endpoint_id",
+		Implication: codes.InvalidArgument, Critical: false,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rowCount, err := s.db.DeleteUserPushEndpoint(ctx, database.DeleteUserPushEndpointParams{
+		EndpointID: endpointID,
+		UserID:     creds.Subject,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to delete push endpoint",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to delete push endpoint")
+	}
+	if rowCount == 0 {
+		slog.WarnContext(ctx, "no push endpoint got deleted")
+		return nil, status.Errorf(codes.NotFound,
+			"couldn't find push endpoint '%v' to delete it", endpointID,
+		)
+	}
+
+	slog.InfoContext(ctx, "success")
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,35 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *raftaServer) DisableTOTP(ctx context.Context, req *m.TOTPCode) (*emptypb.Empty, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	enrolled, err := s.getUserTOTP(ctx, creds.Subject)
+	if err != nil {
+		return nil, err
+	}
+	// Abandoned enrollments didn't protect anything yet
+	if enrolled.Confirmed {
+		if err := s.auth.VerifySecondFactor(ctx, enrolled, req.GetCode()); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := s.disableTOTP(ctx, creds.Subject); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "success", "user_id", creds.Subject)
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,66 @@
+package pb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/totp"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *raftaServer) EnrollTOTP(ctx context.Context, _ *emptypb.Empty) (*m.TOTPEnrollment, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	enrolled, err := s.db.GetUserTOTP(ctx, creds.Subject)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		slog.ErrorContext(ctx, "failed to retrieve two-factor authentication",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to retrieve two-factor authentication")
+	}
+	if err == nil && enrolled.Confirmed {
+		// Replacing the secret would let a stolen access token bypass the
+		// second factor
+		slog.WarnContext(ctx, "two-factor authentication is already enabled")
+		return nil, status.Error(codes.FailedPrecondition,
+			"two-factor authentication is already enabled, disable it first",
+		)
+	}
+
+	user, err := s.db.GetUser(ctx, creds.Subject)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to retrieve user", logging.ErrKey, err)
+		return nil, status.Error(codes.Internal, "failed to retrieve user")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to generate TOTP secret", logging.ErrKey, err)
+		return nil, status.Error(codes.Internal, "failed to generate secret")
+	}
+	err = s.db.SetUserTOTP(ctx, database.SetUserTOTPParams{
+		UserID: creds.Subject,
+		Secret: secret,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to save TOTP secret", logging.ErrKey, err)
+		return nil, status.Error(codes.Internal, "failed to save secret")
+	}
+
+	slog.InfoContext(ctx, "success", "user_id", creds.Subject)
+	return &m.TOTPEnrollment{
+		Secret: secret,
+		Uri:    totp.URI(totpIssuer, user.Email, secret),
+	}, nil
+}
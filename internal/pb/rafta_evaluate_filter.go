@@ -0,0 +1,26 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+)
+
+func (s *raftaServer) EvaluateFilter(ctx context.Context, src *m.TaskSource) (*m.TaskList, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.sourceTasks(ctx, creds.Subject, src)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "success")
+	return &m.TaskList{
+		Tasks: tasks,
+	}, nil
+}
@@ -0,0 +1,39 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func (s *raftaServer) ExportTemplate(ctx context.Context, id *m.UUID) (*m.TemplateDocument, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := s.getUserTemplate(ctx, creds.Subject, id)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := protojson.MarshalOptions{Multiline: true}.Marshal(t.Data)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to export template",
+			"template_id", t.Id.Value,
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to export template")
+	}
+
+	slog.InfoContext(ctx, "success")
+	return &m.TemplateDocument{
+		Json: string(doc),
+	}, nil
+}
@@ -0,0 +1,72 @@
+package pb
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *raftaServer) GetAgenda(ctx context.Context, req *m.AgendaRequest) (*m.Agenda, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	days := int(req.GetDays())
+	if days == 0 {
+		days = agendaDefaultDays
+	}
+	if days > agendaMaxDays {
+		slog.WarnContext(ctx, "agenda range is too long", "days", days)
+		return nil, status.Errorf(codes.InvalidArgument,
+			"agendas cover at most %d days", agendaMaxDays,
+		)
+	}
+
+	loc, err := s.userLocation(ctx, s.db.Queries, creds.Subject, req.GetTimeZone())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	start := now.In(loc)
+	if req.GetStart() != "" {
+		if start, err = time.ParseInLocation(agendaDateLayout, req.GetStart(), loc); err != nil {
+			slog.WarnContext(ctx, "received invalid agenda start",
+				"start", req.GetStart(),
+				logging.ErrKey, err,
+			)
+			return nil, status.Errorf(codes.InvalidArgument,
+				"start must be a date formatted as YYYY-MM-DD (got '%s')", req.GetStart(),
+			)
+		}
+	}
+
+	tasks, err := s.db.GetVisibleUserTasks(ctx, database.GetVisibleUserTasksParams{
+		Owner: creds.Subject,
+		Now:   sql.NullTime{Time: now.UTC(), Valid: true},
+	})
+	if err != nil {
+		slog.ErrorContext(ctx,
+			"failed to retrieve tasks for given user",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to retrieve tasks")
+	}
+
+	tasksPb, err := s.tasksToPb(ctx, creds.Subject, tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "success")
+	return buildAgenda(tasksPb, start, days, now), nil
+}
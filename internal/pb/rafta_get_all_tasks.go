@@ -2,9 +2,12 @@ package pb
 
 import (
 	"context"
+	"database/sql"
 	"log/slog"
+	"time"
 
 	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
 	"github.com/ChausseBenjamin/rafta/internal/logging"
 	m "github.com/ChausseBenjamin/rafta/pkg/model"
 	"google.golang.org/grpc/codes"
@@ -18,7 +21,10 @@ func (s *raftaServer) GetAllTasks(ctx context.Context, _ *emptypb.Empty) (*m.Tas
 		return nil, err
 	}
 
-	tasks, err := s.db.GetUserTasks(ctx, creds.Subject)
+	tasks, err := s.db.GetVisibleUserTasks(ctx, database.GetVisibleUserTasksParams{
+		Owner: creds.Subject,
+		Now:   sql.NullTime{Time: time.Now().UTC(), Valid: true},
+	})
 	if err != nil {
 		slog.ErrorContext(ctx,
 			"failed to retrieve tasks for given user",
@@ -27,21 +33,9 @@ func (s *raftaServer) GetAllTasks(ctx context.Context, _ *emptypb.Empty) (*m.Tas
 		return nil, status.Error(codes.Internal, "failed to retrieve tasks")
 	}
 
-	tasksPb := make([]*m.Task, len(tasks))
-	for i, task := range tasks {
-		tags, err := s.db.GetTaskTags(ctx, task.TaskID)
-		if err != nil {
-			slog.ErrorContext(ctx,
-				"failed to retrieve tags associated with task",
-				"task_id", task.TaskID,
-				logging.ErrKey, err,
-			)
-			return nil, status.Errorf(codes.Internal,
-				"Failure while retrieving tags associated with '%v'", task.TaskID,
-			)
-		}
-
-		tasksPb[i] = taskToPb(task, tags)
+	tasksPb, err := s.tasksToPb(ctx, creds.Subject, tasks)
+	if err != nil {
+		return nil, err
 	}
 
 	slog.InfoContext(ctx, "success")
@@ -0,0 +1,46 @@
+package pb
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *raftaServer) GetAssignedTasks(ctx context.Context, _ *emptypb.Empty) (*m.TaskList, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.db.GetAssignedTasks(ctx, database.GetAssignedTasksParams{
+		Assignee: uuid.NullUUID{UUID: creds.Subject, Valid: true},
+		Now:      sql.NullTime{Time: time.Now().UTC(), Valid: true},
+	})
+	if err != nil {
+		slog.ErrorContext(ctx,
+			"failed to retrieve tasks assigned to user",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to retrieve assigned tasks")
+	}
+
+	tasksPb, err := s.tasksToPb(ctx, creds.Subject, tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "success")
+	return &m.TaskList{
+		Tasks: tasksPb,
+	}, nil
+}
@@ -0,0 +1,38 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *raftaServer) GetClientCertificates(ctx context.Context, _ *emptypb.Empty) (*m.ClientCertificateList, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.GetUserClientCertificates(ctx, creds.Subject)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to retrieve client certificates",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to retrieve client certificates")
+	}
+
+	certsPb := make([]*m.ClientCertificate, len(rows))
+	for i, row := range rows {
+		certsPb[i] = clientCertificateToPb(row)
+	}
+
+	slog.InfoContext(ctx, "success")
+	return &m.ClientCertificateList{
+		Certificates: certsPb,
+	}, nil
+}
@@ -0,0 +1,40 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *raftaServer) GetCustomFields(ctx context.Context, _ *emptypb.Empty) (*m.CustomFieldList, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := s.db.GetUserCustomFields(ctx, creds.Subject)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to retrieve custom fields",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to retrieve custom fields")
+	}
+
+	fieldsPb := make([]*m.CustomField, len(fields))
+	for i, field := range fields {
+		if fieldsPb[i], err = customFieldToPb(ctx, field); err != nil {
+			return nil, err
+		}
+	}
+
+	slog.InfoContext(ctx, "success")
+	return &m.CustomFieldList{
+		Fields: fieldsPb,
+	}, nil
+}
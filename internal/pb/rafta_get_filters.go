@@ -0,0 +1,40 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *raftaServer) GetFilters(ctx context.Context, _ *emptypb.Empty) (*m.SavedFilterList, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	filters, err := s.db.GetUserFilters(ctx, creds.Subject)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to retrieve filters",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to retrieve filters")
+	}
+
+	filtersPb := make([]*m.SavedFilter, len(filters))
+	for i, filter := range filters {
+		if filtersPb[i], err = savedFilterToPb(ctx, filter); err != nil {
+			return nil, err
+		}
+	}
+
+	slog.InfoContext(ctx, "success")
+	return &m.SavedFilterList{
+		Filters: filtersPb,
+	}, nil
+}
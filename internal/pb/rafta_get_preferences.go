@@ -0,0 +1,25 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *raftaServer) GetPreferences(ctx context.Context, _ *emptypb.Empty) (*m.Preferences, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	prefs, err := s.getPreferences(ctx, s.db.Queries, creds.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "success")
+	return withDefaults(prefs), nil
+}
@@ -0,0 +1,38 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *raftaServer) GetPushEndpoints(ctx context.Context, _ *emptypb.Empty) (*m.PushEndpointList, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints, err := s.db.GetUserPushEndpoints(ctx, creds.Subject)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to retrieve push endpoints",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to retrieve push endpoints")
+	}
+
+	endpointsPb := make([]*m.PushEndpoint, len(endpoints))
+	for i, endpoint := range endpoints {
+		endpointsPb[i] = pushEndpointToPb(endpoint)
+	}
+
+	slog.InfoContext(ctx, "success")
+	return &m.PushEndpointList{
+		Endpoints: endpointsPb,
+	}, nil
+}
@@ -0,0 +1,37 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *raftaServer) GetSettings(ctx context.Context, req *m.SettingsRequest) (*m.SettingList, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.GetUserSettings(ctx, database.GetUserSettingsParams{
+		UserID:    creds.Subject,
+		Namespace: req.GetNamespace(),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to retrieve settings", logging.ErrKey, err)
+		return nil, status.Error(codes.Internal, "failed to retrieve settings")
+	}
+
+	settings := make([]*m.Setting, len(rows))
+	for i, row := range rows {
+		settings[i] = settingToPb(row)
+	}
+
+	slog.InfoContext(ctx, "success")
+	return &m.SettingList{Settings: settings}, nil
+}
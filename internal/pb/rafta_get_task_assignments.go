@@ -0,0 +1,53 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *raftaServer) GetTaskAssignments(ctx context.Context, id *m.UUID) (*m.TaskAssignmentList, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.getAccessibleTask(ctx, s.db.Queries, id, creds.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.db.GetTaskAssignments(ctx, task.TaskID)
+	if err != nil {
+		slog.ErrorContext(ctx,
+			"failed to retrieve task assignments",
+			"task_id", task.TaskID,
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to retrieve task assignments")
+	}
+
+	assignmentsPb := make([]*m.TaskAssignment, len(assignments))
+	for i, a := range assignments {
+		assignmentsPb[i] = &m.TaskAssignment{
+			AssignedOn: timestamppb.New(a.AssignedOn.UTC()),
+		}
+		if a.Assignee.Valid {
+			assignmentsPb[i].Assignee = &m.UUID{Value: a.Assignee.UUID.String()}
+		}
+		if a.AssignedBy.Valid {
+			assignmentsPb[i].AssignedBy = &m.UUID{Value: a.AssignedBy.UUID.String()}
+		}
+	}
+
+	slog.InfoContext(ctx, "success")
+	return &m.TaskAssignmentList{
+		Assignments: assignmentsPb,
+	}, nil
+}
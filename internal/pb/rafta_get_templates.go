@@ -0,0 +1,40 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *raftaServer) GetTemplates(ctx context.Context, _ *emptypb.Empty) (*m.TemplateList, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := s.db.GetUserTemplates(ctx, creds.Subject)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to retrieve templates",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to retrieve templates")
+	}
+
+	templatesPb := make([]*m.Template, len(templates))
+	for i, t := range templates {
+		if templatesPb[i], err = templateToPb(ctx, t); err != nil {
+			return nil, err
+		}
+	}
+
+	slog.InfoContext(ctx, "success")
+	return &m.TemplateList{
+		Templates: templatesPb,
+	}, nil
+}
@@ -0,0 +1,65 @@
+package pb
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/util"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *raftaServer) GetTimeEntries(ctx context.Context, req *m.TimeEntryQuery) (*m.TimeEntryList, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	since, until := timeRangeBounds(req.GetRange())
+
+	var entries []database.TimeEntry
+	if req.GetTaskId().GetValue() != "" {
+		taskID, err := util.ParseUUID(ctx, util.ParseUUIDParams{
+			Str: req.TaskId.Value, Subject: "task_id",
+			Implication: codes.InvalidArgument, Critical: false,
+		})
+		if err != nil {
+			return nil, err
+		}
+		entries, err = s.db.GetUserTaskTimeEntries(ctx, database.GetUserTaskTimeEntriesParams{
+			UserID: creds.Subject,
+			TaskID: taskID,
+			Until:  until,
+			Since:  sql.NullTime{Time: since, Valid: true},
+		})
+	} else {
+		entries, err = s.db.GetUserTimeEntries(ctx, database.GetUserTimeEntriesParams{
+			UserID: creds.Subject,
+			Until:  until,
+			Since:  sql.NullTime{Time: since, Valid: true},
+		})
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to retrieve time entries",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to retrieve time entries")
+	}
+
+	now := time.Now().UTC()
+	entriesPb := make([]*m.TimeEntry, len(entries))
+	for i, entry := range entries {
+		entriesPb[i] = timeEntryToPb(entry, now)
+	}
+
+	slog.InfoContext(ctx, "success")
+	return &m.TimeEntryList{
+		Entries: entriesPb,
+	}, nil
+}
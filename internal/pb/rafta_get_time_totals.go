@@ -0,0 +1,103 @@
+package pb
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"database/sql"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func (s *raftaServer) GetTimeTotals(ctx context.Context, req *m.TimeRange) (*m.TimeTotals, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	since, until := timeRangeBounds(req)
+	entries, err := s.db.GetUserTimeEntries(ctx, database.GetUserTimeEntriesParams{
+		UserID: creds.Subject,
+		Until:  until,
+		Since:  sql.NullTime{Time: since, Valid: true},
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to retrieve time entries",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to retrieve time entries")
+	}
+
+	now := time.Now().UTC()
+	var total time.Duration
+	perTask := map[uuid.UUID]time.Duration{}
+	for _, entry := range entries {
+		d := overlap(entry, since, until, now)
+		total += d
+		perTask[entry.TaskID] += d
+	}
+
+	// Tags are fetched for a batch of tasks at once rather than task by task
+	ids := slices.SortedFunc(maps.Keys(perTask), func(a, b uuid.UUID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	perTag := map[string]time.Duration{}
+	for batch := range slices.Chunk(ids, taskBatchSize) {
+		rows, err := s.db.GetTasksTags(ctx, batch)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to retrieve tags associated with tasks", logging.ErrKey, err)
+			return nil, status.Error(codes.Internal, "Failure while retrieving tags associated with tasks")
+		}
+		for _, row := range rows {
+			perTag[row.Tag.Name] += perTask[row.TaskID]
+		}
+	}
+
+	tasks := make([]*m.TaskTimeTotal, len(ids))
+	for i, taskID := range ids {
+		tasks[i] = &m.TaskTimeTotal{
+			TaskId:   &m.UUID{Value: taskID.String()},
+			Duration: durationpb.New(perTask[taskID]),
+		}
+	}
+
+	tags := make([]*m.TagTimeTotal, 0, len(perTag))
+	for name, d := range perTag {
+		tags = append(tags, &m.TagTimeTotal{
+			Tag:      name,
+			Duration: durationpb.New(d),
+		})
+	}
+
+	// Most time consuming first
+	slices.SortFunc(tasks, func(a, b *m.TaskTimeTotal) int {
+		return cmp.Or(
+			cmp.Compare(b.Duration.AsDuration(), a.Duration.AsDuration()),
+			cmp.Compare(a.TaskId.Value, b.TaskId.Value),
+		)
+	})
+	slices.SortFunc(tags, func(a, b *m.TagTimeTotal) int {
+		return cmp.Or(
+			cmp.Compare(b.Duration.AsDuration(), a.Duration.AsDuration()),
+			cmp.Compare(a.Tag, b.Tag),
+		)
+	})
+
+	slog.InfoContext(ctx, "success")
+	return &m.TimeTotals{
+		Total: durationpb.New(total),
+		Tasks: tasks,
+		Tags:  tags,
+	}, nil
+}
@@ -0,0 +1,55 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func (s *raftaServer) ImportTemplate(ctx context.Context, doc *m.TemplateDocument) (*m.Template, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unknown fields are ignored so that documents exported by newer servers
+	// can still be imported.
+	data := &m.TemplateData{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal([]byte(doc.GetJson()), data); err != nil {
+		slog.WarnContext(ctx, "received invalid template document",
+			logging.ErrKey, err,
+		)
+		return nil, status.Errorf(codes.InvalidArgument,
+			"invalid template document: %v", err,
+		)
+	}
+
+	name, definition, err := encodeTemplate(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := s.db.NewTemplate(ctx, database.NewTemplateParams{
+		Owner:      creds.Subject,
+		Name:       name,
+		Definition: definition,
+	})
+	if err != nil {
+		return nil, nameConflict(ctx, err, "template", name)
+	}
+
+	templatePb, err := templateToPb(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "success")
+	return templatePb, nil
+}
@@ -0,0 +1,90 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *raftaServer) InstantiateTemplate(ctx context.Context, req *m.InstantiateTemplateRequest) (*m.TaskList, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := s.getUserTemplate(ctx, creds.Subject, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := s.userLocation(ctx, s.db.Queries, creds.Subject, req.GetTimeZone())
+	if err != nil {
+		return nil, err
+	}
+	anchor := time.Now()
+	if req.GetAnchor() != nil {
+		anchor = req.GetAnchor().AsTime()
+	}
+	anchor = anchor.In(loc)
+
+	// Every task is resolved before touching the DB so that a missing
+	// variable doesn't waste a transaction.
+	tasksData := make([]*m.TaskData, len(t.Data.Tasks))
+	for i, task := range t.Data.Tasks {
+		if tasksData[i], err = templateTaskData(task, req.GetVariables(), anchor); err != nil {
+			slog.WarnContext(ctx, "failed to resolve template task",
+				"template_id", t.Id.Value,
+				"index", i,
+				logging.ErrKey, err,
+			)
+			return nil, status.Errorf(codes.InvalidArgument,
+				"task #%d of the template: %v", i, err,
+			)
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx,
+			"Failed to start template instantiation transaction",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "Failed to begin template instantiation")
+	}
+	defer tx.Rollback()
+	db := s.db.WithTx(tx)
+
+	tasksPb := make([]*m.Task, len(tasksData))
+	for i, data := range tasksData {
+		task, err := s.createTask(ctx, db, creds.Subject, data)
+		if err != nil {
+			return nil, err
+		}
+		if tasksPb[i], err = s.loadTask(ctx, db, creds.Subject, task); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx,
+			"failed to commit transaction",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal,
+			"failed to properly complete template instantiation",
+		)
+	}
+
+	go s.cleanTags(ctx)
+
+	slog.InfoContext(ctx, "success")
+	return &m.TaskList{
+		Tasks: tasksPb,
+	}, nil
+}
@@ -0,0 +1,43 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *raftaServer) ListSessions(ctx context.Context, _ *emptypb.Empty) (*m.SessionList, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.db.GetUserSessions(ctx, database.GetUserSessionsParams{
+		UserID: creds.Subject,
+		Now:    time.Now().UTC(),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to retrieve sessions",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to retrieve sessions")
+	}
+
+	sessionsPb := make([]*m.Session, len(sessions))
+	for i, session := range sessions {
+		sessionsPb[i] = sessionToPb(session, creds.SessionID)
+	}
+
+	slog.InfoContext(ctx, "success")
+	return &m.SessionList{
+		Sessions: sessionsPb,
+	}, nil
+}
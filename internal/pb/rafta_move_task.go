@@ -0,0 +1,131 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/util"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *raftaServer) MoveTask(ctx context.Context, req *m.MoveTaskRequest) (*emptypb.Empty, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	taskID, err := util.ParseUUID(ctx, util.ParseUUIDParams{
+		Str: req.GetId().GetValue(), Subject: "task_id",
+		Implication: codes.InvalidArgument, Critical: false,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var sibling *m.UUID
+	switch sib := req.GetSibling().(type) {
+	case *m.MoveTaskRequest_Before:
+		sibling = sib.Before
+	case *m.MoveTaskRequest_After:
+		sibling = sib.After
+	}
+	var siblingID uuid.UUID
+	if sibling != nil {
+		siblingID, err = util.ParseUUID(ctx, util.ParseUUIDParams{
+			Str: sibling.GetValue(), Subject: "sibling_id",
+			Implication: codes.InvalidArgument, Critical: false,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if siblingID == taskID {
+			slog.WarnContext(ctx, "attempt to move a task relative to itself")
+			return nil, status.Error(codes.InvalidArgument,
+				"a task can't be moved relative to itself",
+			)
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx,
+			"Failed to start task move transaction",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "Failed to begin task move")
+	}
+	defer tx.Rollback()
+	db := s.db.WithTx(tx)
+
+	task, err := s.getAccessibleTask(ctx, db, req.GetId(), creds.Subject)
+	if err != nil {
+		return nil, err
+	}
+	// Assignees only get to move the task next to the ones they can see
+	if sibling != nil && task.Owner != creds.Subject {
+		if _, err := s.getAccessibleTask(ctx, db, sibling, creds.Subject); err != nil {
+			return nil, err
+		}
+	}
+
+	// Views are the owner's, which assignees share
+	tasks, err := s.scopeTasks(ctx, db, task.Owner, req.GetTag())
+	if err != nil {
+		return nil, err
+	}
+	inView := func(id uuid.UUID) func(database.GetScopeTasksRow) bool {
+		return func(t database.GetScopeTasksRow) bool { return t.TaskID == id }
+	}
+	i := slices.IndexFunc(tasks, inView(taskID))
+	if i < 0 {
+		slog.WarnContext(ctx, "task is not part of the view",
+			"task_id", taskID,
+			"tag", req.GetTag(),
+		)
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"task '%v' isn't tagged '%s'", taskID, req.GetTag(),
+		)
+	}
+	tasks = slices.Delete(tasks, i, i+1)
+
+	index := len(tasks)
+	if sibling != nil {
+		if index = slices.IndexFunc(tasks, inView(siblingID)); index < 0 {
+			slog.WarnContext(ctx, "sibling is not part of the view",
+				"sibling_id", siblingID,
+				"tag", req.GetTag(),
+			)
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"task '%v' isn't part of the same list", siblingID,
+			)
+		}
+		if _, after := req.GetSibling().(*m.MoveTaskRequest_After); after {
+			index++
+		}
+	}
+
+	if err := s.placeTask(ctx, db, req.GetTag(), tasks, taskID, index); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx,
+			"failed to commit transaction",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal,
+			"failed to properly complete task move",
+		)
+	}
+
+	slog.InfoContext(ctx, "success")
+	return &emptypb.Empty{}, nil
+}
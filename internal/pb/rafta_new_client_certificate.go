@@ -0,0 +1,68 @@
+package pb
+
+import (
+	"context"
+	"crypto/x509"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/certs"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *raftaServer) NewClientCertificate(ctx context.Context, data *m.ClientCertificateData) (*m.ClientCertificate, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	name, cert, err := validateClientCertificate(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Anyone can get a hold of a certificate, only the client having its key
+	// may register it
+	fingerprint := certs.Fingerprint(cert)
+	chain := auth.PeerCertificates(ctx)
+	if len(chain) == 0 || certs.Fingerprint(chain[0]) != fingerprint {
+		slog.WarnContext(ctx, "client certificate registered over another connection", "fingerprint", fingerprint)
+		return nil, status.Error(codes.FailedPrecondition, "client certificates must be registered over a connection presenting them")
+	}
+	// Those already identify users through their email
+	if s.cfg.ClientCAs != nil && certs.VerifyClient(chain, s.cfg.ClientCAs, x509.ExtKeyUsageAny) == nil {
+		slog.WarnContext(ctx, "client certificate issued by a client CA", "fingerprint", fingerprint)
+		return nil, status.Error(codes.InvalidArgument, "client certificates issued by a client CA can't be registered")
+	}
+
+	// A certificate identifies a single user
+	_, err = s.db.GetClientCertificate(ctx, fingerprint)
+	switch {
+	case err == nil:
+		slog.WarnContext(ctx, "client certificate already registered", "fingerprint", fingerprint)
+		return nil, status.Error(codes.AlreadyExists, "client certificate already registered")
+	case !errors.Is(err, sql.ErrNoRows):
+		slog.ErrorContext(ctx, "failed to retrieve client certificate", logging.ErrKey, err)
+		return nil, status.Error(codes.Internal, "failed to save client certificate")
+	}
+
+	row, err := s.db.NewClientCertificate(ctx, database.NewClientCertificateParams{
+		UserID:      creds.Subject,
+		Name:        name,
+		Fingerprint: fingerprint,
+		Subject:     cert.Subject.String(),
+		NotAfter:    cert.NotAfter.UTC(),
+	})
+	if err != nil {
+		return nil, nameConflict(ctx, err, "client certificate", name)
+	}
+
+	slog.InfoContext(ctx, "success")
+	return clientCertificateToPb(row), nil
+}
@@ -0,0 +1,40 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+)
+
+func (s *raftaServer) NewCustomField(ctx context.Context, data *m.CustomFieldDefinition) (*m.CustomField, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	name, options, err := encodeCustomField(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	field, err := s.db.NewCustomField(ctx, database.NewCustomFieldParams{
+		Owner:   creds.Subject,
+		Name:    name,
+		Kind:    int64(data.GetKind()),
+		Options: options,
+	})
+	if err != nil {
+		return nil, nameConflict(ctx, err, "custom field", name)
+	}
+
+	fieldPb, err := customFieldToPb(ctx, field)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "success")
+	return fieldPb, nil
+}
@@ -0,0 +1,39 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+)
+
+func (s *raftaServer) NewFilter(ctx context.Context, data *m.SavedFilterData) (*m.SavedFilter, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	name, definition, err := encodeSavedFilter(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := s.db.NewFilter(ctx, database.NewFilterParams{
+		Owner:      creds.Subject,
+		Name:       name,
+		Definition: definition,
+	})
+	if err != nil {
+		return nil, nameConflict(ctx, err, "filter", name)
+	}
+
+	filterPb, err := savedFilterToPb(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "success")
+	return filterPb, nil
+}
@@ -0,0 +1,43 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *raftaServer) NewPersonalToken(ctx context.Context, data *m.PersonalTokenData) (*m.NewPersonalTokenResponse, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := validatePersonalToken(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, hash, err := auth.NewPersonalToken()
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to generate personal access token", logging.ErrKey, err)
+		return nil, status.Error(codes.Internal, "failed to generate personal access token")
+	}
+	params.UserID = creds.Subject
+	params.Hash = hash
+
+	token, err := s.db.NewPersonalToken(ctx, params)
+	if err != nil {
+		return nil, nameConflict(ctx, err, "personal access token", params.Name)
+	}
+
+	slog.InfoContext(ctx, "success")
+	return &m.NewPersonalTokenResponse{
+		Token:  personalTokenToPb(token),
+		Secret: secret,
+	}, nil
+}
@@ -0,0 +1,41 @@
+package pb
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+)
+
+func (s *raftaServer) NewPushEndpoint(ctx context.Context, data *m.PushEndpointData) (*m.PushEndpoint, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := validatePushEndpoint(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := s.db.NewPushEndpoint(ctx, database.NewPushEndpointParams{
+		UserID: creds.Subject,
+		Name:   name,
+		Kind:   int64(data.GetKind()),
+		Url:    data.GetUrl(),
+		Token: sql.NullString{
+			String: data.GetToken(),
+			Valid:  data.GetToken() != "",
+		},
+		Events: pushEventMask(data.GetEvents()),
+	})
+	if err != nil {
+		return nil, nameConflict(ctx, err, "push endpoint", name)
+	}
+
+	slog.InfoContext(ctx, "success")
+	return pushEndpointToPb(endpoint), nil
+}
@@ -2,11 +2,9 @@ package pb
 
 import (
 	"context"
-	"database/sql"
 	"log/slog"
 
 	"github.com/ChausseBenjamin/rafta/internal/auth"
-	"github.com/ChausseBenjamin/rafta/internal/database"
 	"github.com/ChausseBenjamin/rafta/internal/logging"
 	m "github.com/ChausseBenjamin/rafta/pkg/model"
 	"google.golang.org/grpc/codes"
@@ -34,38 +32,9 @@ func (s *raftaServer) NewTask(ctx context.Context, t *m.TaskData) (*m.NewTaskRes
 	defer tx.Rollback()
 	db := s.db.WithTx(tx)
 
-	task, err := db.NewTask(ctx, database.NewTaskParams{
-		Title:    t.Title,
-		State:    uint8(t.State),
-		Priority: t.Priority,
-		Description: sql.NullString{
-			String: t.Desc,
-			Valid:  (t.Desc != ""),
-		},
-		DueDate: t.DueDate.AsTime().UTC(),
-		DoDate:  t.DoDate.AsTime().UTC(),
-		RecurrencePattern: sql.NullString{
-			String: t.Recurrence.Pattern,
-			Valid:  (t.Recurrence.Pattern != ""),
-		},
-		RecurrenceEnabled: t.Recurrence.Active,
-		Owner:             creds.Subject,
-	})
+	task, err := s.createTask(ctx, db, creds.Subject, t)
 	if err != nil {
-		slog.ErrorContext(ctx,
-			"failed to insert task into database",
-			logging.ErrKey, err,
-		)
-		return nil, status.Error(codes.Internal,
-			"Failed to insert task",
-		)
-	}
-
-	if len(t.Tags) > 0 {
-		err = s.syncTags(ctx, task.TaskID, t.Tags, db)
-		if err != nil {
-			return nil, err
-		}
+		return nil, err
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -0,0 +1,39 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+)
+
+func (s *raftaServer) NewTemplate(ctx context.Context, data *m.TemplateData) (*m.Template, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	name, definition, err := encodeTemplate(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := s.db.NewTemplate(ctx, database.NewTemplateParams{
+		Owner:      creds.Subject,
+		Name:       name,
+		Definition: definition,
+	})
+	if err != nil {
+		return nil, nameConflict(ctx, err, "template", name)
+	}
+
+	templatePb, err := templateToPb(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "success")
+	return templatePb, nil
+}
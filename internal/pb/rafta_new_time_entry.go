@@ -0,0 +1,54 @@
+package pb
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *raftaServer) NewTimeEntry(ctx context.Context, data *m.TimeEntryData) (*m.TimeEntry, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	started, ended, err := timeEntryBounds(ctx, data, false, now)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.getAccessibleTask(ctx, s.db.Queries, data.TaskId, creds.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := s.db.NewTimeEntry(ctx, database.NewTimeEntryParams{
+		TaskID:    task.TaskID,
+		UserID:    creds.Subject,
+		StartedOn: started,
+		EndedOn:   ended,
+		Note: sql.NullString{
+			String: data.Note,
+			Valid:  (data.Note != ""),
+		},
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to insert time entry",
+			"task_id", task.TaskID,
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to record time entry")
+	}
+
+	slog.InfoContext(ctx, "success")
+	return timeEntryToPb(entry, now), nil
+}
@@ -0,0 +1,24 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+)
+
+func (s *raftaServer) ParseQuickAdd(ctx context.Context, req *m.QuickAddRequest) (*m.QuickAddResponse, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.parseQuickAdd(ctx, creds.Subject, req)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "success")
+	return resp, nil
+}
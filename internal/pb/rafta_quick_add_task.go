@@ -0,0 +1,70 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *raftaServer) QuickAddTask(ctx context.Context, req *m.QuickAddRequest) (*m.QuickAddResponse, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.parseQuickAdd(ctx, creds.Subject, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Parsed.Title == "" {
+		slog.WarnContext(ctx, "quick add text has no title")
+		return nil, status.Error(codes.InvalidArgument,
+			"quick add text must contain a title",
+		)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx,
+			"Failed to start task creation transaction",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(
+			codes.Internal,
+			"Failed to begin task creation",
+		)
+	}
+	defer tx.Rollback()
+	db := s.db.WithTx(tx)
+
+	task, err := s.createTask(ctx, db, creds.Subject, resp.Parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	taskPb, err := s.loadTask(ctx, db, creds.Subject, task)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx,
+			"failed to commit transaction",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal,
+			"failed to properly complete task creation",
+		)
+	}
+
+	go s.cleanTags(ctx)
+
+	slog.InfoContext(ctx, "success")
+	resp.Task = taskPb
+	return resp, nil
+}
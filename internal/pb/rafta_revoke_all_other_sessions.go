@@ -0,0 +1,34 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *raftaServer) RevokeAllOtherSessions(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.db.DeleteOtherUserSessions(ctx, database.DeleteOtherUserSessionsParams{
+		UserID:  creds.Subject,
+		Current: creds.SessionID,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to revoke other sessions",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to revoke other sessions")
+	}
+
+	slog.InfoContext(ctx, "success", "revoked", count)
+	return &emptypb.Empty{}, nil
+}
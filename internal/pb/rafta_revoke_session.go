@@ -0,0 +1,50 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/util"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *raftaServer) RevokeSession(ctx context.Context, id *m.UUID) (*emptypb.Empty, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionID, err := util.ParseUUID(ctx, util.ParseUUIDParams{
+		Str: id.GetValue(), Subject: "session_id",
+		Implication: codes.InvalidArgument, Critical: false,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rowCount, err := s.db.DeleteUserSession(ctx, database.DeleteUserSessionParams{
+		SessionID: sessionID,
+		UserID:    creds.Subject,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to revoke session",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to revoke session")
+	}
+	if rowCount == 0 {
+		slog.WarnContext(ctx, "no session got revoked")
+		return nil, status.Errorf(codes.NotFound,
+			"couldn't find session '%v' to revoke it", sessionID,
+		)
+	}
+
+	slog.InfoContext(ctx, "success")
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,43 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *raftaServer) SetSettings(ctx context.Context, req *m.SettingList) (*m.SettingList, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx,
+			"Failed to start settings update transaction",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to update settings")
+	}
+	defer tx.Rollback()
+
+	settings, err := setSettings(ctx, s.db.WithTx(tx), creds.Subject, req.GetSettings())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx, "failed to commit transaction", logging.ErrKey, err)
+		return nil, status.Error(codes.Internal, "failed to properly complete settings update")
+	}
+	s.settings.publish(creds.Subject, settings...)
+
+	slog.InfoContext(ctx, "success", "count", len(settings))
+	return &m.SettingList{Settings: settings}, nil
+}
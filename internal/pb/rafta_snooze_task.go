@@ -0,0 +1,78 @@
+package pb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/util"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *raftaServer) SnoozeTask(ctx context.Context, req *m.SnoozeRequest) (*m.SnoozeResponse, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	taskID, err := util.ParseUUID(ctx, util.ParseUUIDParams{
+		Str: req.GetId().GetValue(), Subject: "task_id",
+		Implication: codes.InvalidArgument, Critical: false,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	var hiddenUntil time.Time
+	switch until := req.GetUntil().(type) {
+	case *m.SnoozeRequest_Duration:
+		hiddenUntil = now.Add(until.Duration.AsDuration())
+	case *m.SnoozeRequest_Date:
+		hiddenUntil = until.Date.AsTime().UTC()
+	default:
+		slog.WarnContext(ctx, "snooze request is missing a duration or date")
+		return nil, status.Error(codes.InvalidArgument,
+			"snoozing requires either a duration or a date",
+		)
+	}
+	if !hiddenUntil.After(now) {
+		slog.WarnContext(ctx, "attempt to snooze a task into the past",
+			"hidden_until", hiddenUntil,
+		)
+		return nil, status.Error(codes.InvalidArgument,
+			"tasks can only be snoozed until a future date",
+		)
+	}
+
+	updatedOn, err := s.db.SnoozeUserTask(ctx, database.SnoozeUserTaskParams{
+		HiddenUntil: sql.NullTime{Time: hiddenUntil, Valid: true},
+		TaskID:      taskID,
+		Owner:       creds.Subject,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.WarnContext(ctx, "task not found", "task_id", taskID)
+			return nil, status.Errorf(codes.NotFound, "task '%v' not found", taskID)
+		}
+		slog.ErrorContext(ctx, "failed to snooze task",
+			"task_id", taskID,
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to snooze task")
+	}
+
+	slog.InfoContext(ctx, "success")
+	return &m.SnoozeResponse{
+		HiddenUntil: timestamppb.New(hiddenUntil),
+		UpdatedOn:   timestamppb.New(updatedOn.UTC()),
+	}, nil
+}
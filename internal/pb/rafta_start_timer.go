@@ -0,0 +1,101 @@
+package pb
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func (s *raftaServer) StartTimer(ctx context.Context, req *m.StartTimerRequest) (*m.StartTimerResponse, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx,
+			"Failed to start timer transaction",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "Failed to begin starting timer")
+	}
+	defer tx.Rollback()
+	db := s.db.WithTx(tx)
+
+	task, err := s.getAccessibleTask(ctx, db, req.TaskId, creds.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stopping the running timer is the first write of the transaction on
+	// purpose: it locks the database so concurrent requests can't both end up
+	// starting a timer.
+	now := time.Now().UTC()
+	stopped, err := db.StopUserTimers(ctx, database.StopUserTimersParams{
+		EndedOn: sql.NullTime{Time: now, Valid: true},
+		UserID:  creds.Subject,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to stop running timer",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to stop running timer")
+	}
+
+	entry, err := db.NewTimeEntry(ctx, database.NewTimeEntryParams{
+		TaskID:    task.TaskID,
+		UserID:    creds.Subject,
+		StartedOn: now,
+		Note: sql.NullString{
+			String: req.Note,
+			Valid:  (req.Note != ""),
+		},
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to insert time entry",
+			"task_id", task.TaskID,
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to start timer")
+	}
+
+	if req.StartTask && m.TaskState(task.State) == m.TaskState_PENDING {
+		// Access to the task was confirmed above, the update is therefore
+		// performed on behalf of its owner.
+		if _, err := s.updateTask(ctx, tx, task.Owner, &m.TaskUpdateRequest{
+			Id:         req.TaskId,
+			Data:       &m.TaskData{State: m.TaskState_ONGOING},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"state"}},
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx,
+			"failed to commit transaction",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to properly start timer")
+	}
+
+	resp := &m.StartTimerResponse{
+		Entry: timeEntryToPb(entry, now),
+	}
+	if len(stopped) > 0 {
+		resp.Stopped = timeEntryToPb(stopped[0], now)
+	}
+
+	slog.InfoContext(ctx, "success")
+	return resp, nil
+}
@@ -0,0 +1,42 @@
+package pb
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *raftaServer) StopTimer(ctx context.Context, _ *emptypb.Empty) (*m.TimeEntry, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	stopped, err := s.db.StopUserTimers(ctx, database.StopUserTimersParams{
+		EndedOn: sql.NullTime{Time: now, Valid: true},
+		UserID:  creds.Subject,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to stop running timer",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to stop running timer")
+	}
+	if len(stopped) == 0 {
+		slog.WarnContext(ctx, "no timer to stop")
+		return nil, status.Error(codes.NotFound, "no timer is currently running")
+	}
+
+	slog.InfoContext(ctx, "success")
+	return timeEntryToPb(stopped[0], now), nil
+}
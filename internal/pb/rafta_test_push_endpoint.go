@@ -0,0 +1,37 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *raftaServer) TestPushEndpoint(ctx context.Context, id *m.UUID) (*m.PushEndpoint, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := s.getUserPushEndpoint(ctx, s.db.Queries, creds.Subject, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.push.Test(ctx, pushTarget(endpoint), pushTestNotification); err != nil {
+		slog.WarnContext(ctx, "test push notification failed",
+			"endpoint_id", endpoint.EndpointID,
+			logging.ErrKey, err,
+		)
+		return nil, status.Errorf(codes.Unavailable,
+			"push endpoint rejected the test notification: %v", err,
+		)
+	}
+
+	slog.InfoContext(ctx, "success")
+	return pushEndpointToPb(endpoint), nil
+}
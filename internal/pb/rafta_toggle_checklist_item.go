@@ -0,0 +1,87 @@
+package pb
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/markdown"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *raftaServer) ToggleChecklistItem(ctx context.Context, req *m.ChecklistToggleRequest) (*m.ChecklistToggleResponse, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx,
+			"Checklist toggle transaction initialization failure",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to begin checklist update")
+	}
+	defer tx.Rollback()
+	db := s.db.WithTx(tx)
+
+	// Assignees get to check items off as they work on the task
+	task, err := s.getAccessibleTask(ctx, db, req.GetId(), creds.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	desc, checked, err := markdown.ToggleItem(task.Description.String, int(req.Index))
+	if err != nil {
+		slog.WarnContext(ctx, "checklist item out of range",
+			"task_id", task.TaskID,
+			"index", req.Index,
+			"total", task.ChecklistTotal,
+		)
+		return nil, status.Errorf(codes.OutOfRange,
+			"task '%v' has %d checklist items, can't toggle item %d",
+			task.TaskID, task.ChecklistTotal, req.Index,
+		)
+	}
+
+	done, total := markdown.Progress(desc)
+	updated, err := db.UpdateTaskDescription(ctx, database.UpdateTaskDescriptionParams{
+		Description: sql.NullString{
+			String: desc,
+			Valid:  (desc != ""),
+		},
+		ChecklistDone:  int64(done),
+		ChecklistTotal: int64(total),
+		TaskID:         task.TaskID,
+		Owner:          task.Owner,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to update task description",
+			"task_id", task.TaskID,
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to update checklist")
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx,
+			"failure to commit checklist toggle transaction",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to complete checklist update")
+	}
+
+	slog.InfoContext(ctx, "success")
+	return &m.ChecklistToggleResponse{
+		Checked:   checked,
+		Progress:  &m.TaskProgress{Done: done, Total: total},
+		UpdatedOn: timestamppb.New(updated.UTC()),
+	}, nil
+}
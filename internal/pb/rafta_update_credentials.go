@@ -15,7 +15,7 @@ func (s *raftaServer) UpdateCredentials(ctx context.Context, req *m.PasswdMessag
 		return nil, err
 	}
 
-	modified, err := s.updateUserCredentials(ctx, creds.Subject, req.Secret)
+	modified, err := s.updateUserCredentials(ctx, creds.Subject, req.Secret, creds.SessionID)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,65 @@
+package pb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *raftaServer) UpdateCustomField(ctx context.Context, req *m.CustomField) (*m.CustomField, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.getUserCustomField(ctx, s.db.Queries, creds.Subject, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	fieldID := uuid.MustParse(current.Id.Value)
+
+	// Stored values are encoded according to the kind
+	if req.GetData().GetKind() != current.Data.Kind {
+		slog.WarnContext(ctx, "attempted to change the kind of a custom field",
+			"field_id", fieldID,
+		)
+		return nil, status.Error(codes.FailedPrecondition,
+			"the kind of a custom field can't change once created",
+		)
+	}
+
+	name, options, err := encodeCustomField(ctx, req.GetData())
+	if err != nil {
+		return nil, err
+	}
+
+	field, err := s.db.UpdateUserCustomField(ctx, database.UpdateUserCustomFieldParams{
+		Name:    name,
+		Options: options,
+		FieldID: fieldID,
+		Owner:   creds.Subject,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.WarnContext(ctx, "custom field not found", "field_id", fieldID)
+			return nil, status.Errorf(codes.NotFound, "custom field '%v' not found", fieldID)
+		}
+		return nil, nameConflict(ctx, err, "custom field", name)
+	}
+
+	fieldPb, err := customFieldToPb(ctx, field)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "success")
+	return fieldPb, nil
+}
@@ -0,0 +1,57 @@
+package pb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/util"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *raftaServer) UpdateFilter(ctx context.Context, req *m.SavedFilter) (*m.SavedFilter, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	filterID, err := util.ParseUUID(ctx, util.ParseUUIDParams{
+		Str: req.GetId().GetValue(), Subject: "filter_id",
+		Implication: codes.InvalidArgument, Critical: false,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	name, definition, err := encodeSavedFilter(ctx, req.GetData())
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := s.db.UpdateUserFilter(ctx, database.UpdateUserFilterParams{
+		Name:       name,
+		Definition: definition,
+		FilterID:   filterID,
+		Owner:      creds.Subject,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.WarnContext(ctx, "filter not found", "filter_id", filterID)
+			return nil, status.Errorf(codes.NotFound, "filter '%v' not found", filterID)
+		}
+		return nil, nameConflict(ctx, err, "filter", name)
+	}
+
+	filterPb, err := savedFilterToPb(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "success")
+	return filterPb, nil
+}
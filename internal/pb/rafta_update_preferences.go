@@ -0,0 +1,81 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func (s *raftaServer) UpdatePreferences(ctx context.Context, prefs *m.Preferences) (*m.Preferences, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	var urgency []byte
+	if prefs.GetUrgency() != nil {
+		if err := validateUrgency(prefs.GetUrgency()); err != nil {
+			slog.WarnContext(ctx, "invalid urgency coefficients", logging.ErrKey, err)
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		urgency, err = proto.Marshal(prefs.GetUrgency())
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to encode urgency coefficients", logging.ErrKey, err)
+			return nil, status.Error(codes.Internal, "failed to encode preferences")
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx,
+			"Failed to start preferences update transaction",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to update preferences")
+	}
+	defer tx.Rollback()
+	db := s.db.WithTx(tx)
+
+	row, err := db.SetUserPreferences(ctx, database.SetUserPreferencesParams{
+		UserID:  creds.Subject,
+		Urgency: urgency,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to update user preferences", logging.ErrKey, err)
+		return nil, status.Error(codes.Internal, "failed to update preferences")
+	}
+
+	// Empty preferences delete their setting to fall back on the default
+	settings, err := setSettings(ctx, db, creds.Subject, []*m.Setting{
+		{Namespace: settingsNamespace, Key: settingTimeZone, Value: prefs.GetTimeZone()},
+		{Namespace: settingsNamespace, Key: settingLocale, Value: prefs.GetLocale()},
+		{Namespace: settingsNamespace, Key: settingDigestTime, Value: prefs.GetDigestTime()},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := preferencesToPb(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	stored.TimeZone = settings[0].GetValue()
+	stored.Locale = settings[1].GetValue()
+	stored.DigestTime = settings[2].GetValue()
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx, "failed to commit transaction", logging.ErrKey, err)
+		return nil, status.Error(codes.Internal, "failed to properly complete preferences update")
+	}
+	s.settings.publish(creds.Subject, settings...)
+
+	slog.InfoContext(ctx, "success")
+	return withDefaults(stored), nil
+}
@@ -0,0 +1,64 @@
+package pb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *raftaServer) UpdatePushEndpoint(ctx context.Context, req *m.PushEndpoint) (*m.PushEndpoint, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.getUserPushEndpoint(ctx, s.db.Queries, creds.Subject, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := validatePushEndpoint(ctx, req.GetData())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetData().GetKind() != m.PushEndpointKind(current.Kind) {
+		slog.WarnContext(ctx, "attempted to change the kind of a push endpoint",
+			"endpoint_id", current.EndpointID,
+		)
+		return nil, status.Error(codes.FailedPrecondition,
+			"the kind of a push endpoint can't change once created",
+		)
+	}
+
+	token := current.Token
+	if req.GetData().GetToken() != "" {
+		token = sql.NullString{String: req.GetData().GetToken(), Valid: true}
+	}
+
+	endpoint, err := s.db.UpdateUserPushEndpoint(ctx, database.UpdateUserPushEndpointParams{
+		Name:       name,
+		Url:        req.GetData().GetUrl(),
+		Token:      token,
+		Events:     pushEventMask(req.GetData().GetEvents()),
+		Enabled:    !req.GetData().GetDisabled(),
+		EndpointID: current.EndpointID,
+		UserID:     creds.Subject,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.WarnContext(ctx, "push endpoint not found", "endpoint_id", current.EndpointID)
+			return nil, status.Errorf(codes.NotFound, "push endpoint '%v' not found", current.EndpointID)
+		}
+		return nil, nameConflict(ctx, err, "push endpoint", name)
+	}
+
+	slog.InfoContext(ctx, "success")
+	return pushEndpointToPb(endpoint), nil
+}
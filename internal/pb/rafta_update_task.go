@@ -4,15 +4,21 @@ import (
 	"context"
 	"database/sql"
 	"log/slog"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
 	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/markdown"
 	m "github.com/ChausseBenjamin/rafta/pkg/model"
 	"github.com/google/uuid"
 	"github.com/nullism/bqb"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 func (s *raftaServer) UpdateTask(
@@ -24,6 +30,47 @@ func (s *raftaServer) UpdateTask(
 		return nil, err
 	}
 
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx,
+			"Task update transaction initialization failure",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal,
+			"Failed begin start the process of updating a task",
+		)
+	}
+	defer tx.Rollback()
+
+	resp, err := s.updateTask(ctx, tx, creds.Subject, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx,
+			"failure to commit task update transaction",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to complete task update")
+	}
+
+	// The paths and ID were already validated by the update itself
+	paths, _ := taskUpdatePaths(ctx, req)
+	taskID := uuid.MustParse(req.Id.Value)
+	s.notifyTaskChange(ctx, creds.Subject, taskID, slices.Contains(paths, "assignee"))
+	return resp, nil
+}
+
+// updateTask applies a task update within an existing transaction so that
+// other endpoints changing a task (ex: starting a timer) go through the same
+// path as UpdateTask.
+func (s *raftaServer) updateTask(
+	ctx context.Context,
+	tx *sql.Tx,
+	owner uuid.UUID,
+	req *m.TaskUpdateRequest,
+) (*m.TaskUpdateResponse, error) {
 	taskID, err := uuid.Parse(req.Id.Value)
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to task id",
@@ -35,47 +82,67 @@ func (s *raftaServer) UpdateTask(
 		)
 	}
 
-	tx, err := s.db.BeginTx(ctx, nil)
+	paths, err := taskUpdatePaths(ctx, req)
 	if err != nil {
-		slog.ErrorContext(ctx,
-			"Task update transaction initialization failure",
-			logging.ErrKey, err,
-		)
-		return nil, status.Error(codes.Internal,
-			"Failed begin start the process of updating a task",
-		)
+		return nil, err
 	}
-	defer tx.Rollback()
 
-	var state_changed bool
+	var state_changed, assignee_changed, fields_changed bool
 	q := bqb.New("update tasks set updated_on = CURRENT_TIMESTAMP")
-	masks := removeDuplicate(req.Masks)
-	for _, mask := range masks {
-		switch mask {
-		case m.TaskFieldMask_TITLE:
-			q.Concat(", title = ?", req.Data.Title)
-		case m.TaskFieldMask_DESC:
-			q.Concat(", description = ?", req.Data.Desc)
-		case m.TaskFieldMask_PRIORITY:
-			q.Concat(", priority = ?", req.Data.Priority)
-		case m.TaskFieldMask_STATE:
-			q.Concat(", state = ?", req.Data.State)
+	data := req.GetData()
+	for _, path := range paths {
+		switch path {
+		case "title":
+			q.Concat(", title = ?", data.GetTitle())
+		case "desc":
+			done, total := markdown.Progress(data.GetDesc())
+			q.Concat(", description = ?, checklist_done = ?, checklist_total = ?",
+				data.GetDesc(), done, total,
+			)
+		case "priority":
+			q.Concat(", priority = ?", data.GetPriority())
+		case "state":
+			q.Concat(", state = ?", data.GetState())
 			state_changed = true
-		case m.TaskFieldMask_RECURRENCE:
+		case "recurrence":
 			q.Concat(", recurrence_pattern = ?, recurrence_enabled = ?",
-				req.Data.Recurrence.Pattern, req.Data.Recurrence.Active,
+				recurrencePattern(data), data.GetRecurrence().GetActive(),
 			)
-		case m.TaskFieldMask_TAGS:
-			if err := s.syncTags(ctx, taskID, req.Data.Tags, s.db.WithTx(tx)); err != nil {
+		case "recurrence.pattern":
+			q.Concat(", recurrence_pattern = ?", recurrencePattern(data))
+		case "recurrence.active":
+			q.Concat(", recurrence_enabled = ?", data.GetRecurrence().GetActive())
+		case "do":
+			do, err := taskDateToDB(ctx, "do date", data.GetDo(), data.GetDoDate())
+			if err != nil {
 				return nil, err
 			}
+			q.Concat(", do_date = ?", do)
+		case "due":
+			due, err := taskDateToDB(ctx, "due date", data.GetDue(), data.GetDueDate())
+			if err != nil {
+				return nil, err
+			}
+			q.Concat(", due_date = ?", due)
+		case "tags":
+			if err := s.syncTags(ctx, owner, taskID, data.GetTags(), s.db.WithTx(tx)); err != nil {
+				return nil, err
+			}
+		case "hidden_until":
+			q.Concat(", hidden_until = ?", nullTime(data.GetHiddenUntil()))
+		case "assignee":
+			// Handled once the task ownership is confirmed
+			assignee_changed = true
+		case "fields":
+			// Handled once the task ownership is confirmed
+			fields_changed = true
 		}
 	}
 
 	query, args, err := q.Concat(` where task_id = ? and owner = ? returning
 		recurrence_pattern,
 		recurrence_enabled,
-		updated_on;`, req.Id.Value, creds.Subject,
+		updated_on;`, req.Id.Value, owner,
 	).ToSql()
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to build query",
@@ -87,7 +154,7 @@ func (s *raftaServer) UpdateTask(
 	slog.InfoContext(ctx, "executing update query",
 		"query", query,
 		"task_id", req.Id.Value,
-		"owner_id", creds.Subject,
+		"owner_id", owner,
 	)
 
 	// Debug: Check if task exists and who owns it
@@ -105,13 +172,13 @@ func (s *raftaServer) UpdateTask(
 		slog.InfoContext(ctx, "task exists",
 			"task_id", req.Id.Value,
 			"actual_owner", existingOwner,
-			"requesting_user", creds.Subject,
+			"requesting_user", owner,
 		)
-		if existingOwner != creds.Subject.String() {
+		if existingOwner != owner.String() {
 			slog.WarnContext(ctx, "unauthorized task update attempt",
 				"task_id", req.Id.Value,
 				"actual_owner", existingOwner,
-				"requesting_user", creds.Subject,
+				"requesting_user", owner,
 			)
 			return nil, status.Error(
 				codes.PermissionDenied,
@@ -136,26 +203,43 @@ func (s *raftaServer) UpdateTask(
 			logging.ErrKey, err,
 			"query", query,
 			"task_id", req.Id.Value,
-			"owner_id", creds.Subject,
+			"owner_id", owner,
 		)
 		return nil, status.Error(codes.Internal,
 			"failed to feetch updated task",
 		)
 	}
 
+	if assignee_changed {
+		db := s.db.WithTx(tx)
+		task, err := db.GetUserTask(ctx, database.GetUserTaskParams{
+			TaskID: taskID,
+			Owner:  owner,
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to retrieve task to assign",
+				"task_id", taskID,
+				logging.ErrKey, err,
+			)
+			return nil, status.Error(codes.Internal, "failed to retrieve task to assign")
+		}
+		if err := s.assignTask(ctx, db, task, req.GetData().GetAssignee(), owner); err != nil {
+			return nil, err
+		}
+	}
+
+	if fields_changed {
+		if err := s.setFieldValues(ctx, s.db.WithTx(tx), owner, taskID, req.GetData().GetFields()); err != nil {
+			return nil, err
+		}
+	}
+
 	if recurrenceEnabled && state_changed {
 		if err := s.rescheduleTask(ctx, taskID, tx); err != nil {
 			return nil, err
 		}
 	}
 
-	if err := tx.Commit(); err != nil {
-		slog.ErrorContext(ctx,
-			"failure to commit task update transaction",
-			logging.ErrKey, err,
-		)
-		return nil, status.Error(codes.Internal, "failed to complete task update")
-	}
 	return nil, nil
 }
 
@@ -164,20 +248,96 @@ func (s *raftaServer) rescheduleTask(ctx context.Context, id uuid.UUID, _ *sql.T
 	// would block any task containing recurrence info of being updated/used.
 	// nil is returned instead of UNIMPLEMENTED to allow users to still use those
 	// tasks despite that.
+	// Once implemented, occurrences should follow the calendar of the owner
+	// (see userLocation) so that daily tasks keep their time across DST.
 	slog.WarnContext(ctx, "Recurrence isn't currently implemented, skipping reschedule",
 		"task_id", id,
 	)
 	return nil
 }
 
-func removeDuplicate[T comparable](sliceList []T) []T {
-	allKeys := make(map[T]bool)
-	list := []T{}
-	for _, item := range sliceList {
-		if _, value := allKeys[item]; !value {
-			allKeys[item] = true
-			list = append(list, item)
+// Paths of the fields TaskFieldMask values used to bind to.
+var taskFieldMaskPaths = map[m.TaskFieldMask]string{
+	m.TaskFieldMask_TITLE:         "title",
+	m.TaskFieldMask_DESC:          "desc",
+	m.TaskFieldMask_PRIORITY:      "priority",
+	m.TaskFieldMask_STATE:         "state",
+	m.TaskFieldMask_RECURRENCE:    "recurrence",
+	m.TaskFieldMask_DO_DATE:       "do_date",
+	m.TaskFieldMask_DUE_DATE:      "due_date",
+	m.TaskFieldMask_TAGS:          "tags",
+	m.TaskFieldMask_ASSIGNEE:      "assignee",
+	m.TaskFieldMask_HIDDEN_UNTIL:  "hidden_until",
+	m.TaskFieldMask_CUSTOM_FIELDS: "fields",
+}
+
+// Paths of the instants older clients set in place of task dates.
+var taskDateAliases = map[string]string{
+	"do_date":  "do",
+	"due_date": "due",
+}
+
+// taskUpdatePaths returns the TaskData fields an update request changes
+// without duplicates (nested paths covered by their parent are dropped).
+// Requests still relying on TaskFieldMask get their masks converted and the
+// do_date/due_date paths become do/due.
+func taskUpdatePaths(ctx context.Context, req *m.TaskUpdateRequest) ([]string, error) {
+	if req.GetUpdateMask() != nil && len(req.GetMasks()) > 0 {
+		slog.WarnContext(ctx, "task update uses both update_mask and masks")
+		return nil, status.Error(codes.InvalidArgument,
+			"update_mask and masks can't be combined",
+		)
+	}
+
+	mask := req.GetUpdateMask()
+	if mask == nil {
+		mask = &fieldmaskpb.FieldMask{}
+		for _, legacy := range req.GetMasks() {
+			path, ok := taskFieldMaskPaths[legacy]
+			if !ok {
+				slog.WarnContext(ctx, "unknown task field mask", "mask", legacy)
+				return nil, status.Errorf(codes.InvalidArgument,
+					"unknown task field mask '%v'", legacy,
+				)
+			}
+			mask.Paths = append(mask.Paths, path)
+		}
+	}
+
+	if !mask.IsValid(&m.TaskData{}) {
+		slog.WarnContext(ctx, "invalid task update mask", "paths", mask.GetPaths())
+		return nil, status.Errorf(codes.InvalidArgument,
+			"update mask %v doesn't only name fields of TaskData", mask.GetPaths(),
+		)
+	}
+	mask = proto.Clone(mask).(*fieldmaskpb.FieldMask)
+	mask.Normalize()
+
+	paths := mask.GetPaths()
+	for i, path := range paths {
+		if alias, ok := taskDateAliases[path]; ok {
+			paths[i] = alias
+		}
+	}
+	slices.Sort(paths)
+	paths = slices.Compact(paths)
+
+	for _, path := range paths {
+		// Nested paths are only supported where a column backs them
+		if strings.Contains(path, ".") &&
+			path != "recurrence.pattern" && path != "recurrence.active" {
+			slog.WarnContext(ctx, "unsupported task update path", "path", path)
+			return nil, status.Errorf(codes.InvalidArgument,
+				"task fields can't be partially updated through '%s'", path,
+			)
 		}
 	}
-	return list
+	return paths, nil
+}
+
+// recurrencePattern returns the database representation of the recurrence
+// pattern of a task.
+func recurrencePattern(data *m.TaskData) sql.NullString {
+	pattern := data.GetRecurrence().GetPattern()
+	return sql.NullString{String: pattern, Valid: pattern != ""}
 }
@@ -0,0 +1,57 @@
+package pb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/util"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *raftaServer) UpdateTemplate(ctx context.Context, req *m.Template) (*m.Template, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	templateID, err := util.ParseUUID(ctx, util.ParseUUIDParams{
+		Str: req.GetId().GetValue(), Subject: "template_id",
+		Implication: codes.InvalidArgument, Critical: false,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	name, definition, err := encodeTemplate(ctx, req.GetData())
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := s.db.UpdateUserTemplate(ctx, database.UpdateUserTemplateParams{
+		Name:       name,
+		Definition: definition,
+		TemplateID: templateID,
+		Owner:      creds.Subject,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.WarnContext(ctx, "template not found", "template_id", templateID)
+			return nil, status.Errorf(codes.NotFound, "template '%v' not found", templateID)
+		}
+		return nil, nameConflict(ctx, err, "template", name)
+	}
+
+	templatePb, err := templateToPb(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "success")
+	return templatePb, nil
+}
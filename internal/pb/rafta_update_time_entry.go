@@ -0,0 +1,100 @@
+package pb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/util"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *raftaServer) UpdateTimeEntry(ctx context.Context, req *m.TimeEntry) (*m.TimeEntry, error) {
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	entryID, err := util.ParseUUID(ctx, util.ParseUUIDParams{
+		Str: req.GetId().GetValue(), Subject: "entry_id",
+		Implication: codes.InvalidArgument, Critical: false,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx,
+			"Failed to start time entry update transaction",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "Failed to begin time entry update")
+	}
+	defer tx.Rollback()
+	db := s.db.WithTx(tx)
+
+	entry, err := db.GetUserTimeEntry(ctx, database.GetUserTimeEntryParams{
+		EntryID: entryID,
+		UserID:  creds.Subject,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.WarnContext(ctx, "time entry not found", "entry_id", entryID)
+			return nil, status.Errorf(codes.NotFound, "time entry '%v' not found", entryID)
+		}
+		slog.ErrorContext(ctx, "failed to retrieve time entry",
+			"entry_id", entryID,
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to retrieve time entry")
+	}
+
+	now := time.Now().UTC()
+	started, ended, err := timeEntryBounds(ctx, req.GetData(), !entry.EndedOn.Valid, now)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.getAccessibleTask(ctx, db, req.GetData().GetTaskId(), creds.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err = db.UpdateUserTimeEntry(ctx, database.UpdateUserTimeEntryParams{
+		TaskID:    task.TaskID,
+		StartedOn: started,
+		EndedOn:   ended,
+		Note: sql.NullString{
+			String: req.GetData().GetNote(),
+			Valid:  (req.GetData().GetNote() != ""),
+		},
+		EntryID: entryID,
+		UserID:  creds.Subject,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to update time entry",
+			"entry_id", entryID,
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to update time entry")
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx,
+			"failed to commit transaction",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to complete time entry update")
+	}
+
+	slog.InfoContext(ctx, "success")
+	return timeEntryToPb(entry, now), nil
+}
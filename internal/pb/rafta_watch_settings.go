@@ -0,0 +1,58 @@
+package pb
+
+import (
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *raftaServer) WatchSettings(req *m.SettingsRequest, stream m.Rafta_WatchSettingsServer) error {
+	ctx := stream.Context()
+	creds, err := auth.GetCreds(ctx, auth.AccessTokenType)
+	if err != nil {
+		return err
+	}
+
+	// Watching before reading the current settings ensures no change falls
+	// in between (at worst a setting gets sent twice).
+	w := s.settings.watch(creds.Subject, req.GetNamespace())
+	defer s.settings.unwatch(creds.Subject, w)
+
+	rows, err := s.db.GetUserSettings(ctx, database.GetUserSettingsParams{
+		UserID:    creds.Subject,
+		Namespace: req.GetNamespace(),
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to retrieve settings", logging.ErrKey, err)
+		return status.Error(codes.Internal, "failed to retrieve settings")
+	}
+	for _, row := range rows {
+		if err := stream.Send(settingToPb(row)); err != nil {
+			return err
+		}
+	}
+
+	slog.InfoContext(ctx, "watching settings")
+	for {
+		select {
+		case <-ctx.Done():
+			slog.InfoContext(ctx, "success")
+			return nil
+		case setting, ok := <-w.changes:
+			if !ok {
+				slog.WarnContext(ctx, "settings watcher fell behind")
+				return status.Error(codes.Aborted,
+					"too many setting changes to keep up with, watch again",
+				)
+			}
+			if err := stream.Send(setting); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -13,9 +13,13 @@ import (
 	"github.com/ChausseBenjamin/rafta/internal/auth"
 	"github.com/ChausseBenjamin/rafta/internal/database"
 	"github.com/ChausseBenjamin/rafta/internal/intercept"
+	"github.com/ChausseBenjamin/rafta/internal/mail"
+	"github.com/ChausseBenjamin/rafta/internal/oidc"
+	"github.com/ChausseBenjamin/rafta/internal/push"
 	"github.com/ChausseBenjamin/rafta/internal/util"
 	m "github.com/ChausseBenjamin/rafta/pkg/model"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -33,9 +37,13 @@ type protoDB struct {
 
 // used to simplify wrapping for certain tasks
 type protoServer struct {
-	auth *auth.AuthManager
-	cfg  *util.ConfigStore
-	db   *protoDB
+	auth     *auth.AuthManager
+	cfg      *util.ConfigStore
+	db       *protoDB
+	settings *settingsHub
+	push     *push.Dispatcher
+	mail     *mail.Mailer   // nil when emails are disabled
+	oidc     *oidc.Provider // nil when single sign-on is disabled
 }
 
 type raftaServer struct {
@@ -66,20 +74,47 @@ func NewAuthServer(ps *protoServer) *authServer {
 }
 
 // Setup creates a new gRPC with both services
-// and starts listening on the given port
-func Setup(ctx context.Context, authMgr *auth.AuthManager, cfg *util.ConfigStore, db *sql.DB) (*grpc.Server, *database.Queries, error) {
+// and starts listening on the given port. Emails are disabled without a mailer.
+func Setup(ctx context.Context, authMgr *auth.AuthManager, cfg *util.ConfigStore, db *sql.DB, mailer *mail.Mailer) (*grpc.Server, *database.Queries, error) {
 	slog.DebugContext(ctx, "Configuring gRPC server")
-	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
-		intercept.Tagging,
-		authMgr.Authenticating(),
-	))
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(
+			intercept.Tagging,
+			authMgr.Authenticating(),
+		),
+		grpc.ChainStreamInterceptor(
+			intercept.StreamTagging,
+			authMgr.StreamAuthenticating(),
+		),
+	}
+	if cfg.TLS != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(cfg.TLS)))
+	}
+	server := grpc.NewServer(opts...)
 
 	queries, err := database.Prepare(ctx, db)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	ps := &protoServer{auth: authMgr, cfg: cfg, db: &protoDB{DB: db, Queries: queries}}
+	ps := &protoServer{
+		auth:     authMgr,
+		cfg:      cfg,
+		db:       &protoDB{DB: db, Queries: queries},
+		settings: newSettingsHub(),
+	}
+	ps.push = push.NewDispatcher(push.DefaultRetryDelays, cfg.PushPrivate, ps.reportPush)
+	go ps.pushDates(ctx)
+	go ps.revealTasks(ctx)
+
+	if mailer != nil {
+		ps.mail = mailer
+		go ps.sendDigests(ctx)
+	}
+
+	if cfg.OIDC != nil {
+		ps.oidc = oidc.New(*cfg.OIDC)
+	}
 
 	reflection.Register(server)
 	m.RegisterAuthServer(server, NewAuthServer(ps))
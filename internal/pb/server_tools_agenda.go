@@ -0,0 +1,91 @@
+package pb
+
+import (
+	"slices"
+	"time"
+
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	agendaDateLayout  = "2006-01-02"
+	agendaDefaultDays = 7
+	agendaMaxDays     = 366
+)
+
+// buildAgenda sorts tasks into the calendar days starting on the given date.
+// Days are computed in the location of start so that they span from one local
+// midnight to the next even when daylight saving time shifts. All-day and
+// floating dates land on their own day wherever the user is while absolute
+// ones land on the local day they happen on.
+func buildAgenda(tasks []*m.Task, start time.Time, days int, now time.Time) *m.Agenda {
+	loc := start.Location()
+	y, mo, d := start.Date()
+
+	agenda := &m.Agenda{
+		TimeZone: loc.String(),
+		Days:     make([]*m.AgendaDay, days),
+	}
+	byDate := make(map[string]*m.AgendaDay, days)
+	for i := range agenda.Days {
+		dayStart := time.Date(y, mo, d+i, 0, 0, 0, 0, loc)
+		day := &m.AgendaDay{
+			Date:  dayStart.Format(agendaDateLayout),
+			Start: timestamppb.New(dayStart),
+			End:   timestamppb.New(time.Date(y, mo, d+i+1, 0, 0, 0, 0, loc)),
+		}
+		agenda.Days[i] = day
+		byDate[day.Date] = day
+	}
+
+	// Overdue tasks are the ones missed before the agenda (and before now)
+	overdueBefore := time.Date(y, mo, d, 0, 0, 0, 0, loc)
+	if now.Before(overdueBefore) {
+		overdueBefore = now
+	}
+
+	for _, task := range tasks {
+		data := task.GetData()
+		if due := data.GetDue(); due != nil {
+			if day, ok := byDate[dateDay(due, loc)]; ok {
+				day.Due = append(day.Due, task)
+			}
+			if data.GetState() != m.TaskState_DONE && dateEnd(due, loc).Before(overdueBefore) {
+				agenda.Overdue = append(agenda.Overdue, task)
+			}
+		}
+		if do := data.GetDo(); do != nil {
+			if day, ok := byDate[dateDay(do, loc)]; ok {
+				day.Do = append(day.Do, task)
+			}
+		}
+	}
+
+	// All-day dates come before the timed ones of the same day
+	compareDates := func(a, b *m.TaskDate) int {
+		if c := dateStart(a, loc).Compare(dateStart(b, loc)); c != 0 {
+			return c
+		}
+		switch {
+		case a.GetTime() == nil && b.GetTime() != nil:
+			return -1
+		case a.GetTime() != nil && b.GetTime() == nil:
+			return 1
+		}
+		return 0
+	}
+	byDue := func(a, b *m.Task) int {
+		return compareDates(a.GetData().GetDue(), b.GetData().GetDue())
+	}
+	byDo := func(a, b *m.Task) int {
+		return compareDates(a.GetData().GetDo(), b.GetData().GetDo())
+	}
+	slices.SortStableFunc(agenda.Overdue, byDue)
+	for _, day := range agenda.Days {
+		slices.SortStableFunc(day.Due, byDue)
+		slices.SortStableFunc(day.Do, byDo)
+	}
+
+	return agenda
+}
@@ -0,0 +1,53 @@
+package pb
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/certs"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func clientCertificateToPb(c database.ClientCertificate) *m.ClientCertificate {
+	return &m.ClientCertificate{
+		Id:          &m.UUID{Value: c.CertID.String()},
+		Name:        c.Name,
+		Fingerprint: c.Fingerprint,
+		Subject:     c.Subject,
+		NotAfter:    timestamppb.New(c.NotAfter.UTC()),
+		CreatedOn:   timestamppb.New(c.CreatedOn.UTC()),
+		LastUsed:    pbTime(c.LastUsed),
+	}
+}
+
+// validateClientCertificate checks a certificate sent by a client and returns
+// its trimmed name along with the decoded certificate.
+func validateClientCertificate(ctx context.Context, data *m.ClientCertificateData) (string, *x509.Certificate, error) {
+	name := strings.TrimSpace(data.GetName())
+	var (
+		cert *x509.Certificate
+		err  error
+	)
+	if name == "" {
+		err = errors.New("client certificates must have a name")
+	} else if cert, err = certs.ParseCertificate(data.GetPem()); err != nil {
+		err = fmt.Errorf("invalid client certificate: %w", err)
+	} else if time.Now().After(cert.NotAfter) {
+		err = errors.New("client certificate is expired")
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "received invalid client certificate", logging.ErrKey, err)
+		return "", nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return name, cert, nil
+}
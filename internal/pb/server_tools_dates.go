@@ -0,0 +1,131 @@
+package pb
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/caldate"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func taskDateToPb(d caldate.Date) *m.TaskDate {
+	date := &m.TaskDate{
+		Date: &m.CalendarDate{
+			Year:  int32(d.Year),
+			Month: int32(d.Month),
+			Day:   int32(d.Day),
+		},
+		TimeZone: d.Zone,
+	}
+	if d.Kind != caldate.AllDay {
+		date.Time = &m.TimeOfDay{
+			Hours:   int32(d.Hour),
+			Minutes: int32(d.Minute),
+			Seconds: int32(d.Second),
+		}
+	}
+	return date
+}
+
+// taskDateFromPb validates a task date sent by a client.
+func taskDateFromPb(d *m.TaskDate) (caldate.Date, error) {
+	kind := caldate.AllDay
+	if d.GetTime() != nil {
+		kind = caldate.Floating
+		if d.GetTimeZone() != "" {
+			kind = caldate.Absolute
+		}
+	}
+	return caldate.New(kind,
+		int(d.GetDate().GetYear()),
+		time.Month(d.GetDate().GetMonth()),
+		int(d.GetDate().GetDay()),
+		int(d.GetTime().GetHours()),
+		int(d.GetTime().GetMinutes()),
+		int(d.GetTime().GetSeconds()),
+		d.GetTimeZone(),
+	)
+}
+
+// storedTaskDate decodes a task date as stored in the database (nil if
+// unset).
+func storedTaskDate(s sql.NullString) *m.TaskDate {
+	if !s.Valid {
+		return nil
+	}
+	d, err := caldate.Parse(s.String)
+	if err != nil {
+		// Only valid dates get stored
+		return nil
+	}
+	return taskDateToPb(d)
+}
+
+// taskDateToDB returns the database representation of a task date. The
+// date wins over the instant which is only there for older clients.
+func taskDateToDB(
+	ctx context.Context,
+	subject string,
+	date *m.TaskDate,
+	instant *timestamppb.Timestamp,
+) (sql.NullString, error) {
+	switch {
+	case date != nil:
+		d, err := taskDateFromPb(date)
+		if err != nil {
+			slog.WarnContext(ctx, "received invalid task date",
+				"subject", subject,
+				logging.ErrKey, err,
+			)
+			return sql.NullString{}, status.Errorf(codes.InvalidArgument,
+				"invalid %s: %v", subject, err,
+			)
+		}
+		return sql.NullString{String: d.String(), Valid: true}, nil
+	case instant != nil:
+		return sql.NullString{String: caldate.At(instant.AsTime().UTC()).String(), Valid: true}, nil
+	default:
+		return sql.NullString{}, nil
+	}
+}
+
+// dateStart returns the instant a task date begins at in a given location.
+// Dates come from the database and are therefore valid.
+func dateStart(d *m.TaskDate, loc *time.Location) time.Time {
+	date, _ := taskDateFromPb(d)
+	return date.Start(loc)
+}
+
+// dateEnd returns the instant a task date is over in a given location (the
+// end of the day for all-day dates).
+func dateEnd(d *m.TaskDate, loc *time.Location) time.Time {
+	date, _ := taskDateFromPb(d)
+	return date.End(loc)
+}
+
+// dateDay returns the calendar day a task date falls on in a given location
+// (YYYY-MM-DD).
+func dateDay(d *m.TaskDate, loc *time.Location) string {
+	date, _ := taskDateFromPb(d)
+	y, mo, day := date.LocalDate(loc)
+	return time.Date(y, mo, day, 0, 0, 0, 0, time.UTC).Format(agendaDateLayout)
+}
+
+// resolveTaskDates fills in the instants the dates of a task resolve to in a
+// given location.
+func resolveTaskDates(task *m.Task, loc *time.Location) {
+	data := task.GetData()
+	data.DoDate, data.DueDate = nil, nil
+	if data.GetDo() != nil {
+		data.DoDate = timestamppb.New(dateStart(data.GetDo(), loc))
+	}
+	if data.GetDue() != nil {
+		data.DueDate = timestamppb.New(dateStart(data.GetDue(), loc))
+	}
+}
@@ -0,0 +1,148 @@
+package pb
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/mail"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"github.com/google/uuid"
+)
+
+const (
+	digestTimeLayout = "15:04"
+	// How often digest times are checked for emails to send
+	digestPollInterval = time.Minute
+)
+
+// sendDigests emails users their daily digest as their digest time arrives.
+// Like pushDates, times that arrive while the server is down are skipped.
+func (s *protoServer) sendDigests(ctx context.Context) {
+	slog.DebugContext(ctx, "Watching digest times for emails to send")
+	since := time.Now()
+	ticker := time.NewTicker(digestPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.sendDigestsBetween(ctx, since, now)
+			since = now
+		}
+	}
+}
+
+// sendDigestsBetween sends the digests due within (since, until].
+func (s *protoServer) sendDigestsBetween(ctx context.Context, since, until time.Time) {
+	holders, err := s.db.GetSettingHolders(ctx, database.GetSettingHoldersParams{
+		Namespace: settingsNamespace,
+		Key:       settingDigestTime,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to retrieve digest times", logging.ErrKey, err)
+		return
+	}
+
+	for _, holder := range holders {
+		at, err := time.Parse(digestTimeLayout, holder.Value)
+		if err != nil {
+			// Only valid times get stored
+			slog.ErrorContext(ctx, "stored digest time is invalid",
+				"user_id", holder.UserID,
+				logging.ErrKey, err,
+			)
+			continue
+		}
+		loc, err := s.userLocation(ctx, s.db.Queries, holder.UserID, "")
+		if err != nil {
+			continue
+		}
+
+		// The window can straddle midnight in the user's time zone
+		for _, day := range []time.Time{since.In(loc), until.In(loc)} {
+			y, mo, d := day.Date()
+			sendAt := time.Date(y, mo, d, at.Hour(), at.Minute(), 0, 0, loc)
+			if sendAt.After(since) && !sendAt.After(until) {
+				s.sendDigest(ctx, holder.UserID, sendAt)
+				break
+			}
+		}
+	}
+}
+
+// sendDigest emails a user the agenda of the day at the given time. Users
+// with nothing to do that day aren't emailed.
+func (s *protoServer) sendDigest(ctx context.Context, userID uuid.UUID, at time.Time) {
+	log := slog.With("user_id", userID)
+
+	user, err := s.db.GetUser(ctx, userID)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to retrieve digest recipient", logging.ErrKey, err)
+		return
+	}
+
+	tasks, err := s.db.GetVisibleUserTasks(ctx, database.GetVisibleUserTasksParams{
+		Owner: userID,
+		Now:   sql.NullTime{Time: at.UTC(), Valid: true},
+	})
+	if err != nil {
+		log.ErrorContext(ctx, "failed to retrieve tasks for digest", logging.ErrKey, err)
+		return
+	}
+	tasksPb, err := s.tasksToPb(ctx, userID, tasks)
+	if err != nil {
+		return
+	}
+
+	digest := digestOf(user.Name, buildAgenda(tasksPb, at, 1, at), at.Location())
+	if digest.Empty() {
+		log.DebugContext(ctx, "Nothing to put in digest, skipping it")
+		return
+	}
+
+	msg, err := digest.Message(user.Email)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to render digest", logging.ErrKey, err)
+		return
+	}
+	if err := s.mail.Send(ctx, msg); err != nil {
+		log.WarnContext(ctx, "Failed to email digest", logging.ErrKey, err)
+		return
+	}
+	log.DebugContext(ctx, "Emailed digest")
+}
+
+// digestOf sums up the agenda of a single day, leaving out what's done.
+func digestOf(name string, agenda *m.Agenda, loc *time.Location) mail.Digest {
+	day := agenda.GetDays()[0]
+	digest := mail.Digest{Name: name, Day: day.GetStart().AsTime().In(loc)}
+
+	list := func(tasks []*m.Task, date func(*m.TaskData) *m.TaskDate, sameDay bool) []mail.DigestTask {
+		var listed []mail.DigestTask
+		for _, task := range tasks {
+			if task.GetData().GetState() == m.TaskState_DONE {
+				continue
+			}
+			d := date(task.GetData())
+			when := "All day"
+			switch {
+			case !sameDay:
+				when = dateStart(d, loc).Format("Jan 2")
+			case d.GetTime() != nil:
+				when = dateStart(d, loc).Format(digestTimeLayout)
+			}
+			listed = append(listed, mail.DigestTask{Title: task.GetData().GetTitle(), When: when})
+		}
+		return listed
+	}
+	due := (*m.TaskData).GetDue
+	digest.Overdue = list(agenda.GetOverdue(), due, false)
+	digest.Due = list(day.GetDue(), due, true)
+	digest.Do = list(day.GetDo(), (*m.TaskData).GetDo, true)
+	return digest
+}
@@ -0,0 +1,246 @@
+package pb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"math"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/util"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// customFieldToPb converts a custom field definition to its protobuf
+// representation.
+func customFieldToPb(ctx context.Context, f database.CustomField) (*m.CustomField, error) {
+	var options []string
+	if f.Options.Valid {
+		if err := json.Unmarshal([]byte(f.Options.String), &options); err != nil {
+			slog.ErrorContext(ctx, "failed to decode custom field options",
+				"field_id", f.FieldID,
+				logging.ErrKey, err,
+			)
+			return nil, status.Errorf(codes.Internal,
+				"failed to decode options of custom field '%v'", f.FieldID,
+			)
+		}
+	}
+	return &m.CustomField{
+		Id: &m.UUID{Value: f.FieldID.String()},
+		Data: &m.CustomFieldDefinition{
+			Name:    f.Name,
+			Kind:    m.CustomFieldKind(f.Kind),
+			Options: options,
+		},
+	}, nil
+}
+
+// encodeCustomField validates a custom field definition and returns its name
+// and options as they get stored.
+func encodeCustomField(ctx context.Context, def *m.CustomFieldDefinition) (string, sql.NullString, error) {
+	name := strings.TrimSpace(def.GetName())
+	if name == "" {
+		slog.WarnContext(ctx, "custom field is missing a name")
+		return "", sql.NullString{}, status.Error(codes.InvalidArgument,
+			"custom fields require a name",
+		)
+	}
+	if _, ok := m.CustomFieldKind_name[int32(def.GetKind())]; !ok {
+		slog.WarnContext(ctx, "unknown custom field kind", "kind", def.GetKind())
+		return "", sql.NullString{}, status.Errorf(codes.InvalidArgument,
+			"unknown custom field kind '%v'", def.GetKind(),
+		)
+	}
+
+	if def.GetKind() != m.CustomFieldKind_FIELD_ENUM {
+		if len(def.GetOptions()) > 0 {
+			slog.WarnContext(ctx, "options provided to a non-enum custom field")
+			return "", sql.NullString{}, status.Error(codes.InvalidArgument,
+				"only enum fields accept options",
+			)
+		}
+		return name, sql.NullString{}, nil
+	}
+
+	if len(def.GetOptions()) == 0 {
+		slog.WarnContext(ctx, "enum custom field has no options")
+		return "", sql.NullString{}, status.Error(codes.InvalidArgument,
+			"enum fields require at least one option",
+		)
+	}
+	for i, option := range def.GetOptions() {
+		if option == "" || slices.Contains(def.GetOptions()[:i], option) {
+			slog.WarnContext(ctx, "invalid enum option", "option", option)
+			return "", sql.NullString{}, status.Errorf(codes.InvalidArgument,
+				"enum options must be unique and non-empty (got '%v')", option,
+			)
+		}
+	}
+	options, err := json.Marshal(def.GetOptions())
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to encode custom field options", logging.ErrKey, err)
+		return "", sql.NullString{}, status.Error(codes.Internal,
+			"failed to encode custom field options",
+		)
+	}
+	return name, sql.NullString{String: string(options), Valid: true}, nil
+}
+
+// fieldValueToPb decodes the stored representation of a custom field value.
+func fieldValueToPb(fieldID uuid.UUID, kind m.CustomFieldKind, value string) *m.CustomFieldValue {
+	v := &m.CustomFieldValue{FieldId: &m.UUID{Value: fieldID.String()}}
+	switch kind {
+	case m.CustomFieldKind_FIELD_NUMBER:
+		n, _ := strconv.ParseFloat(value, 64)
+		v.Value = &m.CustomFieldValue_Number{Number: n}
+	case m.CustomFieldKind_FIELD_DATE:
+		t, _ := time.Parse(time.RFC3339Nano, value)
+		v.Value = &m.CustomFieldValue_Date{Date: timestamppb.New(t)}
+	default:
+		v.Value = &m.CustomFieldValue_Text{Text: value}
+	}
+	return v
+}
+
+// fieldValueToDB validates a value against the definition of its field and
+// returns its stored representation.
+func fieldValueToDB(ctx context.Context, field *m.CustomField, v *m.CustomFieldValue) (string, error) {
+	invalid := func(reason string) error {
+		slog.WarnContext(ctx, "invalid custom field value",
+			"field_id", field.Id.Value,
+			"reason", reason,
+		)
+		return status.Errorf(codes.InvalidArgument,
+			"invalid value for custom field '%s': %s", field.Data.Name, reason,
+		)
+	}
+
+	switch field.Data.Kind {
+	case m.CustomFieldKind_FIELD_NUMBER:
+		n, ok := v.GetValue().(*m.CustomFieldValue_Number)
+		if !ok {
+			return "", invalid("expected a number")
+		}
+		if math.IsNaN(n.Number) || math.IsInf(n.Number, 0) {
+			return "", invalid("numbers must be finite")
+		}
+		return strconv.FormatFloat(n.Number, 'g', -1, 64), nil
+	case m.CustomFieldKind_FIELD_DATE:
+		d, ok := v.GetValue().(*m.CustomFieldValue_Date)
+		if !ok {
+			return "", invalid("expected a date")
+		}
+		return d.Date.AsTime().UTC().Format(time.RFC3339Nano), nil
+	}
+
+	t, ok := v.GetValue().(*m.CustomFieldValue_Text)
+	if !ok {
+		return "", invalid("expected text")
+	}
+	switch field.Data.Kind {
+	case m.CustomFieldKind_FIELD_ENUM:
+		if !slices.Contains(field.Data.Options, t.Text) {
+			return "", invalid("not one of the field options")
+		}
+	case m.CustomFieldKind_FIELD_URL:
+		u, err := url.Parse(t.Text)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "", invalid("expected an absolute http(s) URL")
+		}
+	}
+	return t.Text, nil
+}
+
+// getUserCustomField retrieves a custom field defined by the given user.
+func (s *protoServer) getUserCustomField(
+	ctx context.Context,
+	db *database.Queries,
+	owner uuid.UUID,
+	id *m.UUID,
+) (*m.CustomField, error) {
+	fieldID, err := util.ParseUUID(ctx, util.ParseUUIDParams{
+		Str: id.GetValue(), Subject: "field_id",
+		Implication: codes.InvalidArgument, Critical: false,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	field, err := db.GetUserCustomField(ctx, database.GetUserCustomFieldParams{
+		FieldID: fieldID,
+		Owner:   owner,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.WarnContext(ctx, "custom field not found", "field_id", fieldID)
+			return nil, status.Errorf(codes.NotFound, "custom field '%v' not found", fieldID)
+		}
+		slog.ErrorContext(ctx, "failed to retrieve custom field",
+			"field_id", fieldID,
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to retrieve custom field")
+	}
+
+	return customFieldToPb(ctx, field)
+}
+
+// setFieldValues sets the custom field values of a task. Fields are those of
+// the task owner and values left unset remove the field from the task. Fields
+// not listed are left untouched.
+func (s *protoServer) setFieldValues(
+	ctx context.Context,
+	db *database.Queries,
+	owner uuid.UUID,
+	taskID uuid.UUID,
+	values []*m.CustomFieldValue,
+) error {
+	for _, v := range values {
+		field, err := s.getUserCustomField(ctx, db, owner, v.GetFieldId())
+		if err != nil {
+			return err
+		}
+		fieldID := uuid.MustParse(field.Id.Value)
+
+		if v.GetValue() == nil {
+			err = db.UnsetTaskFieldValue(ctx, database.UnsetTaskFieldValueParams{
+				TaskID:  taskID,
+				FieldID: fieldID,
+			})
+		} else {
+			var value string
+			if value, err = fieldValueToDB(ctx, field, v); err != nil {
+				return err
+			}
+			err = db.SetTaskFieldValue(ctx, database.SetTaskFieldValueParams{
+				TaskID:  taskID,
+				FieldID: fieldID,
+				Value:   value,
+			})
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to update custom field value",
+				"task_id", taskID,
+				"field_id", fieldID,
+				logging.ErrKey, err,
+			)
+			return status.Errorf(codes.Internal,
+				"failed to update custom field '%v' of task '%v'", fieldID, taskID,
+			)
+		}
+	}
+	return nil
+}
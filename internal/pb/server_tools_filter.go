@@ -0,0 +1,510 @@
+package pb
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/util"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"github.com/google/uuid"
+	"github.com/nullism/bqb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// savedFilterToPb decodes a saved filter into its protobuf representation.
+func savedFilterToPb(ctx context.Context, f database.SavedFilter) (*m.SavedFilter, error) {
+	filter := &m.TaskFilter{}
+	if err := proto.Unmarshal(f.Definition, filter); err != nil {
+		slog.ErrorContext(ctx, "failed to decode saved filter",
+			"filter_id", f.FilterID,
+			logging.ErrKey, err,
+		)
+		return nil, status.Errorf(codes.Internal,
+			"failed to decode filter '%v'", f.FilterID,
+		)
+	}
+	return &m.SavedFilter{
+		Id: &m.UUID{Value: f.FilterID.String()},
+		Data: &m.SavedFilterData{
+			Name:   f.Name,
+			Filter: filter,
+		},
+		CreatedOn: timestamppb.New(f.CreatedOn.UTC()),
+		UpdatedOn: timestamppb.New(f.UpdatedOn.UTC()),
+	}, nil
+}
+
+// encodeSavedFilter validates a filter definition before encoding it for
+// storage so that broken filters can't be saved.
+func encodeSavedFilter(ctx context.Context, data *m.SavedFilterData) (string, []byte, error) {
+	name := strings.TrimSpace(data.GetName())
+	if name == "" {
+		slog.WarnContext(ctx, "saved filter is missing a name")
+		return "", nil, status.Error(codes.InvalidArgument, "filters require a name")
+	}
+	if err := validateFilter(ctx, data.GetFilter()); err != nil {
+		return "", nil, err
+	}
+	definition, err := proto.Marshal(data.GetFilter())
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to encode filter", logging.ErrKey, err)
+		return "", nil, status.Error(codes.Internal, "failed to encode filter")
+	}
+	return name, definition, nil
+}
+
+// nameConflict reports the error to send back when saving a named item
+// (filter, template, etc.) fails, most likely because another item of the
+// same user already bears that name.
+func nameConflict(ctx context.Context, err error, subject, name string) error {
+	if strings.Contains(err.Error(), "UNIQUE constraint") {
+		slog.WarnContext(ctx, subject+" name already in use", "name", name)
+		return status.Errorf(codes.AlreadyExists,
+			"a %s named '%v' already exists", subject, name,
+		)
+	}
+	slog.ErrorContext(ctx, "failed to save "+subject, logging.ErrKey, err)
+	return status.Errorf(codes.Internal, "failed to save %s", subject)
+}
+
+func validateFilter(ctx context.Context, f *m.TaskFilter) error {
+	if _, err := loadLocation(ctx, f.GetTimeZone()); err != nil {
+		return err
+	}
+	for _, cond := range f.GetFields() {
+		if _, err := util.ParseUUID(ctx, util.ParseUUIDParams{
+			Str: cond.GetValue().GetFieldId().GetValue(), Subject: "field_id",
+			Implication: codes.InvalidArgument, Critical: false,
+		}); err != nil {
+			return err
+		}
+	}
+	for _, sort := range f.GetSort() {
+		if sort.GetKey() != m.TaskSortKey_SORT_CUSTOM_FIELD {
+			continue
+		}
+		if _, err := util.ParseUUID(ctx, util.ParseUUIDParams{
+			Str: sort.GetFieldId().GetValue(), Subject: "field_id",
+			Implication: codes.InvalidArgument, Critical: false,
+		}); err != nil {
+			return err
+		}
+	}
+	if f.GetPriorityMin() != 0 && f.GetPriorityMax() != 0 && f.GetPriorityMin() > f.GetPriorityMax() {
+		slog.WarnContext(ctx, "received inverted priority bounds")
+		return status.Error(codes.InvalidArgument,
+			"priority_min can't be greater than priority_max",
+		)
+	}
+	return nil
+}
+
+// sourceTasks lists the tasks of a user coming from a task source. This is
+// what anything listing tasks on behalf of a filter (feeds, exports, etc.)
+// should rely on so that every client shares the same definition. An empty
+// source lists every task.
+func (s *protoServer) sourceTasks(ctx context.Context, owner uuid.UUID, src *m.TaskSource) ([]*m.Task, error) {
+	filter, err := s.resolveFilter(ctx, owner, src)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateFilter(ctx, filter); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tasks, err := s.prefilterTasks(ctx, owner, filter, now)
+	if err != nil {
+		return nil, err
+	}
+
+	tasksPb, err := s.tasksToPb(ctx, owner, tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := s.loadManualOrder(ctx, owner, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := s.userLocation(ctx, s.db.Queries, owner, filter.GetTimeZone())
+	if err != nil {
+		return nil, err
+	}
+
+	return filterTasks(ctx, filter, tasksPb, order, now.In(loc))
+}
+
+// prefilterTasks retrieves the tasks of a user which can match a filter. The
+// conditions on columns (state, tags, priority and visibility) are left to
+// the database so that large task lists don't get loaded just to be thrown
+// away. Date windows depend on the time zone and custom fields on their kind,
+// those (and everything else) are checked by taskMatches afterwards.
+func (s *protoServer) prefilterTasks(ctx context.Context, owner uuid.UUID, f *m.TaskFilter, now time.Time) ([]database.Task, error) {
+	const tagged = `exists (
+		select 1
+		from task_tags tt
+		inner join tags on tags.tag_id = tt.tag_id
+		where tt.task_id = tasks.task_id and tags.name in (?)
+	)`
+
+	q := bqb.New(`select task_id, title, state, priority, description, due_date,
+		do_date, hidden_until, recurrence_pattern, recurrence_enabled,
+		checklist_done, checklist_total, created_on, updated_on, owner, assignee
+		from tasks where owner = ?`, owner)
+	if !f.GetIncludeHidden() {
+		q.And("(hidden_until is null or hidden_until <= ?)", now.UTC())
+	}
+	if len(f.GetStates()) > 0 {
+		states := make([]any, len(f.GetStates()))
+		for i, state := range f.GetStates() {
+			states[i] = int32(state)
+		}
+		q.And("state in (?)", states)
+	}
+	for _, tag := range f.GetTagsAll() {
+		q.And(tagged, []string{tag})
+	}
+	if len(f.GetTagsAny()) > 0 {
+		q.And(tagged, f.GetTagsAny())
+	}
+	if len(f.GetTagsNone()) > 0 {
+		q.And("not "+tagged, f.GetTagsNone())
+	}
+	if f.GetPriorityMin() != 0 || f.GetPriorityMax() != 0 {
+		q.And("priority != 0")
+	}
+	if f.GetPriorityMin() != 0 {
+		q.And("priority >= ?", f.GetPriorityMin())
+	}
+	if f.GetPriorityMax() != 0 {
+		q.And("priority <= ?", f.GetPriorityMax())
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to build query", logging.ErrKey, err)
+		return nil, status.Error(codes.Internal, "failed to build task query")
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		slog.ErrorContext(ctx,
+			"failed to retrieve tasks for given user",
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to retrieve tasks")
+	}
+	defer rows.Close()
+
+	var tasks []database.Task
+	for rows.Next() {
+		var t database.Task
+		if err := rows.Scan(
+			&t.TaskID, &t.Title, &t.State, &t.Priority, &t.Description, &t.DueDate,
+			&t.DoDate, &t.HiddenUntil, &t.RecurrencePattern, &t.RecurrenceEnabled,
+			&t.ChecklistDone, &t.ChecklistTotal, &t.CreatedOn, &t.UpdatedOn, &t.Owner, &t.Assignee,
+		); err != nil {
+			slog.ErrorContext(ctx, "failed to read task", logging.ErrKey, err)
+			return nil, status.Error(codes.Internal, "failed to retrieve tasks")
+		}
+		tasks = append(tasks, t)
+	}
+	if err := rows.Err(); err != nil {
+		slog.ErrorContext(ctx, "failed to read tasks", logging.ErrKey, err)
+		return nil, status.Error(codes.Internal, "failed to retrieve tasks")
+	}
+	return tasks, nil
+}
+
+// resolveFilter returns the filter a task source refers to.
+func (s *protoServer) resolveFilter(ctx context.Context, owner uuid.UUID, src *m.TaskSource) (*m.TaskFilter, error) {
+	switch source := src.GetSource().(type) {
+	case *m.TaskSource_Filter:
+		return source.Filter, nil
+	case *m.TaskSource_SavedFilter:
+		filterID, err := util.ParseUUID(ctx, util.ParseUUIDParams{
+			Str: source.SavedFilter.GetValue(), Subject: "filter_id",
+			Implication: codes.InvalidArgument, Critical: false,
+		})
+		if err != nil {
+			return nil, err
+		}
+		saved, err := s.db.GetUserFilter(ctx, database.GetUserFilterParams{
+			FilterID: filterID,
+			Owner:    owner,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				slog.WarnContext(ctx, "filter not found", "filter_id", filterID)
+				return nil, status.Errorf(codes.NotFound, "filter '%v' not found", filterID)
+			}
+			slog.ErrorContext(ctx, "failed to retrieve filter",
+				"filter_id", filterID,
+				logging.ErrKey, err,
+			)
+			return nil, status.Error(codes.Internal, "failed to retrieve filter")
+		}
+		f, err := savedFilterToPb(ctx, saved)
+		if err != nil {
+			return nil, err
+		}
+		return f.Data.Filter, nil
+	default:
+		return &m.TaskFilter{}, nil
+	}
+}
+
+// filterTasks returns the tasks matching a filter in the order it defines.
+// Relative date windows are computed from now (in the time zone day
+// boundaries are based on).
+func filterTasks(ctx context.Context, f *m.TaskFilter, tasks []*m.Task, order manualOrder, now time.Time) ([]*m.Task, error) {
+	if err := validateFilter(ctx, f); err != nil {
+		return nil, err
+	}
+	matching := make([]*m.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if taskMatches(f, task, now) {
+			matching = append(matching, task)
+		}
+	}
+
+	if len(f.GetSort()) > 0 {
+		slices.SortStableFunc(matching, func(a, b *m.Task) int {
+			for _, sort := range f.GetSort() {
+				if c := compareTasks(sort, a, b, order); c != 0 {
+					return c
+				}
+			}
+			return 0
+		})
+	}
+
+	return matching, nil
+}
+
+func taskMatches(f *m.TaskFilter, task *m.Task, now time.Time) bool {
+	data := task.GetData()
+
+	if !f.GetIncludeHidden() && data.GetHiddenUntil() != nil && data.GetHiddenUntil().AsTime().After(now) {
+		return false
+	}
+
+	if len(f.GetStates()) > 0 && !slices.Contains(f.GetStates(), data.GetState()) {
+		return false
+	}
+
+	for _, tag := range f.GetTagsAll() {
+		if !slices.Contains(data.GetTags(), tag) {
+			return false
+		}
+	}
+	if len(f.GetTagsAny()) > 0 && !slices.ContainsFunc(f.GetTagsAny(), func(tag string) bool {
+		return slices.Contains(data.GetTags(), tag)
+	}) {
+		return false
+	}
+	if slices.ContainsFunc(f.GetTagsNone(), func(tag string) bool {
+		return slices.Contains(data.GetTags(), tag)
+	}) {
+		return false
+	}
+
+	if f.GetPriorityMin() != 0 || f.GetPriorityMax() != 0 {
+		p := data.GetPriority()
+		if p == 0 ||
+			(f.GetPriorityMin() != 0 && p < f.GetPriorityMin()) ||
+			(f.GetPriorityMax() != 0 && p > f.GetPriorityMax()) {
+			return false
+		}
+	}
+
+	for _, cond := range f.GetFields() {
+		if !fieldMatches(cond, taskFieldValue(task, cond.GetValue().GetFieldId())) {
+			return false
+		}
+	}
+
+	return inWindow(f.GetDue(), data.GetDue(), now) &&
+		inWindow(f.GetDo(), data.GetDo(), now)
+}
+
+// taskFieldValue returns the value a task holds for a custom field (nil if
+// unset).
+func taskFieldValue(task *m.Task, fieldID *m.UUID) *m.CustomFieldValue {
+	for _, v := range task.GetData().GetFields() {
+		if strings.EqualFold(v.GetFieldId().GetValue(), fieldID.GetValue()) {
+			return v
+		}
+	}
+	return nil
+}
+
+func fieldMatches(cond *m.CustomFieldCondition, v *m.CustomFieldValue) bool {
+	switch cond.GetOp() {
+	case m.FieldOperator_FIELD_IS_SET:
+		return v != nil
+	case m.FieldOperator_FIELD_IS_UNSET:
+		return v == nil
+	case m.FieldOperator_FIELD_NOT_EQUALS:
+		return v == nil || compareFieldValues(v, cond.GetValue()) != 0
+	}
+	if v == nil {
+		return false
+	}
+
+	switch cond.GetOp() {
+	case m.FieldOperator_FIELD_EQUALS:
+		return compareFieldValues(v, cond.GetValue()) == 0
+	case m.FieldOperator_FIELD_LESS_THAN:
+		return compareFieldValues(v, cond.GetValue()) < 0
+	case m.FieldOperator_FIELD_GREATER_THAN:
+		return compareFieldValues(v, cond.GetValue()) > 0
+	case m.FieldOperator_FIELD_CONTAINS:
+		text, ok := v.GetValue().(*m.CustomFieldValue_Text)
+		return ok && strings.Contains(
+			strings.ToLower(text.Text),
+			strings.ToLower(cond.GetValue().GetText()),
+		)
+	}
+	return false
+}
+
+// compareFieldValues orders two values of a custom field. Values of different
+// types (which can only happen with a malformed filter) sort by type.
+func compareFieldValues(a, b *m.CustomFieldValue) int {
+	switch av := a.GetValue().(type) {
+	case *m.CustomFieldValue_Number:
+		if bv, ok := b.GetValue().(*m.CustomFieldValue_Number); ok {
+			return cmp.Compare(av.Number, bv.Number)
+		}
+	case *m.CustomFieldValue_Date:
+		if bv, ok := b.GetValue().(*m.CustomFieldValue_Date); ok {
+			return av.Date.AsTime().Compare(bv.Date.AsTime())
+		}
+	case *m.CustomFieldValue_Text:
+		if bv, ok := b.GetValue().(*m.CustomFieldValue_Text); ok {
+			return cmp.Compare(av.Text, bv.Text)
+		}
+	}
+	return cmp.Compare(fieldValueRank(a), fieldValueRank(b))
+}
+
+func fieldValueRank(v *m.CustomFieldValue) int {
+	switch v.GetValue().(type) {
+	case *m.CustomFieldValue_Text:
+		return 1
+	case *m.CustomFieldValue_Number:
+		return 2
+	case *m.CustomFieldValue_Date:
+		return 3
+	}
+	return 0
+}
+
+// inWindow reports whether a date falls in a date window. Relative bounds are
+// aligned on day boundaries in the location of now.
+func inWindow(w *m.DateWindow, date *m.TaskDate, now time.Time) bool {
+	if w == nil || (w.FromDay == nil && w.ToDay == nil && w.After == nil && w.Before == nil) {
+		return true
+	}
+	if date == nil {
+		return false
+	}
+	t := dateStart(date, now.Location())
+
+	y, mo, d := now.Date()
+	if w.FromDay != nil {
+		from := time.Date(y, mo, d+int(*w.FromDay), 0, 0, 0, 0, now.Location())
+		if t.Before(from) {
+			return false
+		}
+	}
+	if w.ToDay != nil {
+		until := time.Date(y, mo, d+int(*w.ToDay)+1, 0, 0, 0, 0, now.Location())
+		if !t.Before(until) {
+			return false
+		}
+	}
+	if w.After != nil && t.Before(w.After.AsTime()) {
+		return false
+	}
+	if w.Before != nil && !t.Before(w.Before.AsTime()) {
+		return false
+	}
+	return true
+}
+
+func compareTasks(sort *m.TaskSort, a, b *m.Task, order manualOrder) int {
+	ad, bd := a.GetData(), b.GetData()
+	aRank, aInView := order[sort.GetTag()][a.GetId().GetValue()]
+	bRank, bInView := order[sort.GetTag()][b.GetId().GetValue()]
+
+	// Tasks lacking the sorted attribute come last regardless of direction
+	var aUnset, bUnset bool
+	switch sort.GetKey() {
+	case m.TaskSortKey_SORT_DUE_DATE:
+		aUnset, bUnset = ad.GetDueDate() == nil, bd.GetDueDate() == nil
+	case m.TaskSortKey_SORT_DO_DATE:
+		aUnset, bUnset = ad.GetDoDate() == nil, bd.GetDoDate() == nil
+	case m.TaskSortKey_SORT_PRIORITY:
+		aUnset, bUnset = ad.GetPriority() == 0, bd.GetPriority() == 0
+	case m.TaskSortKey_SORT_CUSTOM_FIELD:
+		aUnset = taskFieldValue(a, sort.GetFieldId()) == nil
+		bUnset = taskFieldValue(b, sort.GetFieldId()) == nil
+	case m.TaskSortKey_SORT_MANUAL:
+		aUnset, bUnset = !aInView, !bInView
+	}
+	switch {
+	case aUnset && bUnset:
+		return 0
+	case aUnset:
+		return 1
+	case bUnset:
+		return -1
+	}
+
+	var c int
+	switch sort.GetKey() {
+	case m.TaskSortKey_SORT_CREATED_ON:
+		c = a.GetMetadata().GetCreatedOn().AsTime().Compare(b.GetMetadata().GetCreatedOn().AsTime())
+	case m.TaskSortKey_SORT_UPDATED_ON:
+		c = a.GetMetadata().GetUpdatedOn().AsTime().Compare(b.GetMetadata().GetUpdatedOn().AsTime())
+	case m.TaskSortKey_SORT_DUE_DATE:
+		c = ad.GetDueDate().AsTime().Compare(bd.GetDueDate().AsTime())
+	case m.TaskSortKey_SORT_DO_DATE:
+		c = ad.GetDoDate().AsTime().Compare(bd.GetDoDate().AsTime())
+	case m.TaskSortKey_SORT_PRIORITY:
+		c = cmp.Compare(ad.GetPriority(), bd.GetPriority())
+	case m.TaskSortKey_SORT_TITLE:
+		c = cmp.Compare(strings.ToLower(ad.GetTitle()), strings.ToLower(bd.GetTitle()))
+	case m.TaskSortKey_SORT_STATE:
+		c = cmp.Compare(ad.GetState(), bd.GetState())
+	case m.TaskSortKey_SORT_CUSTOM_FIELD:
+		c = compareFieldValues(
+			taskFieldValue(a, sort.GetFieldId()),
+			taskFieldValue(b, sort.GetFieldId()),
+		)
+	case m.TaskSortKey_SORT_MANUAL:
+		c = cmp.Compare(aRank, bRank)
+	case m.TaskSortKey_SORT_URGENCY:
+		c = cmp.Compare(b.GetUrgency(), a.GetUrgency())
+	}
+	if sort.GetDescending() {
+		return -c
+	}
+	return c
+}
@@ -0,0 +1,103 @@
+package pb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/oidc"
+	"github.com/ChausseBenjamin/rafta/internal/sec"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// oidcUser finds the user an identity of the provider belongs to. Identities
+// seen for the first time get linked to the user with the same email, or to a
+// new user when the server provisions accounts.
+func (s *protoServer) oidcUser(ctx context.Context, identity oidc.Identity) (uuid.UUID, error) {
+	log := slog.With("issuer", identity.Issuer, "subject", identity.Subject)
+
+	linked, err := s.db.GetOIDCIdentity(ctx, database.GetOIDCIdentityParams{
+		Issuer:  identity.Issuer,
+		Subject: identity.Subject,
+	})
+	if err == nil {
+		err := s.db.TouchOIDCIdentity(ctx, database.TouchOIDCIdentityParams{
+			Issuer:  identity.Issuer,
+			Subject: identity.Subject,
+		})
+		if err != nil {
+			log.ErrorContext(ctx, "failed to record OIDC identity use", logging.ErrKey, err)
+		}
+		return linked.UserID, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		log.ErrorContext(ctx, "failed to retrieve OIDC identity", logging.ErrKey, err)
+		return uuid.Nil, status.Error(codes.Internal, "failed to retrieve user")
+	}
+
+	var userID uuid.UUID
+	user, err := s.db.GetUserFromEmail(ctx, identity.Email)
+	switch {
+	case err == nil:
+		userID = user.UserID
+	case !errors.Is(err, sql.ErrNoRows):
+		log.ErrorContext(ctx, "failed to retrieve user from email", logging.ErrKey, err)
+		return uuid.Nil, status.Error(codes.Internal, "failed to retrieve user")
+	case !s.oidc.AutoProvision():
+		log.WarnContext(ctx, "no user matches OIDC identity", "email", identity.Email)
+		return uuid.Nil, status.Errorf(codes.PermissionDenied,
+			"no account matches '%s', ask an admin to create one", identity.Email,
+		)
+	default:
+		if userID, err = s.provisionUser(ctx, identity); err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	err = s.db.NewOIDCIdentity(ctx, database.NewOIDCIdentityParams{
+		Issuer:  identity.Issuer,
+		Subject: identity.Subject,
+		UserID:  userID,
+	})
+	if err != nil {
+		log.ErrorContext(ctx, "failed to link OIDC identity", logging.ErrKey, err)
+		return uuid.Nil, status.Error(codes.Internal, "failed to link account")
+	}
+	log.InfoContext(ctx, "Linked OIDC identity", "user_id", userID)
+	return userID, nil
+}
+
+// provisionUser creates the account of an identity. Its password is random
+// (and never shown) so that it can only log in through the provider until it
+// changes it.
+func (s *protoServer) provisionUser(ctx context.Context, identity oidc.Identity) (uuid.UUID, error) {
+	if err := s.checkUserCapacity(ctx); err != nil {
+		return uuid.Nil, err
+	}
+
+	passwd, _, _, err := sec.GenPassword()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to generate password", logging.ErrKey, err)
+		return uuid.Nil, status.Error(codes.Internal, "Failed to create new user")
+	}
+
+	name := strings.TrimSpace(identity.Name)
+	if name == "" {
+		name = identity.Email[:strings.LastIndex(identity.Email, "@")]
+	}
+	user, err := s.newUser(ctx, &m.UserSignupRequest{
+		User:       &m.UserData{Name: name, Email: identity.Email},
+		UserSecret: passwd,
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return uuid.Parse(user.GetId().GetValue())
+}
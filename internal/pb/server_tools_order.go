@@ -0,0 +1,199 @@
+package pb
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/fracindex"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Sort keys grow a little every time a task gets squeezed between two others.
+// Past this length, every key of the list gets rewritten evenly spaced.
+const maxSortKeyLength = 24
+
+// manualOrder maps the tag of a view (empty for the main list) to the rank of
+// every task within it.
+type manualOrder map[string]map[string]int
+
+// scopeTasks lists the tasks of a view in their manual order. Tasks never
+// moved come last by creation date and have no sort key.
+func (s *protoServer) scopeTasks(
+	ctx context.Context,
+	db *database.Queries,
+	owner uuid.UUID,
+	tag string,
+) ([]database.GetScopeTasksRow, error) {
+	tasks, err := db.GetScopeTasks(ctx, database.GetScopeTasksParams{
+		Scope: tag,
+		Owner: owner,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to retrieve task order",
+			"tag", tag,
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to retrieve task order")
+	}
+	return tasks, nil
+}
+
+// loadManualOrder retrieves the order of every view a filter sorts by.
+func (s *protoServer) loadManualOrder(ctx context.Context, owner uuid.UUID, f *m.TaskFilter) (manualOrder, error) {
+	order := manualOrder{}
+	for _, sort := range f.GetSort() {
+		if sort.GetKey() != m.TaskSortKey_SORT_MANUAL {
+			continue
+		}
+		if _, ok := order[sort.GetTag()]; ok {
+			continue
+		}
+		tasks, err := s.scopeTasks(ctx, s.db.Queries, owner, sort.GetTag())
+		if err != nil {
+			return nil, err
+		}
+		ranks := make(map[string]int, len(tasks))
+		for i, task := range tasks {
+			ranks[task.TaskID.String()] = i
+		}
+		order[sort.GetTag()] = ranks
+	}
+	return order, nil
+}
+
+// placeTask gives a task the sort key found between its new neighbours. When
+// a neighbour was never ordered or when keys got too long, the whole view gets
+// new keys instead.
+func (s *protoServer) placeTask(
+	ctx context.Context,
+	db *database.Queries,
+	tag string,
+	tasks []database.GetScopeTasksRow, // view without the moved task
+	taskID uuid.UUID,
+	index int, // position of the moved task within the view
+) error {
+	var prev, next string
+	rebalance := false
+	if index > 0 {
+		prev = tasks[index-1].SortKey.String
+		rebalance = !tasks[index-1].SortKey.Valid
+	}
+	if index < len(tasks) {
+		next = tasks[index].SortKey.String
+		rebalance = rebalance || !tasks[index].SortKey.Valid
+	}
+
+	if !rebalance {
+		between := fracindex.Between
+		if index == len(tasks) {
+			// Moved (or added) to the end of the view
+			between = func(prev, _ string) (string, error) { return fracindex.After(prev) }
+		}
+		key, err := between(prev, next)
+		if err == nil && len(key) <= maxSortKeyLength {
+			return s.setTaskPosition(ctx, db, tag, taskID, key)
+		}
+		if err != nil {
+			slog.WarnContext(ctx, "inconsistent sort keys",
+				"tag", tag,
+				logging.ErrKey, err,
+			)
+		}
+	}
+
+	slog.InfoContext(ctx, "rebalancing task order",
+		"tag", tag,
+		"tasks", len(tasks)+1,
+	)
+	ids := make([]uuid.UUID, 0, len(tasks)+1)
+	for _, task := range tasks[:index] {
+		ids = append(ids, task.TaskID)
+	}
+	ids = append(ids, taskID)
+	for _, task := range tasks[index:] {
+		ids = append(ids, task.TaskID)
+	}
+	for i, key := range fracindex.Spread(len(ids)) {
+		if err := s.setTaskPosition(ctx, db, tag, ids[i], key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendTask gives a task a sort key placing it at the end of the given views
+// (the empty tag being the main list), so that moving it later only updates
+// its own key. Only the last key of a view is needed, unless some of its tasks
+// were never ordered and the whole view has to be rebalanced.
+func (s *protoServer) appendTask(
+	ctx context.Context,
+	db *database.Queries,
+	owner uuid.UUID,
+	taskID uuid.UUID,
+	tags []string,
+) error {
+	for _, tag := range tags {
+		end, err := db.GetScopeEnd(ctx, database.GetScopeEndParams{
+			Scope:  tag,
+			Owner:  owner,
+			TaskID: taskID,
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to retrieve the end of the task order",
+				"tag", tag,
+				logging.ErrKey, err,
+			)
+			return status.Error(codes.Internal, "failed to retrieve task order")
+		}
+		if end.Unordered == 0 {
+			key, err := fracindex.After(end.LastKey)
+			if err == nil && len(key) <= maxSortKeyLength {
+				if err := s.setTaskPosition(ctx, db, tag, taskID, key); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		tasks, err := s.scopeTasks(ctx, db, owner, tag)
+		if err != nil {
+			return err
+		}
+		tasks = slices.DeleteFunc(tasks, func(t database.GetScopeTasksRow) bool {
+			return t.TaskID == taskID
+		})
+		if err := s.placeTask(ctx, db, tag, tasks, taskID, len(tasks)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *protoServer) setTaskPosition(
+	ctx context.Context,
+	db *database.Queries,
+	tag string,
+	taskID uuid.UUID,
+	key string,
+) error {
+	err := db.SetTaskPosition(ctx, database.SetTaskPositionParams{
+		TaskID:  taskID,
+		Scope:   tag,
+		SortKey: key,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to update task position",
+			"task_id", taskID,
+			"tag", tag,
+			logging.ErrKey, err,
+		)
+		return status.Errorf(codes.Internal, "failed to update position of task '%v'", taskID)
+	}
+	return nil
+}
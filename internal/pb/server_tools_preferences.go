@@ -0,0 +1,144 @@
+package pb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// getPreferences retrieves the preferences of a user as they were stored
+// (without defaults). Users who never changed their preferences get an empty
+// set.
+func (s *protoServer) getPreferences(ctx context.Context, db *database.Queries, userID uuid.UUID) (*m.Preferences, error) {
+	prefs := &m.Preferences{}
+	row, err := db.GetUserPreferences(ctx, userID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		slog.ErrorContext(ctx, "failed to retrieve user preferences",
+			"user_id", userID,
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to retrieve preferences")
+	default:
+		if prefs, err = preferencesToPb(ctx, row); err != nil {
+			return nil, err
+		}
+	}
+
+	settings, err := db.GetUserSettings(ctx, database.GetUserSettingsParams{
+		UserID:    userID,
+		Namespace: settingsNamespace,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to retrieve well-known settings",
+			"user_id", userID,
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to retrieve preferences")
+	}
+	for _, setting := range settings {
+		switch setting.Key {
+		case settingTimeZone:
+			prefs.TimeZone = setting.Value
+		case settingLocale:
+			prefs.Locale = setting.Value
+		case settingDigestTime:
+			prefs.DigestTime = setting.Value
+		}
+	}
+	return prefs, nil
+}
+
+// preferencesToPb decodes the preferences stored outside of settings.
+func preferencesToPb(ctx context.Context, row database.UserPreference) (*m.Preferences, error) {
+	prefs := &m.Preferences{}
+	if row.Urgency != nil {
+		prefs.Urgency = &m.UrgencyCoefficients{}
+		if err := proto.Unmarshal(row.Urgency, prefs.Urgency); err != nil {
+			slog.ErrorContext(ctx, "failed to decode urgency coefficients",
+				"user_id", row.UserID,
+				logging.ErrKey, err,
+			)
+			return nil, status.Error(codes.Internal, "failed to decode preferences")
+		}
+	}
+	return prefs, nil
+}
+
+// withDefaults fills in the preferences a user didn't set.
+func withDefaults(prefs *m.Preferences) *m.Preferences {
+	tz := prefs.GetTimeZone()
+	if tz == "" {
+		tz = "UTC"
+	}
+	locale := prefs.GetLocale()
+	if locale == "" {
+		locale = "en"
+	}
+	return &m.Preferences{
+		Urgency:    effectiveUrgency(prefs.GetUrgency()),
+		TimeZone:   tz,
+		Locale:     locale,
+		DigestTime: prefs.GetDigestTime(),
+	}
+}
+
+// loadLocation resolves the IANA time zone sent by a client ("" being UTC).
+func loadLocation(ctx context.Context, name string) (*time.Location, error) {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		slog.WarnContext(ctx, "received invalid time zone",
+			"time_zone", name,
+			logging.ErrKey, err,
+		)
+		return nil, status.Errorf(codes.InvalidArgument,
+			"unknown time zone '%v'", name,
+		)
+	}
+	return loc, nil
+}
+
+// userLocation returns the time zone a request of the user gets interpreted
+// in: the one it specifies or, by default, the one from the user preferences.
+func (s *protoServer) userLocation(
+	ctx context.Context,
+	db *database.Queries,
+	userID uuid.UUID,
+	requested string,
+) (*time.Location, error) {
+	if requested != "" {
+		return loadLocation(ctx, requested)
+	}
+	prefs, err := s.getPreferences(ctx, db, userID)
+	if err != nil {
+		return nil, err
+	}
+	return preferredLocation(ctx, userID, prefs), nil
+}
+
+// preferredLocation returns the time zone from the preferences of a user
+// (UTC by default).
+func preferredLocation(ctx context.Context, userID uuid.UUID, prefs *m.Preferences) *time.Location {
+	loc, err := time.LoadLocation(prefs.GetTimeZone())
+	if err != nil {
+		// Only valid time zones get stored, the tz database must have changed
+		slog.ErrorContext(ctx, "stored time zone is no longer valid",
+			"user_id", userID,
+			"time_zone", prefs.GetTimeZone(),
+			logging.ErrKey, err,
+		)
+		return time.UTC
+	}
+	return loc
+}
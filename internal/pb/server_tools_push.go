@@ -0,0 +1,312 @@
+package pb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/push"
+	"github.com/ChausseBenjamin/rafta/internal/util"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Deliveries in a row that can fail before an endpoint gets disabled
+	pushMaxFailures = 5
+	// How often task dates are checked for notifications to send
+	pushPollInterval = time.Minute
+	// Time of day all-day due dates get notified at
+	pushAllDayHour = 9
+)
+
+func pushEndpointToPb(e database.PushEndpoint) *m.PushEndpoint {
+	var events []m.PushEvent
+	for event := range m.PushEvent_name {
+		if e.Events&(1<<event) != 0 {
+			events = append(events, m.PushEvent(event))
+		}
+	}
+	slices.Sort(events)
+
+	return &m.PushEndpoint{
+		Id: &m.UUID{Value: e.EndpointID.String()},
+		Data: &m.PushEndpointData{
+			Name:     e.Name,
+			Kind:     m.PushEndpointKind(e.Kind),
+			Url:      e.Url,
+			Events:   events,
+			Disabled: !e.Enabled,
+		},
+		Status: &m.PushEndpointStatus{
+			Failures:     uint32(e.Failures),
+			LastError:    e.LastError.String,
+			LastDelivery: pbTime(e.LastDelivery),
+			HasToken:     e.Token.Valid,
+		},
+	}
+}
+
+// pushEventMask encodes the events notified to an endpoint (0 for all).
+func pushEventMask(events []m.PushEvent) int64 {
+	var mask int64
+	for _, event := range events {
+		mask |= 1 << event
+	}
+	return mask
+}
+
+// validatePushEndpoint checks an endpoint sent by a client and returns its
+// trimmed name.
+func validatePushEndpoint(ctx context.Context, data *m.PushEndpointData) (string, error) {
+	name := strings.TrimSpace(data.GetName())
+	var err error
+	switch {
+	case name == "":
+		err = errors.New("push endpoints must have a name")
+	case m.PushEndpointKind_name[int32(data.GetKind())] == "":
+		err = fmt.Errorf("unknown push endpoint kind %d", data.GetKind())
+	default:
+		err = push.ValidateURL(data.GetUrl())
+	}
+	if err == nil {
+		for _, event := range data.GetEvents() {
+			if m.PushEvent_name[int32(event)] == "" {
+				err = fmt.Errorf("unknown push event %d", event)
+				break
+			}
+		}
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "received invalid push endpoint", logging.ErrKey, err)
+		return "", status.Error(codes.InvalidArgument, err.Error())
+	}
+	return name, nil
+}
+
+func (s *protoServer) getUserPushEndpoint(
+	ctx context.Context,
+	db *database.Queries,
+	userID uuid.UUID,
+	id *m.UUID,
+) (database.PushEndpoint, error) {
+	endpointID, err := util.ParseUUID(ctx, util.ParseUUIDParams{
+		Str: id.GetValue(), Subject: "endpoint_id",
+		Implication: codes.InvalidArgument, Critical: false,
+	})
+	if err != nil {
+		return database.PushEndpoint{}, err
+	}
+
+	endpoint, err := db.GetUserPushEndpoint(ctx, database.GetUserPushEndpointParams{
+		EndpointID: endpointID,
+		UserID:     userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.WarnContext(ctx, "push endpoint not found", "endpoint_id", endpointID)
+			return endpoint, status.Errorf(codes.NotFound, "push endpoint '%v' not found", endpointID)
+		}
+		slog.ErrorContext(ctx, "failed to retrieve push endpoint",
+			"endpoint_id", endpointID,
+			logging.ErrKey, err,
+		)
+		return endpoint, status.Error(codes.Internal, "failed to retrieve push endpoint")
+	}
+	return endpoint, nil
+}
+
+func pushTarget(e database.PushEndpoint) push.Endpoint {
+	return push.Endpoint{
+		ID:    e.EndpointID,
+		Kind:  push.Kind(e.Kind),
+		URL:   e.Url,
+		Token: e.Token.String,
+	}
+}
+
+// notify sends a notification to every enabled endpoint of a user interested
+// in the event. Like tag cleanup, failing to notify doesn't affect requests
+// so errors are only logged.
+func (s *protoServer) notify(ctx context.Context, userID uuid.UUID, event m.PushEvent, n push.Notification) {
+	endpoints, err := s.db.GetEnabledPushEndpoints(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to retrieve push endpoints",
+			"user_id", userID,
+			logging.ErrKey, err,
+		)
+		return
+	}
+	for _, e := range endpoints {
+		if e.Events == 0 || e.Events&(1<<event) != 0 {
+			s.push.Dispatch(ctx, pushTarget(e), n)
+		}
+	}
+}
+
+// reportPush records the outcome of a delivery, disabling endpoints that keep
+// failing or don't exist anymore.
+func (s *protoServer) reportPush(e push.Endpoint, err error) {
+	// Deliveries outlive the requests that triggered them
+	ctx := context.Background()
+	log := slog.With("endpoint_id", e.ID)
+
+	if err == nil {
+		if err := s.db.RecordPushSuccess(ctx, e.ID); err != nil {
+			log.ErrorContext(ctx, "failed to record push delivery", logging.ErrKey, err)
+		}
+		return
+	}
+
+	row, dbErr := s.db.RecordPushFailure(ctx, database.RecordPushFailureParams{
+		Error:       sql.NullString{String: err.Error(), Valid: true},
+		Disable:     errors.Is(err, push.ErrGone),
+		MaxFailures: pushMaxFailures,
+		EndpointID:  e.ID,
+	})
+	if errors.Is(dbErr, sql.ErrNoRows) {
+		// Deleted while the delivery was in flight
+		return
+	}
+	if dbErr != nil {
+		log.ErrorContext(ctx, "failed to record push failure", logging.ErrKey, dbErr)
+		return
+	}
+	if !row.Enabled {
+		log.WarnContext(ctx, "Disabled failing push endpoint",
+			"failures", row.Failures,
+			logging.ErrKey, err,
+		)
+	}
+}
+
+// notifyTaskChange lets the users sharing a task know someone else changed
+// it. Tasks can only be shared through assignments for now.
+func (s *protoServer) notifyTaskChange(ctx context.Context, actor, taskID uuid.UUID, reassigned bool) {
+	task, err := s.db.GetTask(ctx, taskID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to retrieve changed task",
+			"task_id", taskID,
+			logging.ErrKey, err,
+		)
+		return
+	}
+	if !task.Assignee.Valid || task.Assignee.UUID == actor {
+		return
+	}
+
+	name := "Someone"
+	if user, err := s.db.GetUser(ctx, actor); err == nil {
+		name = user.Name
+	}
+	message := fmt.Sprintf("%s updated \"%s\"", name, task.Title)
+	if reassigned {
+		message = fmt.Sprintf("%s assigned you \"%s\"", name, task.Title)
+	}
+	s.notify(ctx, task.Assignee.UUID, m.PushEvent_PUSH_SHARED_CHANGE, push.Notification{
+		Event:    "shared_change",
+		Title:    task.Title,
+		Message:  message,
+		TaskID:   taskID,
+		Priority: 3,
+		Tags:     []string{"busts_in_silhouette"},
+	})
+}
+
+// pushDates notifies users as the do and due dates of their tasks arrive.
+// Dates are checked periodically and those that arrived since the previous
+// check get notified, so dates that arrive while the server is down aren't.
+func (s *protoServer) pushDates(ctx context.Context) {
+	slog.DebugContext(ctx, "Watching task dates for push notifications")
+	since := time.Now()
+	ticker := time.NewTicker(pushPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.pushDatesBetween(ctx, since, now)
+			since = now
+		}
+	}
+}
+
+// pushDatesBetween notifies the task dates arriving within (since, until].
+func (s *protoServer) pushDatesBetween(ctx context.Context, since, until time.Time) {
+	tasks, err := s.db.GetPushableTasks(ctx, sql.NullTime{Time: until.UTC(), Valid: true})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to retrieve tasks to notify", logging.ErrKey, err)
+		return
+	}
+
+	arrived := func(t time.Time) bool {
+		return t.After(since) && !t.After(until)
+	}
+	locations := make(map[uuid.UUID]*time.Location)
+	for _, task := range tasks {
+		recipients := []uuid.UUID{task.Owner}
+		if task.Assignee.Valid && task.Assignee.UUID != task.Owner {
+			recipients = append(recipients, task.Assignee.UUID)
+		}
+
+		for _, userID := range recipients {
+			loc, ok := locations[userID]
+			if !ok {
+				if loc, err = s.userLocation(ctx, s.db.Queries, userID, ""); err != nil {
+					loc = time.UTC
+				}
+				locations[userID] = loc
+			}
+
+			if do := storedTaskDate(task.DoDate); do != nil && arrived(dateStart(do, loc)) {
+				s.notify(ctx, userID, m.PushEvent_PUSH_REMINDER, push.Notification{
+					Event:    "reminder",
+					Title:    task.Title,
+					Message:  "Time to get started",
+					TaskID:   task.TaskID,
+					Priority: 3,
+					Tags:     []string{"alarm_clock"},
+				})
+			}
+
+			if due := storedTaskDate(task.DueDate); due != nil {
+				at := dateStart(due, loc)
+				message := "Due now"
+				if due.GetTime() == nil {
+					y, mo, d := at.Date()
+					at = time.Date(y, mo, d, pushAllDayHour, 0, 0, 0, loc)
+					message = "Due today"
+				}
+				if arrived(at) {
+					s.notify(ctx, userID, m.PushEvent_PUSH_DUE, push.Notification{
+						Event:    "due",
+						Title:    task.Title,
+						Message:  message,
+						TaskID:   task.TaskID,
+						Priority: 4,
+						Tags:     []string{"hourglass"},
+					})
+				}
+			}
+		}
+	}
+}
+
+// pushTestNotification is what TestPushEndpoint sends.
+var pushTestNotification = push.Notification{
+	Event:    "test",
+	Title:    "Rafta",
+	Message:  "Push notifications are working",
+	Priority: 2,
+	Tags:     []string{"white_check_mark"},
+}
@@ -0,0 +1,101 @@
+package pb
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/push"
+	"github.com/ChausseBenjamin/rafta/internal/util"
+)
+
+// testServer returns a server backed by a fresh database.
+func testServer(t *testing.T) *protoServer {
+	t.Helper()
+	ctx := context.Background()
+	db, err := database.Setup(ctx, filepath.Join(t.TempDir(), "store.db"), &util.ConfigStore{DBCacheSize: -2000})
+	if err != nil {
+		t.Fatalf("failed to set up database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	queries, err := database.Prepare(ctx, db)
+	if err != nil {
+		t.Fatalf("failed to prepare queries: %v", err)
+	}
+	return &protoServer{
+		cfg: &util.ConfigStore{},
+		db:  &protoDB{DB: db, Queries: queries},
+	}
+}
+
+// testEndpoint creates a push endpoint, which has to belong to a user.
+func testEndpoint(t *testing.T, s *protoServer) database.PushEndpoint {
+	t.Helper()
+	ctx := context.Background()
+	user, err := s.db.NewUser(ctx, database.NewUserParams{Name: "Jane", Email: "jane@example.com"})
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	e, err := s.db.NewPushEndpoint(ctx, database.NewPushEndpointParams{
+		UserID: user.UserID,
+		Name:   "phone",
+		Kind:   int64(push.Ntfy),
+		Url:    "https://ntfy.example.com/tasks",
+	})
+	if err != nil {
+		t.Fatalf("failed to create push endpoint: %v", err)
+	}
+	return e
+}
+
+func enabled(t *testing.T, s *protoServer, e database.PushEndpoint) bool {
+	t.Helper()
+	e, err := s.db.GetUserPushEndpoint(context.Background(), database.GetUserPushEndpointParams{
+		EndpointID: e.EndpointID,
+		UserID:     e.UserID,
+	})
+	if err != nil {
+		t.Fatalf("failed to retrieve push endpoint: %v", err)
+	}
+	return e.Enabled
+}
+
+func TestReportPushDisablesFailingEndpoints(t *testing.T) {
+	s := testServer(t)
+	e := testEndpoint(t, s)
+	target := pushTarget(e)
+
+	for range pushMaxFailures - 1 {
+		s.reportPush(target, errors.New("push endpoint responded with 500 Internal Server Error"))
+	}
+	if !enabled(t, s, e) {
+		t.Fatalf("endpoint disabled before %d failures", pushMaxFailures)
+	}
+
+	// A delivery resets the count
+	s.reportPush(target, nil)
+	s.reportPush(target, errors.New("push endpoint responded with 502 Bad Gateway"))
+	if !enabled(t, s, e) {
+		t.Fatal("endpoint disabled although a delivery went through")
+	}
+
+	for range pushMaxFailures - 1 {
+		s.reportPush(target, errors.New("push endpoint responded with 503 Service Unavailable"))
+	}
+	if enabled(t, s, e) {
+		t.Fatalf("endpoint still enabled after %d failures", pushMaxFailures)
+	}
+}
+
+func TestReportPushDisablesGoneEndpoints(t *testing.T) {
+	s := testServer(t)
+	e := testEndpoint(t, s)
+	target := pushTarget(e)
+
+	s.reportPush(target, push.ErrGone)
+	if enabled(t, s, e) {
+		t.Fatal("endpoint still enabled after being gone")
+	}
+}
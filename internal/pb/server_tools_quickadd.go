@@ -0,0 +1,69 @@
+package pb
+
+import (
+	"context"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/caldate"
+	"github.com/ChausseBenjamin/rafta/internal/quickadd"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// parseQuickAdd interprets a quick add request in the time zone of the user
+// and converts the outcome to its protobuf representation.
+func (s *protoServer) parseQuickAdd(ctx context.Context, userID uuid.UUID, req *m.QuickAddRequest) (*m.QuickAddResponse, error) {
+	loc, err := s.userLocation(ctx, s.db.Queries, userID, req.GetTimeZone())
+	if err != nil {
+		return nil, err
+	}
+
+	res := quickadd.Parse(req.GetText(), time.Now().In(loc))
+
+	data := &m.TaskData{
+		Title:    res.Title,
+		Priority: res.Priority,
+		State:    m.TaskState_PENDING,
+		Tags:     res.Tags,
+	}
+	if res.DoDate != nil {
+		data.Do = quickAddDate(*res.DoDate, res.DoAllDay)
+		data.DoDate = timestamppb.New(dateStart(data.Do, loc))
+	}
+	if res.DueDate != nil {
+		data.Due = quickAddDate(*res.DueDate, res.DueAllDay)
+		data.DueDate = timestamppb.New(dateStart(data.Due, loc))
+	}
+	if res.Recurrence != "" {
+		data.Recurrence = &m.TaskRecurrence{
+			Pattern: res.Recurrence,
+			Active:  true,
+		}
+	}
+
+	matches := make([]*m.QuickAddMatch, len(res.Matches))
+	for i, match := range res.Matches {
+		matches[i] = &m.QuickAddMatch{
+			Start: uint32(match.Start),
+			End:   uint32(match.End),
+			Kind:  m.QuickAddMatchKind(match.Kind),
+		}
+	}
+
+	return &m.QuickAddResponse{
+		Parsed:  data,
+		Matches: matches,
+	}, nil
+}
+
+// quickAddDate converts a date understood by quick add. Dates without a time
+// of day are all-day dates, the others are pinned to the time zone the text
+// was interpreted in.
+func quickAddDate(t time.Time, allDay bool) *m.TaskDate {
+	date := caldate.At(t)
+	if allDay {
+		date = date.WholeDay()
+	}
+	return taskDateToPb(date)
+}
@@ -0,0 +1,20 @@
+package pb
+
+import (
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func sessionToPb(session database.Session, current uuid.UUID) *m.Session {
+	return &m.Session{
+		Id:        &m.UUID{Value: session.SessionID.String()},
+		Device:    session.Device,
+		Ip:        session.Ip,
+		CreatedOn: timestamppb.New(session.CreatedOn.UTC()),
+		LastUsed:  timestamppb.New(session.LastUsed.UTC()),
+		ExpiresOn: timestamppb.New(session.ExpiresOn.UTC()),
+		Current:   session.SessionID == current,
+	}
+}
@@ -0,0 +1,241 @@
+package pb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"github.com/google/uuid"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// Namespace of the well-known settings the server honors
+	settingsNamespace = "rafta"
+	settingTimeZone   = "time_zone"
+	settingLocale     = "locale"
+	settingDigestTime = "digest_time"
+
+	maxSettingNamespaceLen = 64
+	maxSettingKeyLen       = 128
+	maxSettingValueLen     = 16 << 10
+	maxSettingsCount       = 512
+	maxSettingsSize        = 256 << 10
+
+	// Number of changes a watcher can lag behind before getting aborted
+	settingsWatchBuffer = 64
+)
+
+var settingNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+func settingToPb(row database.UserSetting) *m.Setting {
+	return &m.Setting{
+		Namespace: row.Namespace,
+		Key:       row.Key,
+		Value:     row.Value,
+		UpdatedOn: timestamppb.New(row.UpdatedOn.UTC()),
+	}
+}
+
+func validateSettingName(subject, name string, maxLen int) error {
+	if !settingNamePattern.MatchString(name) {
+		return fmt.Errorf("setting %s '%s' must only contain letters, digits, '.', '_' and '-'", subject, name)
+	}
+	if len(name) > maxLen {
+		return fmt.Errorf("setting %s '%s' is longer than %d bytes", subject, name, maxLen)
+	}
+	return nil
+}
+
+// validateSetting checks a setting about to be written. Well-known settings
+// get their value normalized.
+func validateSetting(ctx context.Context, setting *m.Setting) (*m.Setting, error) {
+	err := validateSettingName("namespace", setting.GetNamespace(), maxSettingNamespaceLen)
+	if err == nil {
+		err = validateSettingName("key", setting.GetKey(), maxSettingKeyLen)
+	}
+	if err == nil && len(setting.GetValue()) > maxSettingValueLen {
+		err = fmt.Errorf("setting %s/%s is larger than %d bytes",
+			setting.GetNamespace(), setting.GetKey(), maxSettingValueLen,
+		)
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "received invalid setting", logging.ErrKey, err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	value := setting.GetValue()
+	if setting.GetNamespace() == settingsNamespace && value != "" {
+		switch setting.GetKey() {
+		case settingTimeZone:
+			if _, err := loadLocation(ctx, value); err != nil {
+				return nil, err
+			}
+		case settingLocale:
+			tag, err := language.Parse(value)
+			if err != nil {
+				slog.WarnContext(ctx, "received invalid locale",
+					"locale", value,
+					logging.ErrKey, err,
+				)
+				return nil, status.Errorf(codes.InvalidArgument,
+					"invalid locale '%s': %v", value, err,
+				)
+			}
+			value = tag.String()
+		case settingDigestTime:
+			t, err := time.Parse(digestTimeLayout, value)
+			if err != nil {
+				slog.WarnContext(ctx, "received invalid digest time",
+					"digest_time", value,
+					logging.ErrKey, err,
+				)
+				return nil, status.Errorf(codes.InvalidArgument,
+					"digest times are formatted as HH:MM (got '%s')", value,
+				)
+			}
+			value = t.Format(digestTimeLayout)
+		default:
+			slog.WarnContext(ctx, "received unknown well-known setting", "key", setting.GetKey())
+			return nil, status.Errorf(codes.InvalidArgument,
+				"the '%s' namespace is reserved and has no '%s' setting",
+				settingsNamespace, setting.GetKey(),
+			)
+		}
+	}
+
+	return &m.Setting{
+		Namespace: setting.GetNamespace(),
+		Key:       setting.GetKey(),
+		Value:     value,
+	}, nil
+}
+
+// setSettings writes settings on behalf of a user (deleting the empty ones)
+// and returns them as stored. Changes must be published once committed.
+func setSettings(
+	ctx context.Context,
+	db *database.Queries,
+	userID uuid.UUID,
+	settings []*m.Setting,
+) ([]*m.Setting, error) {
+	stored := make([]*m.Setting, len(settings))
+	for i, setting := range settings {
+		setting, err := validateSetting(ctx, setting)
+		if err != nil {
+			return nil, err
+		}
+
+		if setting.Value == "" {
+			err := db.DeleteUserSetting(ctx, database.DeleteUserSettingParams{
+				UserID:    userID,
+				Namespace: setting.Namespace,
+				Key:       setting.Key,
+			})
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to delete setting", logging.ErrKey, err)
+				return nil, status.Error(codes.Internal, "failed to delete setting")
+			}
+			setting.UpdatedOn = timestamppb.Now()
+			stored[i] = setting
+			continue
+		}
+
+		row, err := db.SetUserSetting(ctx, database.SetUserSettingParams{
+			UserID:    userID,
+			Namespace: setting.Namespace,
+			Key:       setting.Key,
+			Value:     setting.Value,
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to store setting", logging.ErrKey, err)
+			return nil, status.Error(codes.Internal, "failed to store setting")
+		}
+		stored[i] = settingToPb(row)
+	}
+
+	usage, err := db.GetUserSettingsUsage(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to measure settings usage", logging.ErrKey, err)
+		return nil, status.Error(codes.Internal, "failed to store settings")
+	}
+	if usage.Count > maxSettingsCount || usage.Size > maxSettingsSize {
+		slog.WarnContext(ctx, "settings quota exceeded",
+			"count", usage.Count,
+			"size", usage.Size,
+		)
+		return nil, status.Errorf(codes.ResourceExhausted,
+			"users hold at most %d settings totaling %d bytes", maxSettingsCount, maxSettingsSize,
+		)
+	}
+	return stored, nil
+}
+
+// settingsHub forwards setting changes to the clients watching them.
+type settingsHub struct {
+	mu       sync.Mutex
+	watchers map[uuid.UUID]map[*settingsWatcher]struct{}
+}
+
+type settingsWatcher struct {
+	namespace string // All of them if empty
+	// Closed when the watcher fell too far behind
+	changes chan *m.Setting
+}
+
+func newSettingsHub() *settingsHub {
+	return &settingsHub{watchers: make(map[uuid.UUID]map[*settingsWatcher]struct{})}
+}
+
+func (h *settingsHub) watch(userID uuid.UUID, namespace string) *settingsWatcher {
+	w := &settingsWatcher{
+		namespace: namespace,
+		changes:   make(chan *m.Setting, settingsWatchBuffer),
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.watchers[userID] == nil {
+		h.watchers[userID] = make(map[*settingsWatcher]struct{})
+	}
+	h.watchers[userID][w] = struct{}{}
+	return w
+}
+
+func (h *settingsHub) unwatch(userID uuid.UUID, w *settingsWatcher) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.watchers[userID], w)
+	if len(h.watchers[userID]) == 0 {
+		delete(h.watchers, userID)
+	}
+}
+
+// publish never blocks: watchers that can't keep up are dropped.
+func (h *settingsHub) publish(userID uuid.UUID, settings ...*m.Setting) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+watchers:
+	for w := range h.watchers[userID] {
+		for _, setting := range settings {
+			if w.namespace != "" && w.namespace != setting.GetNamespace() {
+				continue
+			}
+			select {
+			case w.changes <- setting:
+			default:
+				close(w.changes)
+				delete(h.watchers[userID], w)
+				continue watchers
+			}
+		}
+	}
+}
@@ -2,11 +2,16 @@ package pb
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"log/slog"
 	"slices"
+	"time"
 
 	"github.com/ChausseBenjamin/rafta/internal/database"
 	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/markdown"
+	"github.com/ChausseBenjamin/rafta/internal/util"
 	m "github.com/ChausseBenjamin/rafta/pkg/model"
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
@@ -14,41 +19,399 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func taskToPb(t database.Task, tags []database.Tag) *m.Task {
+// Tasks whose tags and custom fields are fetched in a single query, kept well
+// under the bound parameter limit of SQLite
+const taskBatchSize = 500
+
+func taskToPb(t database.Task, tags []database.Tag, fields []database.GetTaskFieldValuesRow) *m.Task {
 	tagsStr := make([]string, len(tags))
 	for i, tag := range tags {
 		tagsStr[i] = tag.Name
 	}
-	return &m.Task{
+	fieldsPb := make([]*m.CustomFieldValue, len(fields))
+	for i, field := range fields {
+		fieldsPb[i] = fieldValueToPb(field.FieldID, m.CustomFieldKind(field.Kind), field.Value)
+	}
+	var assignee *m.UUID
+	if t.Assignee.Valid {
+		assignee = &m.UUID{Value: t.Assignee.UUID.String()}
+	}
+	task := &m.Task{
 		Id: &m.UUID{Value: t.TaskID.String()},
 		Data: &m.TaskData{
 			Title:    t.Title,
 			Desc:     t.Description.String,
 			Priority: t.Priority,
 			Tags:     tagsStr,
-			DoDate:   timestamppb.New(t.DoDate.UTC()),
-			DueDate:  timestamppb.New(t.DueDate.UTC()),
+			Do:       storedTaskDate(t.DoDate),
+			Due:      storedTaskDate(t.DueDate),
 			State:    m.TaskState(t.State),
 			Recurrence: &m.TaskRecurrence{
 				Pattern: t.RecurrencePattern.String,
 				Active:  t.RecurrenceEnabled,
 			},
+			Assignee:    assignee,
+			HiddenUntil: pbTime(t.HiddenUntil),
+			Fields:      fieldsPb,
 		},
 		Metadata: &m.TaskMetadata{
 			CreatedOn: timestamppb.New(t.CreatedOn.UTC()),
 			UpdatedOn: timestamppb.New(t.UpdatedOn.UTC()),
 		},
+		Progress: &m.TaskProgress{
+			Done:  uint32(t.ChecklistDone),
+			Total: uint32(t.ChecklistTotal),
+		},
+	}
+	resolveTaskDates(task, time.UTC)
+	return task
+}
+
+// loadTask converts a task to its protobuf representation as seen by the
+// given user.
+func (s *protoServer) loadTask(
+	ctx context.Context,
+	db *database.Queries,
+	viewer uuid.UUID,
+	task database.Task,
+) (*m.Task, error) {
+	taskPb, err := s.taskDetails(ctx, db, task)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.viewTasks(ctx, db, viewer, taskPb); err != nil {
+		return nil, err
+	}
+	return taskPb, nil
+}
+
+// taskDetails converts a task to its protobuf representation, fetching the
+// tags and custom field values of the task along the way.
+func (s *protoServer) taskDetails(ctx context.Context, db *database.Queries, task database.Task) (*m.Task, error) {
+	tags, err := db.GetTaskTags(ctx, task.TaskID)
+	if err != nil {
+		slog.ErrorContext(ctx,
+			"failed to retrieve tags associated with task",
+			"task_id", task.TaskID,
+			logging.ErrKey, err,
+		)
+		return nil, status.Errorf(codes.Internal,
+			"Failure while retrieving tags associated with '%v'", task.TaskID,
+		)
+	}
+
+	fields, err := db.GetTaskFieldValues(ctx, task.TaskID)
+	if err != nil {
+		slog.ErrorContext(ctx,
+			"failed to retrieve custom fields associated with task",
+			"task_id", task.TaskID,
+			logging.ErrKey, err,
+		)
+		return nil, status.Errorf(codes.Internal,
+			"Failure while retrieving custom fields associated with '%v'", task.TaskID,
+		)
+	}
+
+	return taskToPb(task, tags, fields), nil
+}
+
+// tasksToPb converts a batch of tasks to their protobuf representation as
+// seen by the given user. Tags and custom field values of the whole batch are
+// fetched at once rather than task by task.
+func (s *protoServer) tasksToPb(ctx context.Context, viewer uuid.UUID, tasks []database.Task) ([]*m.Task, error) {
+	tags := make(map[uuid.UUID][]database.Tag, len(tasks))
+	fields := make(map[uuid.UUID][]database.GetTaskFieldValuesRow, len(tasks))
+	for batch := range slices.Chunk(tasks, taskBatchSize) {
+		ids := make([]uuid.UUID, len(batch))
+		for i, task := range batch {
+			ids[i] = task.TaskID
+		}
+
+		tagRows, err := s.db.GetTasksTags(ctx, ids)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to retrieve tags associated with tasks", logging.ErrKey, err)
+			return nil, status.Error(codes.Internal, "Failure while retrieving tags associated with tasks")
+		}
+		for _, row := range tagRows {
+			tags[row.TaskID] = append(tags[row.TaskID], row.Tag)
+		}
+
+		fieldRows, err := s.db.GetTasksFieldValues(ctx, ids)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to retrieve custom fields associated with tasks", logging.ErrKey, err)
+			return nil, status.Error(codes.Internal, "Failure while retrieving custom fields associated with tasks")
+		}
+		for _, row := range fieldRows {
+			fields[row.TaskID] = append(fields[row.TaskID], database.GetTaskFieldValuesRow{
+				FieldID: row.FieldID,
+				Value:   row.Value,
+				Kind:    row.Kind,
+			})
+		}
+	}
+
+	tasksPb := make([]*m.Task, len(tasks))
+	for i, task := range tasks {
+		tasksPb[i] = taskToPb(task, tags[task.TaskID], fields[task.TaskID])
+	}
+	if err := s.viewTasks(ctx, s.db.Queries, viewer, tasksPb...); err != nil {
+		return nil, err
+	}
+	return tasksPb, nil
+}
+
+// createTask inserts a new task owned by the given user along with its tags
+// and assignee. The caller is in charge of the transaction wrapping db.
+func (s *protoServer) createTask(
+	ctx context.Context,
+	db *database.Queries,
+	owner uuid.UUID,
+	t *m.TaskData,
+) (database.Task, error) {
+	due, err := taskDateToDB(ctx, "due date", t.GetDue(), t.GetDueDate())
+	if err != nil {
+		return database.Task{}, err
+	}
+	do, err := taskDateToDB(ctx, "do date", t.GetDo(), t.GetDoDate())
+	if err != nil {
+		return database.Task{}, err
+	}
+
+	done, total := markdown.Progress(t.GetDesc())
+	task, err := db.NewTask(ctx, database.NewTaskParams{
+		Title:    t.GetTitle(),
+		State:    uint8(t.GetState()),
+		Priority: t.GetPriority(),
+		Description: sql.NullString{
+			String: t.GetDesc(),
+			Valid:  (t.GetDesc() != ""),
+		},
+		ChecklistDone:  int64(done),
+		ChecklistTotal: int64(total),
+		DueDate:        due,
+		DoDate:         do,
+		HiddenUntil:    nullTime(t.GetHiddenUntil()),
+		RecurrencePattern: sql.NullString{
+			String: t.GetRecurrence().GetPattern(),
+			Valid:  (t.GetRecurrence().GetPattern() != ""),
+		},
+		RecurrenceEnabled: t.GetRecurrence().GetActive(),
+		Owner:             owner,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx,
+			"failed to insert task into database",
+			logging.ErrKey, err,
+		)
+		return task, status.Error(codes.Internal,
+			"Failed to insert task",
+		)
+	}
+
+	if len(t.GetTags()) > 0 {
+		if err := s.syncTags(ctx, owner, task.TaskID, t.GetTags(), db); err != nil {
+			return task, err
+		}
+	}
+
+	if t.GetAssignee().GetValue() != "" {
+		if err := s.assignTask(ctx, db, task, t.GetAssignee(), owner); err != nil {
+			return task, err
+		}
+	}
+
+	if len(t.GetFields()) > 0 {
+		if err := s.setFieldValues(ctx, db, owner, task.TaskID, t.GetFields()); err != nil {
+			return task, err
+		}
+	}
+
+	// Views of its tags were handled along with them
+	if err := s.appendTask(ctx, db, owner, task.TaskID, []string{""}); err != nil {
+		return task, err
+	}
+
+	return task, nil
+}
+
+// nullTime converts an optional timestamp to its database representation.
+func nullTime(t *timestamppb.Timestamp) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.AsTime().UTC(), Valid: true}
+}
+
+// pbTime converts an optional database timestamp to its protobuf
+// representation (nil when NULL).
+func pbTime(t sql.NullTime) *timestamppb.Timestamp {
+	if !t.Valid {
+		return nil
 	}
+	return timestamppb.New(t.Time.UTC())
+}
+
+// canAccessTask reports whether a user is allowed to see a task: its owner
+// and the user it's assigned to. This is the single place to extend once
+// sharing exists.
+func canAccessTask(task database.Task, userID uuid.UUID) bool {
+	return task.Owner == userID || (task.Assignee.Valid && task.Assignee.UUID == userID)
+}
+
+// canBeAssigned ensures a task can be handed over to a user. Besides its
+// owner, only users working on one of the task's tags (they own tasks tagged
+// the same way) can be assigned to it. Both sides have to opt in that way so
+// tasks can't be pushed onto (nor shared with) arbitrary users of the server.
+func (s *protoServer) canBeAssigned(
+	ctx context.Context,
+	db *database.Queries,
+	task database.Task,
+	assignee uuid.UUID,
+) error {
+	if assignee == task.Owner {
+		return nil
+	}
+
+	eligible, err := db.WorksOnTaskTags(ctx, database.WorksOnTaskTagsParams{
+		TaskID: task.TaskID,
+		UserID: assignee,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to ensure assignee works on the task tags",
+			"task_id", task.TaskID,
+			"assignee_id", assignee,
+			logging.ErrKey, err,
+		)
+		return status.Error(codes.Internal, "failed to validate assignee")
+	}
+	if !eligible {
+		slog.WarnContext(ctx, "attempt to assign task to user not sharing its tags",
+			"task_id", task.TaskID,
+			"assignee_id", assignee,
+		)
+		return status.Errorf(codes.FailedPrecondition,
+			"user '%v' doesn't work on any tag of task '%v'", assignee, task.TaskID,
+		)
+	}
+	return nil
+}
+
+// getAccessibleTask retrieves a task the given user has access to. Tasks
+// the user can't access are reported as missing to avoid leaking their
+// existence.
+func (s *protoServer) getAccessibleTask(
+	ctx context.Context,
+	db *database.Queries,
+	id *m.UUID,
+	userID uuid.UUID,
+) (database.Task, error) {
+	taskID, err := util.ParseUUID(ctx, util.ParseUUIDParams{
+		Str: id.GetValue(), Subject: "task_id",
+		Implication: codes.InvalidArgument, Critical: false,
+	})
+	if err != nil {
+		return database.Task{}, err
+	}
+
+	task, err := db.GetTask(ctx, taskID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		slog.ErrorContext(ctx, "failed to retrieve task",
+			"task_id", taskID,
+			logging.ErrKey, err,
+		)
+		return task, status.Error(codes.Internal, "failed to retrieve task")
+	}
+	if err != nil || !canAccessTask(task, userID) {
+		slog.WarnContext(ctx, "task not found", "task_id", taskID)
+		return task, status.Errorf(codes.NotFound, "task '%v' not found", taskID)
+	}
+
+	return task, nil
+}
+
+// assignTask hands a task over to a new assignee, which gives them access to
+// it, and records the handoff so the owner can review it later. A nil (or
+// empty) assignee unassigns the task. Assigning a task to its current assignee
+// is a no-op.
+func (s *protoServer) assignTask(
+	ctx context.Context,
+	db *database.Queries,
+	task database.Task,
+	assignee *m.UUID,
+	assignedBy uuid.UUID,
+) error {
+	newAssignee := uuid.NullUUID{}
+	if assignee != nil && assignee.Value != "" {
+		id, err := util.ParseUUID(ctx, util.ParseUUIDParams{
+			Str: assignee.Value, Subject: "assignee_id",
+			Implication: codes.InvalidArgument, Critical: false,
+		})
+		if err != nil {
+			return err
+		}
+		newAssignee = uuid.NullUUID{UUID: id, Valid: true}
+	}
+
+	if newAssignee == task.Assignee {
+		return nil
+	}
+
+	if newAssignee.Valid {
+		if _, err := db.GetUser(ctx, newAssignee.UUID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				slog.WarnContext(ctx, "attempt to assign task to unknown user",
+					"task_id", task.TaskID,
+					"assignee_id", newAssignee.UUID,
+				)
+				return status.Errorf(codes.NotFound,
+					"user '%v' does not exist", newAssignee.UUID,
+				)
+			}
+			slog.ErrorContext(ctx, "failed to retrieve assignee",
+				"assignee_id", newAssignee.UUID,
+				logging.ErrKey, err,
+			)
+			return status.Error(codes.Internal, "failed to validate assignee")
+		}
+		if err := s.canBeAssigned(ctx, db, task, newAssignee.UUID); err != nil {
+			return err
+		}
+	}
+
+	if err := db.SetTaskAssignee(ctx, database.SetTaskAssigneeParams{
+		TaskID:   task.TaskID,
+		Assignee: newAssignee,
+	}); err != nil {
+		slog.ErrorContext(ctx, "failed to update task assignee",
+			"task_id", task.TaskID,
+			logging.ErrKey, err,
+		)
+		return status.Error(codes.Internal, "failed to assign task")
+	}
+
+	if _, err := db.NewTaskAssignment(ctx, database.NewTaskAssignmentParams{
+		TaskID:     task.TaskID,
+		Assignee:   newAssignee,
+		AssignedBy: uuid.NullUUID{UUID: assignedBy, Valid: true},
+	}); err != nil {
+		slog.ErrorContext(ctx, "failed to record task assignment",
+			"task_id", task.TaskID,
+			logging.ErrKey, err,
+		)
+		return status.Error(codes.Internal, "failed to record task assignment")
+	}
+
+	return nil
 }
 
 // syncTags ensures that tags for a task are up-to-date by taking in a task,
 // it's old and new tags and perform the following:
 // - Unassign from task tags that are no longer used
 // - Create tags that don't get exist
-// - Assign any tag not currently assigned to the task
+// - Assign any tag not currently assigned to the task (last in its view)
 // - Unassign tags that are no longer associated with the task
 // - Delete tags that are no longer linked to any task
-func (s *protoServer) syncTags(ctx context.Context, taskID uuid.UUID, tagNames []string, db *database.Queries) error {
+func (s *protoServer) syncTags(ctx context.Context, owner, taskID uuid.UUID, tagNames []string, db *database.Queries) error {
 	// Build a []database.Tag containing tags that already exists
 	existingTags, err := db.GetExistingTags(ctx, tagNames)
 	if err != nil {
@@ -96,6 +459,7 @@ func (s *protoServer) syncTags(ctx context.Context, taskID uuid.UUID, tagNames [
 	}
 
 	// Link every tag in existingTags that isn't already linked to the task
+	var added []string
 	for _, tag := range existingTags {
 		if !slices.ContainsFunc(linkedTags, func(t database.Tag) bool {
 			return (t.TagID == tag.TagID)
@@ -115,8 +479,12 @@ func (s *protoServer) syncTags(ctx context.Context, taskID uuid.UUID, tagNames [
 				)
 			}
 			linkedTags = append(linkedTags, tag)
+			added = append(added, tag.Name)
 		}
 	}
+	if err := s.appendTask(ctx, db, owner, taskID, added); err != nil {
+		return err
+	}
 
 	// Unassign every tag from linkedTags that isn't in existingTags
 	var tagsToUnassign []int64
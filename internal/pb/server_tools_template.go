@@ -0,0 +1,205 @@
+package pb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/caldate"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/template"
+	"github.com/ChausseBenjamin/rafta/internal/util"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// templateToPb decodes a template into its protobuf representation.
+func templateToPb(ctx context.Context, t database.Template) (*m.Template, error) {
+	data := &m.TemplateData{}
+	if err := proto.Unmarshal(t.Definition, data); err != nil {
+		slog.ErrorContext(ctx, "failed to decode template",
+			"template_id", t.TemplateID,
+			logging.ErrKey, err,
+		)
+		return nil, status.Errorf(codes.Internal,
+			"failed to decode template '%v'", t.TemplateID,
+		)
+	}
+	data.Name = t.Name
+	return &m.Template{
+		Id:        &m.UUID{Value: t.TemplateID.String()},
+		Data:      data,
+		CreatedOn: timestamppb.New(t.CreatedOn.UTC()),
+		UpdatedOn: timestamppb.New(t.UpdatedOn.UTC()),
+		Variables: templateVariables(data),
+	}, nil
+}
+
+// templateVariables lists the placeholders used throughout a template.
+func templateVariables(data *m.TemplateData) []string {
+	var texts []string
+	for _, task := range data.GetTasks() {
+		texts = append(texts, task.GetTitle(), task.GetDesc())
+		texts = append(texts, task.GetTags()...)
+		texts = append(texts, task.GetSubtasks()...)
+	}
+	return template.Placeholders(texts...)
+}
+
+// encodeTemplate validates a template before encoding it for storage so that
+// templates which can't be instantiated can't be saved.
+func encodeTemplate(ctx context.Context, data *m.TemplateData) (string, []byte, error) {
+	name := strings.TrimSpace(data.GetName())
+	if name == "" {
+		slog.WarnContext(ctx, "template is missing a name")
+		return "", nil, status.Error(codes.InvalidArgument, "templates require a name")
+	}
+	if len(data.GetTasks()) == 0 {
+		slog.WarnContext(ctx, "template has no tasks")
+		return "", nil, status.Error(codes.InvalidArgument, "templates require at least one task")
+	}
+	for i, task := range data.GetTasks() {
+		if strings.TrimSpace(task.GetTitle()) == "" {
+			slog.WarnContext(ctx, "template task is missing a title", "index", i)
+			return "", nil, status.Errorf(codes.InvalidArgument,
+				"task #%d of the template requires a title", i,
+			)
+		}
+		for _, expr := range []string{task.GetDoDate(), task.GetDueDate()} {
+			if expr == "" {
+				continue
+			}
+			if _, err := template.ParseOffset(expr); err != nil {
+				slog.WarnContext(ctx, "template task has an invalid date",
+					"index", i,
+					logging.ErrKey, err,
+				)
+				return "", nil, status.Errorf(codes.InvalidArgument,
+					"task #%d of the template: %v", i, err,
+				)
+			}
+		}
+	}
+
+	definition, err := proto.Marshal(data)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to encode template", logging.ErrKey, err)
+		return "", nil, status.Error(codes.Internal, "failed to encode template")
+	}
+	return name, definition, nil
+}
+
+// getUserTemplate retrieves a template owned by the given user.
+func (s *protoServer) getUserTemplate(ctx context.Context, owner uuid.UUID, id *m.UUID) (*m.Template, error) {
+	templateID, err := util.ParseUUID(ctx, util.ParseUUIDParams{
+		Str: id.GetValue(), Subject: "template_id",
+		Implication: codes.InvalidArgument, Critical: false,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := s.db.GetUserTemplate(ctx, database.GetUserTemplateParams{
+		TemplateID: templateID,
+		Owner:      owner,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			slog.WarnContext(ctx, "template not found", "template_id", templateID)
+			return nil, status.Errorf(codes.NotFound, "template '%v' not found", templateID)
+		}
+		slog.ErrorContext(ctx, "failed to retrieve template",
+			"template_id", templateID,
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to retrieve template")
+	}
+
+	return templateToPb(ctx, t)
+}
+
+// templateTaskData turns a task of a template into the data of a task to
+// create, substituting placeholders and resolving relative dates from anchor.
+func templateTaskData(t *m.TemplateTask, vars map[string]string, anchor time.Time) (*m.TaskData, error) {
+	title, err := template.Expand(t.GetTitle(), vars)
+	if err != nil {
+		return nil, err
+	}
+	desc, err := template.Expand(t.GetDesc(), vars)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]string, 0, len(t.GetTags()))
+	for _, tag := range t.GetTags() {
+		expanded, err := template.Expand(tag, vars)
+		if err != nil {
+			return nil, err
+		}
+		if expanded != "" {
+			tags = append(tags, expanded)
+		}
+	}
+
+	if len(t.GetSubtasks()) > 0 {
+		var sb strings.Builder
+		sb.WriteString(desc)
+		if desc != "" {
+			sb.WriteString("\n\n")
+		}
+		for _, subtask := range t.GetSubtasks() {
+			expanded, err := template.Expand(subtask, vars)
+			if err != nil {
+				return nil, err
+			}
+			fmt.Fprintf(&sb, "- [ ] %s\n", expanded)
+		}
+		desc = sb.String()
+	}
+
+	data := &m.TaskData{
+		Title:      title,
+		Desc:       desc,
+		Priority:   t.GetPriority(),
+		State:      m.TaskState_PENDING,
+		Tags:       tags,
+		Recurrence: t.GetRecurrence(),
+	}
+
+	if t.GetDoDate() != "" {
+		offset, err := template.ParseOffset(t.GetDoDate())
+		if err != nil {
+			return nil, err
+		}
+		data.Do = templateDate(offset, anchor)
+	}
+	if t.GetDueDate() != "" {
+		offset, err := template.ParseOffset(t.GetDueDate())
+		if err != nil {
+			return nil, err
+		}
+		data.Due = templateDate(offset, anchor)
+	}
+
+	return data, nil
+}
+
+// templateDate resolves a date offset against the instantiation anchor.
+// Offsets counted in days give all-day dates while the others are pinned to
+// the time zone of the anchor.
+func templateDate(offset template.Offset, anchor time.Time) *m.TaskDate {
+	date := caldate.At(offset.From(anchor))
+	if offset.DateOnly() {
+		date = date.WholeDay()
+	}
+	return taskDateToPb(date)
+}
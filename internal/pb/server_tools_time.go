@@ -0,0 +1,114 @@
+package pb
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// endOfTime is used as the upper bound of open-ended time ranges.
+var endOfTime = time.Date(9999, time.December, 31, 23, 59, 59, 0, time.UTC)
+
+// timeEntryToPb converts a time entry to its protobuf representation. Running
+// timers have their duration computed up to now.
+func timeEntryToPb(e database.TimeEntry, now time.Time) *m.TimeEntry {
+	entry := &m.TimeEntry{
+		Id: &m.UUID{Value: e.EntryID.String()},
+		Data: &m.TimeEntryData{
+			TaskId:    &m.UUID{Value: e.TaskID.String()},
+			StartedOn: timestamppb.New(e.StartedOn.UTC()),
+			Note:      e.Note.String,
+		},
+	}
+	end := now
+	if e.EndedOn.Valid {
+		end = e.EndedOn.Time
+		entry.Data.EndedOn = timestamppb.New(e.EndedOn.Time.UTC())
+	}
+	entry.Duration = durationpb.New(end.Sub(e.StartedOn))
+	return entry
+}
+
+// timeRangeBounds returns the bounds of a time range, substituting unset
+// bounds so that the range is open-ended.
+func timeRangeBounds(r *m.TimeRange) (since, until time.Time) {
+	since, until = time.Time{}, endOfTime
+	if r.GetFrom() != nil {
+		since = r.GetFrom().AsTime().UTC()
+	}
+	if r.GetTo() != nil {
+		until = r.GetTo().AsTime().UTC()
+	}
+	return since, until
+}
+
+// overlap returns how much of a time entry falls within [since, until).
+func overlap(e database.TimeEntry, since, until, now time.Time) time.Duration {
+	start, end := e.StartedOn, now
+	if e.EndedOn.Valid {
+		end = e.EndedOn.Time
+	}
+	if start.Before(since) {
+		start = since
+	}
+	if end.After(until) {
+		end = until
+	}
+	if end.Before(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+// timeEntryBounds validates the bounds of a time entry. An entry can only
+// lack an end if it's allowed to be running.
+func timeEntryBounds(
+	ctx context.Context,
+	data *m.TimeEntryData,
+	allowRunning bool,
+	now time.Time,
+) (time.Time, sql.NullTime, error) {
+	if data.GetStartedOn() == nil {
+		slog.WarnContext(ctx, "time entry is missing its start")
+		return time.Time{}, sql.NullTime{}, status.Error(codes.InvalidArgument,
+			"time entries require a start",
+		)
+	}
+	started := data.GetStartedOn().AsTime().UTC()
+	if started.After(now) {
+		slog.WarnContext(ctx, "time entry starts in the future", "started_on", started)
+		return time.Time{}, sql.NullTime{}, status.Error(codes.InvalidArgument,
+			"time entries can't start in the future",
+		)
+	}
+
+	if data.GetEndedOn() == nil {
+		if !allowRunning {
+			slog.WarnContext(ctx, "time entry is missing its end")
+			return time.Time{}, sql.NullTime{}, status.Error(codes.InvalidArgument,
+				"only the running timer can be left without an end",
+			)
+		}
+		return started, sql.NullTime{}, nil
+	}
+
+	ended := data.GetEndedOn().AsTime().UTC()
+	if ended.Before(started) {
+		slog.WarnContext(ctx, "time entry ends before it starts",
+			"started_on", started,
+			"ended_on", ended,
+		)
+		return time.Time{}, sql.NullTime{}, status.Error(codes.InvalidArgument,
+			"time entries can't end before they start",
+		)
+	}
+	return started, sql.NullTime{Time: ended, Valid: true}, nil
+}
@@ -0,0 +1,74 @@
+package pb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/auth"
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func personalTokenToPb(t database.PersonalToken) *m.PersonalToken {
+	return &m.PersonalToken{
+		Id: &m.UUID{Value: t.TokenID.String()},
+		Data: &m.PersonalTokenData{
+			Name:      t.Name,
+			Scopes:    strings.Fields(t.Scopes),
+			ExpiresOn: pbTime(t.ExpiresOn),
+		},
+		CreatedOn: timestamppb.New(t.CreatedOn.UTC()),
+		LastUsed:  pbTime(t.LastUsed),
+	}
+}
+
+// validatePersonalToken checks a token requested by a client and returns it
+// as it should be stored (without its user and hash).
+func validatePersonalToken(ctx context.Context, data *m.PersonalTokenData) (database.NewPersonalTokenParams, error) {
+	params := database.NewPersonalTokenParams{Name: strings.TrimSpace(data.GetName())}
+
+	// Scopes are stored in a consistent order
+	var scopes []string
+	for _, scope := range auth.Scopes {
+		if slices.Contains(data.GetScopes(), scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	params.Scopes = strings.Join(scopes, " ")
+
+	var err error
+	for _, scope := range data.GetScopes() {
+		if !slices.Contains(auth.Scopes, scope) {
+			err = fmt.Errorf("unknown scope '%s' (expected one of %s)", scope, strings.Join(auth.Scopes, ", "))
+			break
+		}
+	}
+	switch {
+	case err != nil:
+	case params.Name == "":
+		err = errors.New("personal access tokens must have a name")
+	case len(scopes) == 0:
+		err = errors.New("personal access tokens must have at least one scope")
+	case data.GetExpiresOn() != nil && !data.GetExpiresOn().AsTime().After(time.Now()):
+		err = errors.New("personal access tokens must expire in the future")
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "received invalid personal access token", logging.ErrKey, err)
+		return params, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if data.GetExpiresOn() != nil {
+		params.ExpiresOn = sql.NullTime{Time: data.GetExpiresOn().AsTime().UTC(), Valid: true}
+	}
+	return params, nil
+}
@@ -0,0 +1,67 @@
+package pb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Name authenticator apps list the codes of rafta accounts under
+const totpIssuer = "Rafta"
+
+// getUserTOTP retrieves the two-factor authentication a user enrolled in.
+func (s *protoServer) getUserTOTP(ctx context.Context, userID uuid.UUID) (database.UserTotp, error) {
+	enrolled, err := s.db.GetUserTOTP(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		slog.WarnContext(ctx, "two-factor authentication isn't enrolled")
+		return enrolled, status.Error(codes.FailedPrecondition,
+			"two-factor authentication isn't enrolled",
+		)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to retrieve two-factor authentication",
+			logging.ErrKey, err,
+		)
+		return enrolled, status.Error(codes.Internal,
+			"failed to retrieve two-factor authentication",
+		)
+	}
+	return enrolled, nil
+}
+
+// disableTOTP removes the two-factor authentication of a user along with their
+// recovery codes. It returns whether the user had one.
+func (s *protoServer) disableTOTP(ctx context.Context, userID uuid.UUID) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to begin transaction", logging.ErrKey, err)
+		return false, status.Error(codes.Internal, "failed to disable two-factor authentication")
+	}
+	defer tx.Rollback()
+	db := s.db.WithTx(tx)
+
+	rowCount, err := db.DeleteUserTOTP(ctx, userID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to delete two-factor authentication", logging.ErrKey, err)
+		return false, status.Error(codes.Internal, "failed to disable two-factor authentication")
+	}
+	if err := db.DeleteRecoveryCodes(ctx, userID); err != nil {
+		slog.ErrorContext(ctx, "failed to delete recovery codes", logging.ErrKey, err)
+		return false, status.Error(codes.Internal, "failed to disable two-factor authentication")
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx, "failed to commit two-factor authentication removal",
+			logging.ErrKey, err,
+		)
+		return false, status.Error(codes.Internal, "failed to disable two-factor authentication")
+	}
+	return rowCount > 0, nil
+}
@@ -0,0 +1,153 @@
+package pb
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/database"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+// Loosely based on the urgency of Taskwarrior tasks.
+var defaultUrgency = &m.UrgencyCoefficients{
+	Priority:  proto.Float64(6),
+	Due:       proto.Float64(12),
+	Age:       proto.Float64(2),
+	Ongoing:   proto.Float64(4),
+	Blocked:   proto.Float64(-5),
+	Scheduled: proto.Float64(5),
+	Hidden:    proto.Float64(-3),
+	Tags:      proto.Float64(1),
+	Tag:       map[string]float64{"next": 15},
+}
+
+// Age at which a task gets the full age coefficient.
+const urgencyMaxAge = 365 * 24 * time.Hour
+
+// effectiveUrgency returns the coefficients set by a user completed with the
+// default ones.
+func effectiveUrgency(c *m.UrgencyCoefficients) *m.UrgencyCoefficients {
+	effective := proto.Clone(defaultUrgency).(*m.UrgencyCoefficients)
+	proto.Merge(effective, c)
+	return effective
+}
+
+// validateUrgency makes sure every coefficient is a finite number.
+func validateUrgency(c *m.UrgencyCoefficients) error {
+	coefficients := map[string]float64{
+		"priority":  c.GetPriority(),
+		"due":       c.GetDue(),
+		"age":       c.GetAge(),
+		"ongoing":   c.GetOngoing(),
+		"blocked":   c.GetBlocked(),
+		"scheduled": c.GetScheduled(),
+		"hidden":    c.GetHidden(),
+		"tags":      c.GetTags(),
+	}
+	for tag, v := range c.GetTag() {
+		coefficients["tag '"+tag+"'"] = v
+	}
+	for name, v := range coefficients {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("%s coefficient must be a finite number", name)
+		}
+	}
+	return nil
+}
+
+// urgency computes how pressing a task is at a given time.
+func urgency(c *m.UrgencyCoefficients, task *m.Task, now time.Time) float64 {
+	data := task.GetData()
+	if data.GetState() == m.TaskState_DONE {
+		return 0
+	}
+
+	var u float64
+	if data.GetPriority() != 0 {
+		u += c.GetPriority() / float64(data.GetPriority())
+	}
+
+	if data.GetDue() != nil {
+		// Days past the due date (negative until then)
+		overdue := now.Sub(dateEnd(data.GetDue(), now.Location())).Hours() / 24
+		switch {
+		case overdue >= 7:
+			u += c.GetDue()
+		case overdue >= -14:
+			u += c.GetDue() * ((overdue+14)*0.8/21 + 0.2)
+		default:
+			u += c.GetDue() * 0.2
+		}
+	}
+
+	age := now.Sub(task.GetMetadata().GetCreatedOn().AsTime())
+	u += c.GetAge() * min(max(float64(age)/float64(urgencyMaxAge), 0), 1)
+
+	switch data.GetState() {
+	case m.TaskState_ONGOING:
+		u += c.GetOngoing()
+	case m.TaskState_BLOCKED:
+		u += c.GetBlocked()
+	}
+
+	if data.GetDo() != nil && !dateStart(data.GetDo(), now.Location()).After(now) {
+		u += c.GetScheduled()
+	}
+	if data.GetHiddenUntil() != nil && data.GetHiddenUntil().AsTime().After(now) {
+		u += c.GetHidden()
+	}
+
+	switch len(data.GetTags()) {
+	case 0:
+	case 1:
+		u += c.GetTags() * 0.8
+	case 2:
+		u += c.GetTags() * 0.9
+	default:
+		u += c.GetTags()
+	}
+	for _, tag := range data.GetTags() {
+		u += c.GetTag()[tag]
+	}
+
+	return u
+}
+
+// isOverdue reports whether a task missed its due date. Tasks due on a given
+// day are only overdue once that day is over.
+func isOverdue(task *m.Task, now time.Time) bool {
+	data := task.GetData()
+	return data.GetState() != m.TaskState_DONE &&
+		data.GetDue() != nil &&
+		dateEnd(data.GetDue(), now.Location()).Before(now)
+}
+
+// viewTasks fills in the fields of tasks which depend on the user viewing
+// them: the instants their dates resolve to in the user's time zone, their
+// urgency and whether they're overdue. Times passed to urgency and isOverdue
+// are expressed in the user's time zone for that reason.
+func (s *protoServer) viewTasks(
+	ctx context.Context,
+	db *database.Queries,
+	viewer uuid.UUID,
+	tasks ...*m.Task,
+) error {
+	prefs, err := s.getPreferences(ctx, db, viewer)
+	if err != nil {
+		return err
+	}
+	c := effectiveUrgency(prefs.GetUrgency())
+	loc := preferredLocation(ctx, viewer, prefs)
+
+	now := time.Now().In(loc)
+	for _, task := range tasks {
+		resolveTaskDates(task, loc)
+		task.Urgency = urgency(c, task, now)
+		task.IsOverdue = isOverdue(task, now)
+	}
+	return nil
+}
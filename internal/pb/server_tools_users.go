@@ -24,6 +24,36 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// checkUserCapacity refuses new users once the server reached its max-user
+// capacity.
+func (s *protoServer) checkUserCapacity(ctx context.Context) error {
+	// 0 implies no user limit
+	if s.cfg.MaxUsers <= 0 {
+		return nil
+	}
+
+	userCount, err := s.db.GetUserCount(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx,
+			"Failed to determine the platforms user count to limit signups",
+			logging.ErrKey, err,
+		)
+		return status.Error(codes.Internal,
+			"Failed to determine if the platform accepts new users",
+		)
+	}
+
+	if userCount >= int64(s.cfg.MaxUsers) {
+		slog.WarnContext(ctx,
+			"Blocked signup attempt as server has reached max-user capacity",
+		)
+		return status.Error(codes.FailedPrecondition, msgNoNewUser)
+	}
+	return nil
+}
+
+// newUser creates a user. Passwords chosen by users must be checked with
+// ValidatePasswd beforehand.
 func (s *protoServer) newUser(ctx context.Context, req *m.UserSignupRequest) (*m.User, error) {
 	if err := validateEmail(ctx, req.User.Email); err != nil {
 		return nil, err
@@ -47,10 +77,6 @@ func (s *protoServer) newUser(ctx context.Context, req *m.UserSignupRequest) (*m
 		)
 	}
 
-	if err := s.auth.ValidatePasswd(req.UserSecret); err != nil {
-		return nil, err
-	}
-
 	hash, salt, err := sec.GenerateHashWithThreads(req.UserSecret, getArgonThreads(s.cfg.ArgonThreads))
 	if err != nil {
 		slog.ErrorContext(ctx, "Failure to hash user password", logging.ErrKey, err)
@@ -151,10 +177,13 @@ func (s *protoServer) updateUser(ctx context.Context, userID uuid.UUID, data *m.
 	return timestamppb.New(updated.UTC()), nil
 }
 
+// updateUserCredentials changes the password of a user and signs out every
+// session except keep (uuid.Nil to sign out all of them).
 func (s *protoServer) updateUserCredentials(
 	ctx context.Context,
 	userID uuid.UUID,
 	passwd string,
+	keep uuid.UUID,
 ) (*timestamppb.Timestamp, error) {
 	if err := s.auth.ValidatePasswd(passwd); err != nil {
 		return nil, err
@@ -193,6 +222,17 @@ func (s *protoServer) updateUserCredentials(
 		return nil, status.Error(codes.Internal, "failed to update credentials")
 	}
 
+	if _, err := db.DeleteOtherUserSessions(ctx, database.DeleteOtherUserSessionsParams{
+		UserID:  userID,
+		Current: keep,
+	}); err != nil {
+		slog.ErrorContext(ctx, "failed to sign out sessions",
+			"user_id", userID,
+			logging.ErrKey, err,
+		)
+		return nil, status.Error(codes.Internal, "failed to update credentials")
+	}
+
 	modified, err := db.UpdateUserModified(ctx)
 	if err != nil {
 		slog.ErrorContext(ctx,
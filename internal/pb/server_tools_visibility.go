@@ -0,0 +1,63 @@
+package pb
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+	"github.com/ChausseBenjamin/rafta/internal/push"
+	m "github.com/ChausseBenjamin/rafta/pkg/model"
+	"github.com/google/uuid"
+)
+
+// How often hidden tasks are checked for ones to reveal
+const revealInterval = time.Minute
+
+// revealTasks makes hidden tasks visible again once their hidden_until is
+// reached and lets their owner and assignee know. A single periodic sweep
+// covers every task, the first one also reveals the tasks whose hidden_until
+// passed while the server was down.
+func (s *protoServer) revealTasks(ctx context.Context) {
+	slog.DebugContext(ctx, "Watching hidden tasks to reveal")
+	ticker := time.NewTicker(revealInterval)
+	defer ticker.Stop()
+	for {
+		s.revealDueTasks(ctx, time.Now())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *protoServer) revealDueTasks(ctx context.Context, now time.Time) {
+	tasks, err := s.db.RevealTasks(ctx, sql.NullTime{Time: now.UTC(), Valid: true})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to reveal hidden tasks", logging.ErrKey, err)
+		return
+	}
+
+	for _, task := range tasks {
+		slog.InfoContext(ctx, "Task became visible",
+			"task_id", task.TaskID,
+			"owner", task.Owner,
+		)
+		recipients := []uuid.UUID{task.Owner}
+		if task.Assignee.Valid && task.Assignee.UUID != task.Owner {
+			recipients = append(recipients, task.Assignee.UUID)
+		}
+		for _, userID := range recipients {
+			s.notify(ctx, userID, m.PushEvent_PUSH_REVEALED, push.Notification{
+				Event:    "revealed",
+				Title:    task.Title,
+				Message:  "Back on your list",
+				TaskID:   task.TaskID,
+				Priority: 3,
+				Tags:     []string{"eyes"},
+			})
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package push
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/ChausseBenjamin/rafta/internal/logging"
+)
+
+const (
+	attemptTimeout = 10 * time.Second
+	// Deliveries in flight at once (waiting for a retry included)
+	maxInFlight = 64
+)
+
+// Delays between delivery attempts: a notification is attempted once more
+// than there are delays.
+var DefaultRetryDelays = []time.Duration{
+	5 * time.Second,
+	30 * time.Second,
+	2 * time.Minute,
+}
+
+// Dispatcher delivers notifications in the background, retrying failed
+// attempts. The outcome of every delivery (after retries) is handed to a
+// report function so that failing endpoints can get disabled.
+type Dispatcher struct {
+	client   *http.Client
+	delays   []time.Duration
+	report   func(e Endpoint, err error)
+	inFlight chan struct{}
+}
+
+// NewDispatcher returns a dispatcher refusing to deliver to addresses that
+// aren't public unless allowPrivate is set (ex: for a ntfy server on the LAN).
+func NewDispatcher(delays []time.Duration, allowPrivate bool, report func(e Endpoint, err error)) *Dispatcher {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !allowPrivate {
+		dialer := &net.Dialer{
+			Timeout:   attemptTimeout,
+			KeepAlive: 30 * time.Second,
+			Control:   publicOnly,
+		}
+		transport.DialContext = dialer.DialContext
+	}
+	return &Dispatcher{
+		client:   &http.Client{Timeout: attemptTimeout, Transport: transport},
+		delays:   delays,
+		report:   report,
+		inFlight: make(chan struct{}, maxInFlight),
+	}
+}
+
+// Test makes a single delivery attempt and returns its outcome without
+// reporting it.
+func (d *Dispatcher) Test(ctx context.Context, e Endpoint, n Notification) error {
+	return Send(ctx, d.client, e, n)
+}
+
+// Dispatch queues a notification for delivery. It never blocks: the
+// notification is dropped when too many deliveries are already in flight.
+//
+// The delivery outlives the request: ctx is only kept to trace where the
+// notification originated from in logs.
+func (d *Dispatcher) Dispatch(ctx context.Context, e Endpoint, n Notification) {
+	log := slog.With(
+		"endpoint_id", e.ID,
+		"event", n.Event,
+		"task_id", n.TaskID,
+	)
+
+	select {
+	case d.inFlight <- struct{}{}:
+	default:
+		log.WarnContext(ctx, "Too many push notifications in flight, dropping one")
+		return
+	}
+
+	go func() {
+		defer func() { <-d.inFlight }()
+
+		var err error
+		for attempt := 0; ; attempt++ {
+			err = Send(context.Background(), d.client, e, n)
+			if err == nil || permanent(err) || attempt >= len(d.delays) {
+				break
+			}
+			log.DebugContext(ctx, "Push notification delivery failed, retrying",
+				"attempt", attempt+1,
+				logging.ErrKey, err,
+			)
+			time.Sleep(d.delays[attempt])
+		}
+
+		switch {
+		case errors.Is(err, errRequest):
+			// Not the endpoint's fault, it shouldn't count against it
+			log.ErrorContext(ctx, "Failed to build push notification", logging.ErrKey, err)
+			return
+		case err != nil:
+			log.WarnContext(ctx, "Failed to deliver push notification", logging.ErrKey, err)
+		default:
+			log.DebugContext(ctx, "Delivered push notification")
+		}
+		d.report(e, err)
+	}()
+}
+
+// permanent tells whether retrying a failed delivery is pointless.
+func permanent(err error) bool {
+	return errors.Is(err, ErrGone) || errors.Is(err, ErrPrivateAddress) || errors.Is(err, errRequest)
+}
@@ -0,0 +1,199 @@
+// push delivers notifications to the push endpoints users register, which is
+// how mobile clients hear from rafta without keeping a connection open:
+//
+//   - UnifiedPush endpoints (the URL a distributor hands to an app) receive a
+//     JSON document the app decodes itself.
+//   - ntfy topics (ex: https://ntfy.example.com/my-tasks) receive a plain
+//     text message with its title, tags and priority set through headers.
+package push
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+type Kind uint8
+
+const (
+	UnifiedPush Kind = iota
+	Ntfy
+)
+
+// UnifiedPush distributors aren't required to accept larger messages.
+const maxUnifiedPushSize = 4096
+
+var (
+	ErrInvalidURL = errors.New("invalid push endpoint URL")
+	// ErrGone means the endpoint no longer exists (ex: the app got
+	// uninstalled) and retrying is pointless.
+	ErrGone = errors.New("push endpoint no longer exists")
+	// ErrPrivateAddress means the endpoint resolved to an address that isn't
+	// publicly routable (ex: loopback, LAN), which would let users reach
+	// services only the server can.
+	ErrPrivateAddress = errors.New("push endpoint address is not public")
+	// errRequest means the notification couldn't be turned into a request,
+	// which says nothing about the endpoint.
+	errRequest = errors.New("failed to build push request")
+)
+
+// Endpoint is where notifications of a user get delivered.
+type Endpoint struct {
+	ID    uuid.UUID
+	Kind  Kind
+	URL   string
+	Token string // ntfy access token (optional)
+}
+
+// Notification is a single message meant for a user.
+type Notification struct {
+	Event    string    `json:"event"` // ex: "due", "reminder"
+	Title    string    `json:"title"`
+	Message  string    `json:"message"`
+	TaskID   uuid.UUID `json:"task_id"`
+	Priority int       `json:"priority"` // 1 (min) to 5 (max), like ntfy
+	Tags     []string  `json:"-"`        // ntfy tags/emojis (ex: "alarm_clock")
+}
+
+// ValidateURL makes sure a push endpoint URL can be delivered to.
+func ValidateURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidURL, err)
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return fmt.Errorf("%w: scheme must be http or https (got '%s')", ErrInvalidURL, u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%w: missing host", ErrInvalidURL)
+	}
+	return nil
+}
+
+// Send makes a single delivery attempt.
+func Send(ctx context.Context, client *http.Client, e Endpoint, n Notification) error {
+	req, err := request(ctx, e, n)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errRequest, err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096)) //nolint:errcheck
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return fmt.Errorf("%w (%s)", ErrGone, resp.Status)
+	default:
+		return fmt.Errorf("push endpoint responded with %s", resp.Status)
+	}
+}
+
+func request(ctx context.Context, e Endpoint, n Notification) (*http.Request, error) {
+	switch e.Kind {
+	case UnifiedPush:
+		body, err := json.Marshal(n)
+		if err != nil {
+			return nil, err
+		}
+		// Only the message is free-form enough to get that long (escaping makes
+		// the excess an estimate, hence the loop)
+		for len(body) > maxUnifiedPushSize && n.Message != "" {
+			n.Message = truncate(n.Message, len(n.Message)-(len(body)-maxUnifiedPushSize))
+			if body, err = json.Marshal(n); err != nil {
+				return nil, err
+			}
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.URL, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("TTL", strconv.Itoa(int((24 * time.Hour).Seconds())))
+		return req, nil
+
+	case Ntfy:
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.URL, bytes.NewBufferString(n.Message))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+		req.Header.Set("Title", headerValue(n.Title))
+		if n.Priority != 0 {
+			req.Header.Set("Priority", strconv.Itoa(n.Priority))
+		}
+		tags := append([]string{}, n.Tags...)
+		if n.Event != "" {
+			tags = append(tags, n.Event)
+		}
+		if len(tags) > 0 {
+			req.Header.Set("Tags", headerValue(strings.Join(tags, ",")))
+		}
+		if e.Token != "" {
+			req.Header.Set("Authorization", "Bearer "+e.Token)
+		}
+		return req, nil
+
+	default:
+		return nil, fmt.Errorf("unknown push endpoint kind %d", e.Kind)
+	}
+}
+
+// headerValue keeps user-written text (ex: task titles) from breaking out of
+// the header it's sent in.
+func headerValue(s string) string {
+	return strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return r == '\r' || r == '\n'
+	}), " ")
+}
+
+// publicOnly is a net.Dialer control function refusing connections to
+// addresses that aren't publicly routable. Checking the address being dialed
+// (rather than the URL) covers redirects and DNS answers changing between
+// validation and delivery.
+func publicOnly(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrPrivateAddress, err)
+	}
+	addr := addrPort.Addr().Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() || sharedAddresses.Contains(addr) {
+		return fmt.Errorf("%w (%s)", ErrPrivateAddress, addr)
+	}
+	return nil
+}
+
+// Carrier-grade NAT range (RFC 6598), not covered by netip.Addr.IsPrivate.
+var sharedAddresses = netip.MustParsePrefix("100.64.0.0/10")
+
+// truncate shortens s to at most n bytes without splitting a character.
+func truncate(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,203 @@
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var testNotification = Notification{
+	Event:    "due",
+	Title:    "Water the plants",
+	Message:  "Due now",
+	TaskID:   uuid.New(),
+	Priority: 4,
+	Tags:     []string{"alarm_clock"},
+}
+
+// endpoint starts a push server answering with the statuses given (the last
+// one repeating) and counting the requests it receives.
+func endpoint(t *testing.T, handle func(r *http.Request), statuses ...int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := int(hits.Add(1)) - 1
+		if handle != nil {
+			handle(r)
+		}
+		w.WriteHeader(statuses[min(i, len(statuses)-1)])
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+// dispatcher returns a dispatcher retrying right away whose reports end up
+// in the returned channel.
+func dispatcher(allowPrivate bool) (*Dispatcher, chan error) {
+	reports := make(chan error, 1)
+	d := NewDispatcher([]time.Duration{time.Millisecond, time.Millisecond}, allowPrivate, func(_ Endpoint, err error) {
+		reports <- err
+	})
+	return d, reports
+}
+
+func report(t *testing.T, reports chan error) error {
+	t.Helper()
+	select {
+	case err := <-reports:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("delivery was never reported")
+		return nil
+	}
+}
+
+func TestSendUnifiedPush(t *testing.T) {
+	var got Notification
+	srv, _ := endpoint(t, func(r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode notification: %v", err)
+		}
+	}, http.StatusCreated)
+
+	d, _ := dispatcher(true)
+	if err := d.Test(context.Background(), Endpoint{Kind: UnifiedPush, URL: srv.URL}, testNotification); err != nil {
+		t.Fatalf("Test() = %v", err)
+	}
+	if got.Title != testNotification.Title || got.TaskID != testNotification.TaskID {
+		t.Errorf("received %+v, want %+v", got, testNotification)
+	}
+}
+
+func TestSendNtfyHeaders(t *testing.T) {
+	var header http.Header
+	srv, _ := endpoint(t, func(r *http.Request) { header = r.Header }, http.StatusOK)
+
+	n := testNotification
+	n.Title = "Line\r\nInjected: yes"
+	d, _ := dispatcher(true)
+	if err := d.Test(context.Background(), Endpoint{Kind: Ntfy, URL: srv.URL, Token: "tk_secret"}, n); err != nil {
+		t.Fatalf("Test() = %v", err)
+	}
+
+	for key, want := range map[string]string{
+		"Title":         "Line Injected: yes",
+		"Priority":      "4",
+		"Tags":          "alarm_clock,due",
+		"Authorization": "Bearer tk_secret",
+		"Injected":      "",
+	} {
+		if got := header.Get(key); got != want {
+			t.Errorf("header %s = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestSendGone(t *testing.T) {
+	srv, _ := endpoint(t, nil, http.StatusGone)
+	d, _ := dispatcher(true)
+	err := d.Test(context.Background(), Endpoint{Kind: Ntfy, URL: srv.URL}, testNotification)
+	if !errors.Is(err, ErrGone) {
+		t.Errorf("Test() = %v, want ErrGone", err)
+	}
+}
+
+func TestDispatchRetries(t *testing.T) {
+	srv, hits := endpoint(t, nil, http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK)
+	d, reports := dispatcher(true)
+
+	d.Dispatch(context.Background(), Endpoint{Kind: Ntfy, URL: srv.URL}, testNotification)
+	if err := report(t, reports); err != nil {
+		t.Errorf("reported %v, want a delivery", err)
+	}
+	if n := hits.Load(); n != 3 {
+		t.Errorf("endpoint got %d attempts, want 3", n)
+	}
+}
+
+func TestDispatchGivesUp(t *testing.T) {
+	srv, hits := endpoint(t, nil, http.StatusInternalServerError)
+	d, reports := dispatcher(true)
+
+	d.Dispatch(context.Background(), Endpoint{Kind: Ntfy, URL: srv.URL}, testNotification)
+	if err := report(t, reports); err == nil {
+		t.Error("reported a delivery, want a failure")
+	}
+	if n := hits.Load(); n != 3 {
+		t.Errorf("endpoint got %d attempts, want 3", n)
+	}
+}
+
+func TestDispatchGoneIsNotRetried(t *testing.T) {
+	srv, hits := endpoint(t, nil, http.StatusNotFound)
+	d, reports := dispatcher(true)
+
+	d.Dispatch(context.Background(), Endpoint{Kind: UnifiedPush, URL: srv.URL}, testNotification)
+	if err := report(t, reports); !errors.Is(err, ErrGone) {
+		t.Errorf("reported %v, want ErrGone", err)
+	}
+	if n := hits.Load(); n != 1 {
+		t.Errorf("endpoint got %d attempts, want 1", n)
+	}
+}
+
+func TestDispatchRefusesPrivateAddresses(t *testing.T) {
+	srv, hits := endpoint(t, nil, http.StatusOK)
+	d, reports := dispatcher(false)
+
+	d.Dispatch(context.Background(), Endpoint{Kind: Ntfy, URL: srv.URL}, testNotification)
+	if err := report(t, reports); !errors.Is(err, ErrPrivateAddress) {
+		t.Errorf("reported %v, want ErrPrivateAddress", err)
+	}
+	if n := hits.Load(); n != 0 {
+		t.Errorf("endpoint got %d attempts, want none", n)
+	}
+}
+
+func TestDispatchDoesNotReportBuildErrors(t *testing.T) {
+	d, reports := dispatcher(true)
+
+	d.Dispatch(context.Background(), Endpoint{Kind: Kind(42), URL: "https://push.example.com"}, testNotification)
+	deadline := time.Now().Add(5 * time.Second)
+	for len(d.inFlight) > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case err := <-reports:
+		t.Errorf("reported %v, want nothing", err)
+	default:
+	}
+}
+
+func TestPublicOnly(t *testing.T) {
+	for address, public := range map[string]bool{
+		"93.184.215.14:443":          true,
+		"[2606:4700::6810:84e5]:443": true,
+		"127.0.0.1:80":               false,
+		"10.1.2.3:80":                false,
+		"172.16.0.1:80":              false,
+		"192.168.1.10:80":            false,
+		"100.64.0.1:80":              false,
+		"169.254.169.254:80":         false,
+		"0.0.0.0:80":                 false,
+		"[::1]:80":                   false,
+		"[fd00::1]:80":               false,
+		"[fe80::1]:80":               false,
+		"[::ffff:127.0.0.1]:80":      false,
+	} {
+		err := publicOnly("tcp", address, nil)
+		if (err == nil) != public {
+			t.Errorf("publicOnly(%s) = %v, want public=%v", address, err, public)
+		}
+	}
+}
@@ -0,0 +1,711 @@
+// quickadd turns a single line of text into task data so that every client
+// doesn't need to reimplement its own natural language parser. For example:
+//
+//	buy milk tomorrow 5pm #errands !high every week
+//
+// Gets understood as a task titled "buy milk" due tomorrow at 17:00, tagged
+// "errands", with the highest priority and recurring weekly.
+//
+// Recognized tokens (anything else is part of the title):
+//   - Tags: `#name`
+//   - Priority: `!high`, `!medium`, `!low`, `!<n>` or `!!!`, `!!`, `!`
+//   - Dates: `today`, `tonight`, `tomorrow`, weekdays (`fri`, `next friday`),
+//     `next week|month|year`, `in 3 days`, `2026-10-23`, `oct 23 [2026]`,
+//     `23 october`, optionally followed by a time (`5pm`, `at 17:30`, `noon`).
+//     Dates default to the due date unless prefixed by `do:`, `start`,
+//     `starting` or `from`. `due:` and `by` force the due date.
+//   - Recurrence: `daily`, `weekly`, `monthly`, `yearly`, `every day`,
+//     `every 2 weeks`, `every other month`, `every monday`, `every weekday`.
+//     Patterns are expressed as RFC 5545 RRULEs (ex: FREQ=WEEKLY;INTERVAL=2).
+//   - Quoted text (`"friday" review`) is always part of the title.
+package quickadd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kind identifies what a portion of the input got interpreted as.
+type Kind uint8
+
+const (
+	KindDueDate Kind = iota
+	KindDoDate
+	KindTag
+	KindPriority
+	KindRecurrence
+)
+
+// Priorities associated with the named priority markers. Rafta considers 1 as
+// the highest priority and 0 as undefined.
+const (
+	PriorityHigh   uint32 = 1
+	PriorityMedium uint32 = 2
+	PriorityLow    uint32 = 3
+)
+
+// Match is a portion of the input which got interpreted as something other
+// than the title. Start and End are byte offsets within the input.
+type Match struct {
+	Start int
+	End   int
+	Kind  Kind
+}
+
+// Result holds everything understood from a line of text.
+type Result struct {
+	Title      string
+	Tags       []string
+	Priority   uint32
+	DoDate     *time.Time
+	DueDate    *time.Time
+	DoAllDay   bool // DoDate has no time of day (holds the start of the day)
+	DueAllDay  bool // DueDate has no time of day (holds the end of the day)
+	Recurrence string
+	Matches    []Match
+}
+
+type word struct {
+	text  string // lowercased text stripped of trailing punctuation
+	raw   string
+	start int
+	end   int
+}
+
+type role uint8
+
+const (
+	roleAny role = iota
+	roleDue
+	roleDo
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+var weekdaysPlural = map[string]time.Weekday{
+	"sundays": time.Sunday, "mondays": time.Monday, "tuesdays": time.Tuesday,
+	"wednesdays": time.Wednesday, "thursdays": time.Thursday,
+	"fridays": time.Friday, "saturdays": time.Saturday,
+}
+
+var rruleDays = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+var months = map[string]time.Month{
+	"jan": time.January, "january": time.January,
+	"feb": time.February, "february": time.February,
+	"mar": time.March, "march": time.March,
+	"apr": time.April, "april": time.April,
+	"may": time.May,
+	"jun": time.June, "june": time.June,
+	"jul": time.July, "july": time.July,
+	"aug": time.August, "august": time.August,
+	"sep": time.September, "sept": time.September, "september": time.September,
+	"oct": time.October, "october": time.October,
+	"nov": time.November, "november": time.November,
+	"dec": time.December, "december": time.December,
+}
+
+var frequencies = map[string]string{
+	"day": "DAILY", "days": "DAILY",
+	"week": "WEEKLY", "weeks": "WEEKLY",
+	"month": "MONTHLY", "months": "MONTHLY",
+	"year": "YEARLY", "years": "YEARLY",
+}
+
+var adverbFrequencies = map[string]string{
+	"daily":    "DAILY",
+	"weekly":   "WEEKLY",
+	"monthly":  "MONTHLY",
+	"yearly":   "YEARLY",
+	"annually": "YEARLY",
+}
+
+// Parse interprets a line of text. Relative dates are resolved from now,
+// whose location is the time zone used to interpret every date.
+func Parse(line string, now time.Time) Result {
+	p := &parser{words: split(line), now: now}
+	p.parse()
+	return p.res
+}
+
+type parser struct {
+	words []word
+	now   time.Time
+	res   Result
+	title []string
+}
+
+func (p *parser) parse() {
+	for i := 0; i < len(p.words); {
+		if n := p.quoted(i); n > 0 {
+			i += n
+			continue
+		}
+		if n := p.tag(i); n > 0 {
+			i += n
+			continue
+		}
+		if n := p.priority(i); n > 0 {
+			i += n
+			continue
+		}
+		if n := p.recurrence(i); n > 0 {
+			i += n
+			continue
+		}
+		if n := p.date(i); n > 0 {
+			i += n
+			continue
+		}
+		p.title = append(p.title, p.words[i].raw)
+		i++
+	}
+	p.res.Title = strings.Join(p.title, " ")
+}
+
+func (p *parser) match(from, to int, kind Kind) {
+	p.res.Matches = append(p.res.Matches, Match{
+		Start: p.words[from].start,
+		End:   p.words[to-1].end,
+		Kind:  kind,
+	})
+}
+
+func (p *parser) quoted(i int) int {
+	if !strings.HasPrefix(p.words[i].raw, `"`) {
+		return 0
+	}
+	for j := i; j < len(p.words); j++ {
+		raw := p.words[j].raw
+		if (j > i || len(raw) > 1) && strings.HasSuffix(raw, `"`) {
+			for k := i; k <= j; k++ {
+				raw := p.words[k].raw
+				if k == i {
+					raw = raw[1:]
+				}
+				if k == j {
+					raw = raw[:len(raw)-1]
+				}
+				if raw != "" {
+					p.title = append(p.title, raw)
+				}
+			}
+			return j - i + 1
+		}
+	}
+	return 0
+}
+
+func (p *parser) tag(i int) int {
+	w := p.words[i]
+	if len(w.raw) < 2 || w.raw[0] != '#' {
+		return 0
+	}
+	tag := strings.TrimRight(w.raw[1:], ".,;:!?")
+	if tag == "" {
+		return 0
+	}
+	for _, t := range p.res.Tags {
+		if t == tag {
+			p.match(i, i+1, KindTag)
+			return 1
+		}
+	}
+	p.res.Tags = append(p.res.Tags, tag)
+	p.match(i, i+1, KindTag)
+	return 1
+}
+
+func (p *parser) priority(i int) int {
+	w := p.words[i].raw
+	if !strings.HasPrefix(w, "!") {
+		return 0
+	}
+	var prio uint32
+	switch strings.ToLower(w) {
+	case "!!!", "!high", "!h":
+		prio = PriorityHigh
+	case "!!", "!medium", "!med", "!m":
+		prio = PriorityMedium
+	case "!", "!low", "!l":
+		prio = PriorityLow
+	default:
+		n, err := strconv.ParseUint(w[1:], 10, 32)
+		if err != nil || n == 0 {
+			return 0
+		}
+		prio = uint32(n)
+	}
+	p.res.Priority = prio
+	p.match(i, i+1, KindPriority)
+	return 1
+}
+
+func (p *parser) recurrence(i int) int {
+	if p.res.Recurrence != "" {
+		return 0
+	}
+	w := p.words[i].text
+	if freq, ok := adverbFrequencies[w]; ok {
+		p.res.Recurrence = "FREQ=" + freq
+		p.match(i, i+1, KindRecurrence)
+		return 1
+	}
+	if w != "every" || i+1 >= len(p.words) {
+		return 0
+	}
+
+	j := i + 1
+	interval := 1
+	switch next := p.words[j].text; {
+	case next == "other":
+		interval = 2
+		j++
+	case isNumber(next):
+		interval, _ = strconv.Atoi(next)
+		j++
+	}
+	if j >= len(p.words) || interval < 1 {
+		return 0
+	}
+
+	unit := p.words[j].text
+	var rule string
+	switch {
+	case frequencies[unit] != "":
+		rule = "FREQ=" + frequencies[unit]
+	case unit == "weekday" || unit == "weekdays":
+		rule = "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"
+	case unit == "weekend" || unit == "weekends":
+		rule = "FREQ=WEEKLY;BYDAY=SA,SU"
+	default:
+		day, ok := weekdays[unit]
+		if !ok {
+			day, ok = weekdaysPlural[unit]
+		}
+		if !ok {
+			return 0
+		}
+		rule = "FREQ=WEEKLY;BYDAY=" + rruleDays[day]
+		// Recurring on a weekday implies the first occurrence when no date
+		// is provided.
+		if p.res.DueDate == nil {
+			due := endOfDay(nextWeekday(p.now, day, false))
+			p.res.DueDate, p.res.DueAllDay = &due, true
+		}
+	}
+	if interval > 1 {
+		rule = fmt.Sprintf("%s;INTERVAL=%d", rule, interval)
+	}
+
+	p.res.Recurrence = rule
+	p.match(i, j+1, KindRecurrence)
+	return j - i + 1
+}
+
+// date parses an optional role prefix, a date and an optional time of day.
+func (p *parser) date(i int) int {
+	r := roleAny
+	j := i
+	w := p.words[j]
+
+	// Inline prefixes (ex: due:friday, do:2026-10-23)
+	inline := ""
+	switch {
+	case strings.HasPrefix(w.text, "due:"):
+		r, inline = roleDue, w.text[len("due:"):]
+	case strings.HasPrefix(w.text, "do:"):
+		r, inline = roleDo, w.text[len("do:"):]
+	}
+	if inline != "" {
+		day, hasTime, ok := p.singleDate(inline)
+		if !ok {
+			return 0
+		}
+		j++
+		if !hasTime {
+			if t, n := p.timeOfDay(j); n > 0 {
+				day, hasTime = at(day, t), true
+				j += n
+			}
+		}
+		return p.setDate(i, j, r, day, hasTime)
+	}
+
+	switch w.text {
+	case "due", "by", "before":
+		r = roleDue
+		j++
+	case "do", "start", "starting", "from":
+		r = roleDo
+		j++
+	case "on":
+		j++
+	}
+	if j >= len(p.words) {
+		return 0
+	}
+
+	day, hasTime, n := p.dateExpr(j)
+	if n == 0 {
+		// A lone time of day is for today (ex: "call bob at 5pm")
+		t, n := p.timeOfDay(j)
+		if n == 0 {
+			return 0
+		}
+		j += n
+		// The time might precede the date (ex: "5pm tomorrow")
+		if d, _, m := p.dateExpr(j); m > 0 {
+			return p.setDate(i, j+m, r, at(d, t), true)
+		}
+		return p.setDate(i, j, r, at(p.now, t), true)
+	}
+	j += n
+	if !hasTime {
+		if t, n := p.timeOfDay(j); n > 0 {
+			day, hasTime = at(day, t), true
+			j += n
+		}
+	}
+	return p.setDate(i, j, r, day, hasTime)
+}
+
+func (p *parser) setDate(from, to int, r role, day time.Time, hasTime bool) int {
+	if r == roleAny {
+		switch {
+		case p.res.DueDate == nil:
+			r = roleDue
+		case p.res.DoDate == nil:
+			r = roleDo
+		default:
+			return 0
+		}
+	}
+	switch r {
+	case roleDue:
+		if !hasTime {
+			day = endOfDay(day)
+		}
+		p.res.DueDate, p.res.DueAllDay = &day, !hasTime
+		p.match(from, to, KindDueDate)
+	case roleDo:
+		if !hasTime {
+			day = startOfDay(day)
+		}
+		p.res.DoDate, p.res.DoAllDay = &day, !hasTime
+		p.match(from, to, KindDoDate)
+	}
+	return to - from
+}
+
+// dateExpr parses a date starting at word i. It returns the date, whether a
+// time of day was part of the expression and the amount of words consumed.
+func (p *parser) dateExpr(i int) (time.Time, bool, int) {
+	if i >= len(p.words) {
+		return time.Time{}, false, 0
+	}
+	w := p.words[i].text
+
+	if day, hasTime, ok := p.singleDate(w); ok {
+		return day, hasTime, 1
+	}
+
+	next := ""
+	if i+1 < len(p.words) {
+		next = p.words[i+1].text
+	}
+
+	switch w {
+	case "next":
+		if day, ok := weekdays[next]; ok {
+			return nextWeekday(p.now, day, true), false, 2
+		}
+		switch next {
+		case "week":
+			return nextWeekday(p.now, time.Monday, true), false, 2
+		case "month":
+			y, m, _ := p.now.Date()
+			return time.Date(y, m+1, 1, 0, 0, 0, 0, p.now.Location()), false, 2
+		case "year":
+			return time.Date(p.now.Year()+1, time.January, 1, 0, 0, 0, 0, p.now.Location()), false, 2
+		}
+		return time.Time{}, false, 0
+	case "in":
+		return p.relative(i + 1)
+	}
+
+	// Month names (ex: "oct 23", "october 23rd 2026")
+	if month, ok := months[w]; ok {
+		day, ok := ordinal(next)
+		if !ok {
+			return time.Time{}, false, 0
+		}
+		year, n := p.year(i + 2)
+		t, ok := p.monthDay(year, month, day)
+		if !ok {
+			return time.Time{}, false, 0
+		}
+		return t, false, 2 + n
+	}
+	// Day first (ex: "23 oct", "23rd of october 2026")
+	if day, ok := ordinal(w); ok {
+		j := i + 1
+		if next == "of" {
+			j++
+		}
+		if j >= len(p.words) {
+			return time.Time{}, false, 0
+		}
+		month, ok := months[p.words[j].text]
+		if !ok {
+			return time.Time{}, false, 0
+		}
+		year, n := p.year(j + 1)
+		t, ok := p.monthDay(year, month, day)
+		if !ok {
+			return time.Time{}, false, 0
+		}
+		return t, false, j - i + 1 + n
+	}
+
+	return time.Time{}, false, 0
+}
+
+// singleDate parses dates expressed as a single word.
+func (p *parser) singleDate(w string) (time.Time, bool, bool) {
+	switch w {
+	case "today":
+		return p.now, false, true
+	case "tonight":
+		return at(p.now, 20*time.Hour), true, true
+	case "tomorrow", "tmr", "tmrw":
+		return p.now.AddDate(0, 0, 1), false, true
+	}
+	if day, ok := weekdays[w]; ok {
+		return nextWeekday(p.now, day, false), false, true
+	}
+	loc := p.now.Location()
+	if t, err := time.ParseInLocation("2006-01-02", w, loc); err == nil {
+		return t, false, true
+	}
+	for _, layout := range []string{"2006-01-02t15:04", "2006-01-02t15:04:05"} {
+		if t, err := time.ParseInLocation(layout, w, loc); err == nil {
+			return t, true, true
+		}
+	}
+	return time.Time{}, false, false
+}
+
+// relative parses "<n> <unit>" (ex: "3 days", "an hour") following "in".
+func (p *parser) relative(i int) (time.Time, bool, int) {
+	if i+1 >= len(p.words) {
+		return time.Time{}, false, 0
+	}
+	amount := 0
+	switch w := p.words[i].text; {
+	case w == "a" || w == "an":
+		amount = 1
+	case isNumber(w):
+		amount, _ = strconv.Atoi(w)
+	default:
+		return time.Time{}, false, 0
+	}
+
+	switch p.words[i+1].text {
+	case "minute", "minutes", "min", "mins":
+		return p.now.Add(time.Duration(amount) * time.Minute), true, 3
+	case "hour", "hours", "hr", "hrs":
+		return p.now.Add(time.Duration(amount) * time.Hour), true, 3
+	case "day", "days":
+		return p.now.AddDate(0, 0, amount), false, 3
+	case "week", "weeks":
+		return p.now.AddDate(0, 0, 7*amount), false, 3
+	case "month", "months":
+		return p.now.AddDate(0, amount, 0), false, 3
+	case "year", "years":
+		return p.now.AddDate(amount, 0, 0), false, 3
+	}
+	return time.Time{}, false, 0
+}
+
+// year parses an optional year at word i.
+func (p *parser) year(i int) (int, int) {
+	if i < len(p.words) && len(p.words[i].text) == 4 && isNumber(p.words[i].text) {
+		y, _ := strconv.Atoi(p.words[i].text)
+		return y, 1
+	}
+	return 0, 0
+}
+
+// monthDay resolves a day of the year. Without a year, the next occurrence
+// of that day is used (ex: feb 29 is in the next leap year). Days the month
+// doesn't have (ex: apr 31) aren't dates.
+func (p *parser) monthDay(year int, month time.Month, day int) (time.Time, bool) {
+	loc := p.now.Location()
+	if year != 0 {
+		t := time.Date(year, month, day, 0, 0, 0, 0, loc)
+		return t, t.Day() == day
+	}
+	// Leap days come back at least every 8 years
+	for y := p.now.Year(); y <= p.now.Year()+8; y++ {
+		t := time.Date(y, month, day, 0, 0, 0, 0, loc)
+		if t.Day() == day && !t.Before(startOfDay(p.now)) {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// timeOfDay parses a time (ex: "5pm", "5:30 pm", "at 17:00", "noon") at word
+// i. It returns the offset from midnight and the amount of words consumed.
+func (p *parser) timeOfDay(i int) (time.Duration, int) {
+	if i >= len(p.words) {
+		return 0, 0
+	}
+	j := i
+	if p.words[j].text == "at" || p.words[j].text == "@" {
+		j++
+		if j >= len(p.words) {
+			return 0, 0
+		}
+	}
+
+	w := p.words[j].text
+	switch w {
+	case "noon", "midday":
+		return 12 * time.Hour, j - i + 1
+	case "midnight":
+		return 0, j - i + 1
+	}
+
+	meridiem := ""
+	switch {
+	case strings.HasSuffix(w, "am"), strings.HasSuffix(w, "pm"):
+		meridiem, w = w[len(w)-2:], w[:len(w)-2]
+	case j+1 < len(p.words) && (p.words[j+1].text == "am" || p.words[j+1].text == "pm"):
+		meridiem = p.words[j+1].text
+		j++
+	}
+
+	hourStr, minStr, hasMin := strings.Cut(w, ":")
+	if !isNumber(hourStr) || (hasMin && (len(minStr) != 2 || !isNumber(minStr))) {
+		return 0, 0
+	}
+	// A bare number (ex: "3") is too ambiguous to be a time.
+	if !hasMin && meridiem == "" {
+		return 0, 0
+	}
+	hour, _ := strconv.Atoi(hourStr)
+	minute := 0
+	if hasMin {
+		minute, _ = strconv.Atoi(minStr)
+	}
+
+	switch meridiem {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0
+		}
+		hour %= 12
+		if meridiem == "pm" {
+			hour += 12
+		}
+	default:
+		if hour > 23 {
+			return 0, 0
+		}
+	}
+	if minute > 59 {
+		return 0, 0
+	}
+
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute, j - i + 1
+}
+
+func split(line string) []word {
+	var words []word
+	start := -1
+	for i, r := range line + " " {
+		isSpace := r == ' ' || r == '\t' || r == '\n' || r == '\r'
+		switch {
+		case isSpace && start >= 0:
+			raw := line[start:i]
+			words = append(words, word{
+				raw:   raw,
+				text:  strings.TrimRight(strings.ToLower(raw), ".,;"),
+				start: start,
+				end:   i,
+			})
+			start = -1
+		case !isSpace && start < 0:
+			start = i
+		}
+	}
+	return words
+}
+
+func ordinal(w string) (int, bool) {
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		w = strings.TrimSuffix(w, suffix)
+	}
+	if !isNumber(w) {
+		return 0, false
+	}
+	day, _ := strconv.Atoi(w)
+	return day, day >= 1 && day <= 31
+}
+
+func isNumber(w string) bool {
+	if w == "" {
+		return false
+	}
+	for _, r := range w {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// nextWeekday returns the next occurrence of a weekday. Unless strict is set,
+// today counts as an occurrence.
+func nextWeekday(now time.Time, day time.Weekday, strict bool) time.Time {
+	delta := (int(day) - int(now.Weekday()) + 7) % 7
+	if delta == 0 && strict {
+		delta = 7
+	}
+	return now.AddDate(0, 0, delta)
+}
+
+// at returns the moment the wall clock shows a time of day (offset since
+// midnight) on a day, so that "5pm" means 17:00 even on the day clocks
+// change.
+func at(day time.Time, offset time.Duration) time.Time {
+	y, m, d := day.Date()
+	return time.Date(y, m, d,
+		int(offset/time.Hour), int(offset%time.Hour/time.Minute), int(offset%time.Minute/time.Second),
+		0, day.Location(),
+	)
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// endOfDay is used for due dates without a time: the task is due at any
+// point during that day.
+func endOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 23, 59, 59, 0, t.Location())
+}
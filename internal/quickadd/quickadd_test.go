@@ -0,0 +1,202 @@
+package quickadd
+
+import (
+	"slices"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func toronto(t *testing.T) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation("America/Toronto")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+	return loc
+}
+
+// format renders an optional date for comparisons ("" when unset).
+func format(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func TestParse(t *testing.T) {
+	loc := toronto(t)
+	// A Wednesday, the Sunday after it is when DST starts
+	now := time.Date(2026, time.March, 4, 10, 0, 0, 0, loc)
+
+	for _, tt := range []struct {
+		line       string
+		title      string
+		due, do    string
+		dueAllDay  bool
+		doAllDay   bool
+		tags       []string
+		priority   uint32
+		recurrence string
+	}{
+		{
+			line:       "buy milk tomorrow 5pm #errands !high every week",
+			title:      "buy milk",
+			due:        "2026-03-05T17:00:00-05:00",
+			tags:       []string{"errands"},
+			priority:   PriorityHigh,
+			recurrence: "FREQ=WEEKLY",
+		},
+		{line: "call bob", title: "call bob"},
+
+		// Relative days
+		{line: "pay rent today", title: "pay rent", due: "2026-03-04T23:59:59-05:00", dueAllDay: true},
+		{line: "tonight party", title: "party", due: "2026-03-04T20:00:00-05:00"},
+		{line: "tmrw", due: "2026-03-05T23:59:59-05:00", dueAllDay: true},
+		{line: "in 3 days", due: "2026-03-07T23:59:59-05:00", dueAllDay: true},
+		{line: "in 2 weeks at noon", due: "2026-03-18T12:00:00-04:00"},
+		{line: "in an hour", due: "2026-03-04T11:00:00-05:00"},
+		{line: "in 3 apples", title: "in 3 apples"},
+
+		// Weekdays
+		{line: "report wed", title: "report", due: "2026-03-04T23:59:59-05:00", dueAllDay: true},
+		{line: "report next wed", title: "report", due: "2026-03-11T23:59:59-04:00", dueAllDay: true},
+		{line: "report friday 9:30am", title: "report", due: "2026-03-06T09:30:00-05:00"},
+		{line: "start next week", do: "2026-03-09T00:00:00-04:00", doAllDay: true},
+		{
+			line:      "plan do:monday due:fri",
+			title:     "plan",
+			due:       "2026-03-06T23:59:59-05:00",
+			dueAllDay: true,
+			do:        "2026-03-09T00:00:00-04:00",
+			doAllDay:  true,
+		},
+
+		// Month and day
+		{line: "dentist 2026-03-10 at 9:30", title: "dentist", due: "2026-03-10T09:30:00-04:00"},
+		{line: "party 4 march", title: "party", due: "2026-03-04T23:59:59-05:00", dueAllDay: true},
+		{line: "party march 3rd", title: "party", due: "2027-03-03T23:59:59-05:00", dueAllDay: true},
+		{line: "taxes 15th of jan", title: "taxes", due: "2027-01-15T23:59:59-05:00", dueAllDay: true},
+		{line: "taxes jan 15 2026", title: "taxes", due: "2026-01-15T23:59:59-05:00", dueAllDay: true},
+		{line: "dec 31 review", title: "review", due: "2026-12-31T23:59:59-05:00", dueAllDay: true},
+		{line: "next month", due: "2026-04-01T23:59:59-04:00", dueAllDay: true},
+		{line: "next year", due: "2027-01-01T23:59:59-05:00", dueAllDay: true},
+		{line: "in 1 month", due: "2026-04-04T23:59:59-04:00", dueAllDay: true},
+		{line: "leap day feb 29", title: "leap day", due: "2028-02-29T23:59:59-05:00", dueAllDay: true},
+
+		// Days the month doesn't have
+		{line: "review feb 30", title: "review feb 30"},
+		{line: "review apr 31st", title: "review apr 31st"},
+		{line: "review 31 of april 2026", title: "review 31 of april 2026"},
+		{line: "review feb 29 2027", title: "review feb 29 2027"},
+		{line: "review 2026-02-30", title: "review 2026-02-30"},
+		{line: "review 2026-02-28t25:00", title: "review 2026-02-28t25:00"},
+		{line: "review at 13pm", title: "review at 13pm"},
+
+		// Tags and priority
+		{line: "#a task #b #a", title: "task", tags: []string{"a", "b"}},
+		{line: "# not a tag", title: "# not a tag"},
+		{line: "urgent !!", title: "urgent", priority: PriorityMedium},
+		{line: "urgent !", title: "urgent", priority: PriorityLow},
+		{line: "urgent !5", title: "urgent", priority: 5},
+		{line: "urgent !0", title: "urgent !0"},
+
+		// Recurrence and quotes
+		{line: "standup every weekday 9am", title: "standup", due: "2026-03-04T09:00:00-05:00", recurrence: "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"},
+		{line: "trash every other monday", title: "trash", due: "2026-03-09T23:59:59-04:00", dueAllDay: true, recurrence: "FREQ=WEEKLY;BYDAY=MO;INTERVAL=2"},
+		{line: `"friday" review`, title: "friday review"},
+	} {
+		t.Run(tt.line, func(t *testing.T) {
+			got := Parse(tt.line, now)
+			if got.Title != tt.title {
+				t.Errorf("title = %q, want %q", got.Title, tt.title)
+			}
+			if due := format(got.DueDate); due != tt.due || got.DueAllDay != tt.dueAllDay {
+				t.Errorf("due = %q (all day: %v), want %q (all day: %v)", due, got.DueAllDay, tt.due, tt.dueAllDay)
+			}
+			if do := format(got.DoDate); do != tt.do || got.DoAllDay != tt.doAllDay {
+				t.Errorf("do = %q (all day: %v), want %q (all day: %v)", do, got.DoAllDay, tt.do, tt.doAllDay)
+			}
+			if !slices.Equal(got.Tags, tt.tags) {
+				t.Errorf("tags = %v, want %v", got.Tags, tt.tags)
+			}
+			if got.Priority != tt.priority {
+				t.Errorf("priority = %d, want %d", got.Priority, tt.priority)
+			}
+			if got.Recurrence != tt.recurrence {
+				t.Errorf("recurrence = %q, want %q", got.Recurrence, tt.recurrence)
+			}
+		})
+	}
+}
+
+func TestParseAcrossDST(t *testing.T) {
+	loc := toronto(t)
+
+	for _, tt := range []struct {
+		name string
+		now  time.Time
+		line string
+		due  string
+	}{
+		{
+			name: "time of day the day DST starts",
+			now:  time.Date(2026, time.March, 7, 22, 0, 0, 0, loc),
+			line: "tomorrow 9am",
+			due:  "2026-03-08T09:00:00-04:00",
+		},
+		{
+			name: "time of day the day DST ends",
+			now:  time.Date(2026, time.October, 31, 22, 0, 0, 0, loc),
+			line: "sunday at 17:00",
+			due:  "2026-11-01T17:00:00-05:00",
+		},
+		{
+			name: "lone time of day the day DST starts",
+			now:  time.Date(2026, time.March, 8, 0, 30, 0, 0, loc),
+			line: "at 6pm",
+			due:  "2026-03-08T18:00:00-04:00",
+		},
+		{
+			name: "days across DST",
+			now:  time.Date(2026, time.March, 7, 10, 0, 0, 0, loc),
+			line: "in 2 days",
+			due:  "2026-03-09T23:59:59-04:00",
+		},
+		{
+			name: "hours across DST",
+			now:  time.Date(2026, time.November, 1, 0, 30, 0, 0, loc),
+			line: "in 2 hours",
+			due:  "2026-11-01T01:30:00-05:00",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse(tt.line, tt.now)
+			if due := format(got.DueDate); due != tt.due {
+				t.Errorf("Parse(%q) due = %q, want %q", tt.line, due, tt.due)
+			}
+		})
+	}
+}
+
+func TestParseMatches(t *testing.T) {
+	line := "buy milk due:fri #errands !!"
+	got := Parse(line, time.Date(2026, time.March, 4, 10, 0, 0, 0, time.UTC))
+
+	want := []struct {
+		text string
+		kind Kind
+	}{
+		{"due:fri", KindDueDate},
+		{"#errands", KindTag},
+		{"!!", KindPriority},
+	}
+	if len(got.Matches) != len(want) {
+		t.Fatalf("matches = %v, want %d of them", got.Matches, len(want))
+	}
+	for i, m := range got.Matches {
+		if text := line[m.Start:m.End]; text != want[i].text || m.Kind != want[i].kind {
+			t.Errorf("match %d = %q (%v), want %q (%v)", i, text, m.Kind, want[i].text, want[i].kind)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// template implements the small languages used by task templates:
+//
+//   - Placeholders (`{{version}}`) substituted in text when a template gets
+//     instantiated.
+//   - Relative dates (`+3d`, `+1w2d@17:00`, `-2h`) computed from the moment a
+//     template gets instantiated.
+package template
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMissingVariable = errors.New("missing template variable")
+	ErrInvalidOffset   = errors.New("invalid relative date")
+)
+
+var placeholderRe = regexp.MustCompile(`{{\s*([A-Za-z0-9_.-]+)\s*}}`)
+
+// Placeholders lists the distinct variable names used in the given texts in
+// order of appearance.
+func Placeholders(texts ...string) []string {
+	var names []string
+	for _, text := range texts {
+		for _, match := range placeholderRe.FindAllStringSubmatch(text, -1) {
+			if !slices.Contains(names, match[1]) {
+				names = append(names, match[1])
+			}
+		}
+	}
+	return names
+}
+
+// Expand substitutes every placeholder of a text with its value. Every
+// placeholder must have a value.
+func Expand(text string, vars map[string]string) (string, error) {
+	var missing []string
+	expanded := placeholderRe.ReplaceAllStringFunc(text, func(match string) string {
+		name := placeholderRe.FindStringSubmatch(match)[1]
+		value, ok := vars[name]
+		if !ok {
+			if !slices.Contains(missing, name) {
+				missing = append(missing, name)
+			}
+			return match
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("%w: %s", ErrMissingVariable, strings.Join(missing, ", "))
+	}
+	return expanded, nil
+}
+
+// Offset is a date relative to an anchor (usually when a template gets
+// instantiated).
+type Offset struct {
+	Years, Months, Days int
+	Duration            time.Duration
+	// TimeOfDay (offset from midnight) set by the `@HH:MM` suffix.
+	TimeOfDay *time.Duration
+}
+
+// DateOnly reports whether the offset designates a whole day rather than a
+// precise moment.
+func (o Offset) DateOnly() bool {
+	return o.Duration == 0 && o.TimeOfDay == nil
+}
+
+// From applies the offset to an anchor. Calendar units are applied in the
+// anchor's location so that days stay days across DST changes.
+func (o Offset) From(anchor time.Time) time.Time {
+	t := anchor.AddDate(o.Years, o.Months, o.Days).Add(o.Duration)
+	if o.TimeOfDay != nil {
+		// @17:00 reads the clock of the resulting day, whatever its offset
+		// from UTC (which differs from the anchor's across a DST change)
+		y, m, d := t.Date()
+		tod := *o.TimeOfDay
+		t = time.Date(y, m, d, int(tod/time.Hour), int(tod%time.Hour/time.Minute), 0, 0, t.Location())
+	}
+	return t
+}
+
+// ParseOffset parses a relative date made of a sign followed by one or more
+// amounts with a unit and an optional time of day:
+//
+//	+3d        three days later
+//	+1w2d      nine days later
+//	+1mo@9:00  a month later at 09:00
+//	-2h        two hours earlier
+//	+0d@17:00  the same day at 17:00
+//
+// Units are m (minutes), h (hours), d (days), w (weeks), mo (months) and y
+// (years).
+func ParseOffset(expr string) (Offset, error) {
+	var o Offset
+	s := strings.TrimSpace(expr)
+
+	sign := 1
+	switch {
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	case strings.HasPrefix(s, "-"):
+		sign, s = -1, s[1:]
+	default:
+		return o, fmt.Errorf("%w '%s': must start with + or -", ErrInvalidOffset, expr)
+	}
+
+	s, timeOfDay, hasTime := strings.Cut(s, "@")
+	if hasTime {
+		hour, minute, ok := strings.Cut(timeOfDay, ":")
+		h, errH := strconv.Atoi(hour)
+		min, errM := strconv.Atoi(minute)
+		if !ok || errH != nil || errM != nil || h < 0 || h > 23 || min < 0 || min > 59 {
+			return o, fmt.Errorf("%w '%s': time of day must be HH:MM", ErrInvalidOffset, expr)
+		}
+		tod := time.Duration(h)*time.Hour + time.Duration(min)*time.Minute
+		o.TimeOfDay = &tod
+	}
+
+	if s == "" {
+		return o, fmt.Errorf("%w '%s': missing amount", ErrInvalidOffset, expr)
+	}
+	for s != "" {
+		i := 0
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		j := i
+		for j < len(s) && (s[j] < '0' || s[j] > '9') {
+			j++
+		}
+		n, err := strconv.Atoi(s[:i])
+		if err != nil {
+			return o, fmt.Errorf("%w '%s': expected an amount", ErrInvalidOffset, expr)
+		}
+		n *= sign
+		switch s[i:j] {
+		case "m":
+			o.Duration += time.Duration(n) * time.Minute
+		case "h":
+			o.Duration += time.Duration(n) * time.Hour
+		case "d":
+			o.Days += n
+		case "w":
+			o.Days += 7 * n
+		case "mo":
+			o.Months += n
+		case "y":
+			o.Years += n
+		default:
+			return o, fmt.Errorf("%w '%s': unknown unit '%s'", ErrInvalidOffset, expr, s[i:j])
+		}
+		s = s[j:]
+	}
+
+	return o, nil
+}
@@ -0,0 +1,144 @@
+package template
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestParseOffset(t *testing.T) {
+	hours := func(h, m int) *time.Duration {
+		d := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute
+		return &d
+	}
+
+	for _, tt := range []struct {
+		expr string
+		want Offset
+	}{
+		{expr: "+3d", want: Offset{Days: 3}},
+		{expr: "+1w2d@17:00", want: Offset{Days: 9, TimeOfDay: hours(17, 0)}},
+		{expr: "+1mo@9:00", want: Offset{Months: 1, TimeOfDay: hours(9, 0)}},
+		{expr: "-2h", want: Offset{Duration: -2 * time.Hour}},
+		{expr: "-1d2h", want: Offset{Days: -1, Duration: -2 * time.Hour}},
+		{expr: "+0d@17:00", want: Offset{TimeOfDay: hours(17, 0)}},
+		{expr: "+0d@00:00", want: Offset{TimeOfDay: hours(0, 0)}},
+		{expr: "+1y2mo3w4d5h6m", want: Offset{Years: 1, Months: 2, Days: 25, Duration: 5*time.Hour + 6*time.Minute}},
+		{expr: " +3d ", want: Offset{Days: 3}},
+		{expr: "+2d@23:59", want: Offset{Days: 2, TimeOfDay: hours(23, 59)}},
+	} {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := ParseOffset(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseOffset(%q) = %v", tt.expr, err)
+			}
+			if got.Years != tt.want.Years || got.Months != tt.want.Months ||
+				got.Days != tt.want.Days || got.Duration != tt.want.Duration ||
+				(got.TimeOfDay == nil) != (tt.want.TimeOfDay == nil) ||
+				(got.TimeOfDay != nil && *got.TimeOfDay != *tt.want.TimeOfDay) {
+				t.Errorf("ParseOffset(%q) = %+v, want %+v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseOffsetRejects(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"3d",
+		"+",
+		"-",
+		"+3",
+		"+3x",
+		"+d",
+		"+3d4",
+		"@24:00",
+		"+1d@24:00",
+		"+1d@12:60",
+		"+1d@-1:00",
+		"+1d@9",
+		"+1d@",
+		"+@9:00",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			if o, err := ParseOffset(expr); !errors.Is(err, ErrInvalidOffset) {
+				t.Errorf("ParseOffset(%q) = %+v, %v, want ErrInvalidOffset", expr, o, err)
+			}
+		})
+	}
+}
+
+func TestOffsetDateOnly(t *testing.T) {
+	for expr, want := range map[string]bool{
+		"+3d":       true,
+		"+1mo":      true,
+		"+3d@09:00": false,
+		"+2h":       false,
+		"+1d2h":     false,
+	} {
+		o, err := ParseOffset(expr)
+		if err != nil {
+			t.Fatalf("ParseOffset(%q) = %v", expr, err)
+		}
+		if o.DateOnly() != want {
+			t.Errorf("ParseOffset(%q).DateOnly() = %v, want %v", expr, o.DateOnly(), want)
+		}
+	}
+}
+
+func TestOffsetFromAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/Toronto")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+	// Clocks go forward on 2026-03-08 and back on 2026-11-01
+	spring := time.Date(2026, time.March, 7, 10, 0, 0, 0, loc)
+	fall := time.Date(2026, time.October, 31, 12, 0, 0, 0, loc)
+
+	for _, tt := range []struct {
+		expr   string
+		anchor time.Time
+		want   string
+	}{
+		{"+1d", spring, "2026-03-08T10:00:00-04:00"},
+		{"+24h", spring, "2026-03-08T11:00:00-04:00"},
+		{"+1d@09:00", spring, "2026-03-08T09:00:00-04:00"},
+		{"+1w@17:30", spring, "2026-03-14T17:30:00-04:00"},
+		{"+1d", fall, "2026-11-01T12:00:00-05:00"},
+		{"+24h", fall, "2026-11-01T11:00:00-05:00"},
+		{"+1d@00:00", fall, "2026-11-01T00:00:00-04:00"},
+		{"+1d@17:00", fall, "2026-11-01T17:00:00-05:00"},
+		{"-1d@17:00", time.Date(2026, time.November, 1, 12, 0, 0, 0, loc), "2026-10-31T17:00:00-04:00"},
+	} {
+		t.Run(tt.expr, func(t *testing.T) {
+			o, err := ParseOffset(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseOffset(%q) = %v", tt.expr, err)
+			}
+			if got := o.From(tt.anchor).Format(time.RFC3339); got != tt.want {
+				t.Errorf("%q from %v = %s, want %s", tt.expr, tt.anchor, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaceholders(t *testing.T) {
+	got := Placeholders("Release {{version}} to {{ env }}", "{{version}} notes {{bad name}}", "{{a.b-c_1}}")
+	if want := []string{"version", "env", "a.b-c_1"}; !slices.Equal(got, want) {
+		t.Errorf("Placeholders() = %v, want %v", got, want)
+	}
+}
+
+func TestExpand(t *testing.T) {
+	got, err := Expand("Release {{version}} ({{ version }})", map[string]string{"version": "1.2"})
+	if err != nil || got != "Release 1.2 (1.2)" {
+		t.Errorf("Expand() = %q, %v", got, err)
+	}
+
+	_, err = Expand("{{a}} {{b}} {{a}}", map[string]string{"b": ""})
+	if !errors.Is(err, ErrMissingVariable) || err.Error() != "missing template variable: a" {
+		t.Errorf("Expand() = %v, want the missing variable", err)
+	}
+}
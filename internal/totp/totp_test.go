@@ -0,0 +1,106 @@
+package totp
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Secret of the RFC 6238 test vectors ("12345678901234567890" in ASCII)
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	// SHA1 vectors of RFC 6238 appendix B, truncated to their last 6 digits
+	// like the 6 digit codes they'd be
+	for _, tt := range []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	} {
+		got, err := Code(rfcSecret, Step(time.Unix(tt.unix, 0)))
+		if err != nil || got != tt.code {
+			t.Errorf("Code() at %d = %q, %v, want %q", tt.unix, got, err, tt.code)
+		}
+	}
+
+	got, err := Code(strings.ToLower(rfcSecret), 1)
+	if err != nil || got != "287082" {
+		t.Errorf("Code() with a lower case secret = %q, %v", got, err)
+	}
+	if _, err := Code("not base32!", 1); !errors.Is(err, errInvalidSecret) {
+		t.Errorf("Code() with an invalid secret = %v, want errInvalidSecret", err)
+	}
+}
+
+func TestValidateSkew(t *testing.T) {
+	at := time.Unix(1111111111, 0)
+	current := Step(at)
+
+	for offset := int64(-3); offset <= 3; offset++ {
+		code, err := Code(rfcSecret, current+offset)
+		if err != nil {
+			t.Fatalf("Code() = %v", err)
+		}
+		step, err := Validate(rfcSecret, code, at)
+		if offset >= -skew && offset <= skew {
+			if err != nil || step != current+offset {
+				t.Errorf("Validate() of step %+d = %d, %v, want step %d", offset, step, err, current+offset)
+			}
+		} else if !errors.Is(err, ErrInvalidCode) {
+			t.Errorf("Validate() of step %+d = %d, %v, want ErrInvalidCode", offset, step, err)
+		}
+	}
+}
+
+func TestValidateInput(t *testing.T) {
+	at := time.Unix(1111111111, 0)
+
+	if step, err := Validate(rfcSecret, "050 471", at); err != nil || step != Step(at) {
+		t.Errorf("Validate() with a space = %d, %v", step, err)
+	}
+	for _, code := range []string{"", "50471", "0504710", "abcdef", "14050471"} {
+		if _, err := Validate(rfcSecret, code, at); !errors.Is(err, ErrInvalidCode) {
+			t.Errorf("Validate(%q) = %v, want ErrInvalidCode", code, err)
+		}
+	}
+	if _, err := Validate("not base32!", "050471", at); !errors.Is(err, errInvalidSecret) {
+		t.Errorf("Validate() with an invalid secret = %v, want errInvalidSecret", err)
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret() = %v", err)
+	}
+	key, err := encoding.DecodeString(secret)
+	if err != nil || len(key) != secretSize {
+		t.Errorf("GenerateSecret() = %q, which decodes to %d bytes (%v)", secret, len(key), err)
+	}
+	if other, _ := GenerateSecret(); other == secret {
+		t.Errorf("GenerateSecret() returned %q twice", secret)
+	}
+}
+
+func TestURI(t *testing.T) {
+	uri, err := url.Parse(URI("Rafta", "jane doe@example.com", rfcSecret))
+	if err != nil {
+		t.Fatalf("failed to parse URI: %v", err)
+	}
+	if uri.Scheme != "otpauth" || uri.Host != "totp" || uri.Path != "/Rafta:jane doe@example.com" {
+		t.Errorf("URI() = %v", uri)
+	}
+	q := uri.Query()
+	if q.Get("secret") != rfcSecret || q.Get("issuer") != "Rafta" ||
+		q.Get("digits") != "6" || q.Get("period") != "30" {
+		t.Errorf("URI() has parameters %v", q)
+	}
+}
@@ -2,9 +2,13 @@ package util
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"log/slog"
 	"time"
 
+	"github.com/ChausseBenjamin/rafta/internal/directory"
+	"github.com/ChausseBenjamin/rafta/internal/oidc"
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -37,6 +41,11 @@ type ConfigStore struct {
 	JWTRefreshTTL time.Duration
 	DBCacheSize   int
 	ArgonThreads  uint
+	PushPrivate   bool              // Push endpoints may resolve to non-public addresses
+	TLS           *tls.Config       // nil when serving plaintext
+	ClientCAs     *x509.CertPool    // Issuers of client certificates (nil for none)
+	OIDC          *oidc.Config      // nil when single sign-on is disabled
+	LDAP          *directory.Config // nil when users log in with local passwords
 }
 
 func GetFromContext[T any](ctx context.Context, key any) *T {
@@ -5,6 +5,10 @@ import (
 	"log/slog"
 	"os"
 
+	// The container image is built from scratch and ships without a zoneinfo
+	// database, yet time zones are needed to interpret user provided dates.
+	_ "time/tzdata"
+
 	"github.com/ChausseBenjamin/rafta/internal/app"
 	"github.com/ChausseBenjamin/rafta/internal/logging"
 )
@@ -9,7 +9,9 @@ package model
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -83,34 +85,53 @@ func (TaskState) EnumDescriptor() ([]byte, []int) {
 // changed (especially the description which is an entire markdown file.
 // Include in update requests the list of all fields that need changing
 // using this enum to identify each field.
+// Deprecated: use TaskUpdateRequest.update_mask which also reaches nested
+// fields.
 type TaskFieldMask int32
 
 const (
-	TaskFieldMask_TITLE      TaskFieldMask = 0 // Binds to TaskData.title
-	TaskFieldMask_DESC       TaskFieldMask = 1 // Binds to TaskData.desc
-	TaskFieldMask_PRIORITY   TaskFieldMask = 2 // Binds to TaskData.priority
-	TaskFieldMask_STATE      TaskFieldMask = 3 // Binds to TaskData.state
-	TaskFieldMask_RECURRENCE TaskFieldMask = 4 // Binds to TaskData.recurrence
-	TaskFieldMask_TAGS       TaskFieldMask = 7 // Binds to TaskData.tags
+	TaskFieldMask_TITLE        TaskFieldMask = 0 // Binds to TaskData.title
+	TaskFieldMask_DESC         TaskFieldMask = 1 // Binds to TaskData.desc
+	TaskFieldMask_PRIORITY     TaskFieldMask = 2 // Binds to TaskData.priority
+	TaskFieldMask_STATE        TaskFieldMask = 3 // Binds to TaskData.state
+	TaskFieldMask_RECURRENCE   TaskFieldMask = 4 // Binds to TaskData.recurrence
+	TaskFieldMask_DO_DATE      TaskFieldMask = 5 // Binds to TaskData.do (and do_date)
+	TaskFieldMask_DUE_DATE     TaskFieldMask = 6 // Binds to TaskData.due (and due_date)
+	TaskFieldMask_TAGS         TaskFieldMask = 7 // Binds to TaskData.tags
+	TaskFieldMask_ASSIGNEE     TaskFieldMask = 8 // Binds to TaskData.assignee
+	TaskFieldMask_HIDDEN_UNTIL TaskFieldMask = 9 // Binds to TaskData.hidden_until
+	// Binds to TaskData.fields. Only the fields listed get updated, a value
+	// left unset removes the field from the task.
+	TaskFieldMask_CUSTOM_FIELDS TaskFieldMask = 10
 )
 
 // Enum value maps for TaskFieldMask.
 var (
 	TaskFieldMask_name = map[int32]string{
-		0: "TITLE",
-		1: "DESC",
-		2: "PRIORITY",
-		3: "STATE",
-		4: "RECURRENCE",
-		7: "TAGS",
+		0:  "TITLE",
+		1:  "DESC",
+		2:  "PRIORITY",
+		3:  "STATE",
+		4:  "RECURRENCE",
+		5:  "DO_DATE",
+		6:  "DUE_DATE",
+		7:  "TAGS",
+		8:  "ASSIGNEE",
+		9:  "HIDDEN_UNTIL",
+		10: "CUSTOM_FIELDS",
 	}
 	TaskFieldMask_value = map[string]int32{
-		"TITLE":      0,
-		"DESC":       1,
-		"PRIORITY":   2,
-		"STATE":      3,
-		"RECURRENCE": 4,
-		"TAGS":       7,
+		"TITLE":         0,
+		"DESC":          1,
+		"PRIORITY":      2,
+		"STATE":         3,
+		"RECURRENCE":    4,
+		"DO_DATE":       5,
+		"DUE_DATE":      6,
+		"TAGS":          7,
+		"ASSIGNEE":      8,
+		"HIDDEN_UNTIL":  9,
+		"CUSTOM_FIELDS": 10,
 	}
 )
 
@@ -141,6 +162,355 @@ func (TaskFieldMask) EnumDescriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{1}
 }
 
+// Identifies the type of values a custom field holds.
+type CustomFieldKind int32
+
+const (
+	CustomFieldKind_FIELD_STRING CustomFieldKind = 0
+	CustomFieldKind_FIELD_NUMBER CustomFieldKind = 1
+	CustomFieldKind_FIELD_DATE   CustomFieldKind = 2
+	CustomFieldKind_FIELD_ENUM   CustomFieldKind = 3 // One of CustomFieldDefinition.options
+	CustomFieldKind_FIELD_URL    CustomFieldKind = 4 // Absolute http(s) URL
+)
+
+// Enum value maps for CustomFieldKind.
+var (
+	CustomFieldKind_name = map[int32]string{
+		0: "FIELD_STRING",
+		1: "FIELD_NUMBER",
+		2: "FIELD_DATE",
+		3: "FIELD_ENUM",
+		4: "FIELD_URL",
+	}
+	CustomFieldKind_value = map[string]int32{
+		"FIELD_STRING": 0,
+		"FIELD_NUMBER": 1,
+		"FIELD_DATE":   2,
+		"FIELD_ENUM":   3,
+		"FIELD_URL":    4,
+	}
+)
+
+func (x CustomFieldKind) Enum() *CustomFieldKind {
+	p := new(CustomFieldKind)
+	*p = x
+	return p
+}
+
+func (x CustomFieldKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CustomFieldKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_schema_proto_enumTypes[2].Descriptor()
+}
+
+func (CustomFieldKind) Type() protoreflect.EnumType {
+	return &file_schema_proto_enumTypes[2]
+}
+
+func (x CustomFieldKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CustomFieldKind.Descriptor instead.
+func (CustomFieldKind) EnumDescriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{2}
+}
+
+// Identifies what a portion of a quick add text got interpreted as.
+type QuickAddMatchKind int32
+
+const (
+	QuickAddMatchKind_QUICK_ADD_DUE_DATE   QuickAddMatchKind = 0 // Binds to TaskData.due_date
+	QuickAddMatchKind_QUICK_ADD_DO_DATE    QuickAddMatchKind = 1 // Binds to TaskData.do_date
+	QuickAddMatchKind_QUICK_ADD_TAG        QuickAddMatchKind = 2 // Binds to TaskData.tags
+	QuickAddMatchKind_QUICK_ADD_PRIORITY   QuickAddMatchKind = 3 // Binds to TaskData.priority
+	QuickAddMatchKind_QUICK_ADD_RECURRENCE QuickAddMatchKind = 4 // Binds to TaskData.recurrence
+)
+
+// Enum value maps for QuickAddMatchKind.
+var (
+	QuickAddMatchKind_name = map[int32]string{
+		0: "QUICK_ADD_DUE_DATE",
+		1: "QUICK_ADD_DO_DATE",
+		2: "QUICK_ADD_TAG",
+		3: "QUICK_ADD_PRIORITY",
+		4: "QUICK_ADD_RECURRENCE",
+	}
+	QuickAddMatchKind_value = map[string]int32{
+		"QUICK_ADD_DUE_DATE":   0,
+		"QUICK_ADD_DO_DATE":    1,
+		"QUICK_ADD_TAG":        2,
+		"QUICK_ADD_PRIORITY":   3,
+		"QUICK_ADD_RECURRENCE": 4,
+	}
+)
+
+func (x QuickAddMatchKind) Enum() *QuickAddMatchKind {
+	p := new(QuickAddMatchKind)
+	*p = x
+	return p
+}
+
+func (x QuickAddMatchKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (QuickAddMatchKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_schema_proto_enumTypes[3].Descriptor()
+}
+
+func (QuickAddMatchKind) Type() protoreflect.EnumType {
+	return &file_schema_proto_enumTypes[3]
+}
+
+func (x QuickAddMatchKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use QuickAddMatchKind.Descriptor instead.
+func (QuickAddMatchKind) EnumDescriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{3}
+}
+
+// Identifies which attribute tasks get sorted by.
+type TaskSortKey int32
+
+const (
+	TaskSortKey_SORT_CREATED_ON   TaskSortKey = 0
+	TaskSortKey_SORT_UPDATED_ON   TaskSortKey = 1
+	TaskSortKey_SORT_DUE_DATE     TaskSortKey = 2 // Tasks without a due date come last.
+	TaskSortKey_SORT_DO_DATE      TaskSortKey = 3 // Tasks without a do date come last.
+	TaskSortKey_SORT_PRIORITY     TaskSortKey = 4 // Highest priority first, undefined priority last.
+	TaskSortKey_SORT_TITLE        TaskSortKey = 5
+	TaskSortKey_SORT_STATE        TaskSortKey = 6
+	TaskSortKey_SORT_CUSTOM_FIELD TaskSortKey = 7 // Tasks without a value come last.
+	TaskSortKey_SORT_MANUAL       TaskSortKey = 8 // Order set with MoveTask, tasks outside the view last.
+	TaskSortKey_SORT_URGENCY      TaskSortKey = 9 // Most urgent first.
+)
+
+// Enum value maps for TaskSortKey.
+var (
+	TaskSortKey_name = map[int32]string{
+		0: "SORT_CREATED_ON",
+		1: "SORT_UPDATED_ON",
+		2: "SORT_DUE_DATE",
+		3: "SORT_DO_DATE",
+		4: "SORT_PRIORITY",
+		5: "SORT_TITLE",
+		6: "SORT_STATE",
+		7: "SORT_CUSTOM_FIELD",
+		8: "SORT_MANUAL",
+		9: "SORT_URGENCY",
+	}
+	TaskSortKey_value = map[string]int32{
+		"SORT_CREATED_ON":   0,
+		"SORT_UPDATED_ON":   1,
+		"SORT_DUE_DATE":     2,
+		"SORT_DO_DATE":      3,
+		"SORT_PRIORITY":     4,
+		"SORT_TITLE":        5,
+		"SORT_STATE":        6,
+		"SORT_CUSTOM_FIELD": 7,
+		"SORT_MANUAL":       8,
+		"SORT_URGENCY":      9,
+	}
+)
+
+func (x TaskSortKey) Enum() *TaskSortKey {
+	p := new(TaskSortKey)
+	*p = x
+	return p
+}
+
+func (x TaskSortKey) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskSortKey) Descriptor() protoreflect.EnumDescriptor {
+	return file_schema_proto_enumTypes[4].Descriptor()
+}
+
+func (TaskSortKey) Type() protoreflect.EnumType {
+	return &file_schema_proto_enumTypes[4]
+}
+
+func (x TaskSortKey) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskSortKey.Descriptor instead.
+func (TaskSortKey) EnumDescriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{4}
+}
+
+// Identifies how a custom field value gets compared.
+type FieldOperator int32
+
+const (
+	FieldOperator_FIELD_EQUALS       FieldOperator = 0
+	FieldOperator_FIELD_NOT_EQUALS   FieldOperator = 1 // Tasks without a value match.
+	FieldOperator_FIELD_LESS_THAN    FieldOperator = 2
+	FieldOperator_FIELD_GREATER_THAN FieldOperator = 3
+	FieldOperator_FIELD_CONTAINS     FieldOperator = 4 // Case insensitive substring (text values only).
+	FieldOperator_FIELD_IS_SET       FieldOperator = 5 // Value is ignored.
+	FieldOperator_FIELD_IS_UNSET     FieldOperator = 6 // Value is ignored.
+)
+
+// Enum value maps for FieldOperator.
+var (
+	FieldOperator_name = map[int32]string{
+		0: "FIELD_EQUALS",
+		1: "FIELD_NOT_EQUALS",
+		2: "FIELD_LESS_THAN",
+		3: "FIELD_GREATER_THAN",
+		4: "FIELD_CONTAINS",
+		5: "FIELD_IS_SET",
+		6: "FIELD_IS_UNSET",
+	}
+	FieldOperator_value = map[string]int32{
+		"FIELD_EQUALS":       0,
+		"FIELD_NOT_EQUALS":   1,
+		"FIELD_LESS_THAN":    2,
+		"FIELD_GREATER_THAN": 3,
+		"FIELD_CONTAINS":     4,
+		"FIELD_IS_SET":       5,
+		"FIELD_IS_UNSET":     6,
+	}
+)
+
+func (x FieldOperator) Enum() *FieldOperator {
+	p := new(FieldOperator)
+	*p = x
+	return p
+}
+
+func (x FieldOperator) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FieldOperator) Descriptor() protoreflect.EnumDescriptor {
+	return file_schema_proto_enumTypes[5].Descriptor()
+}
+
+func (FieldOperator) Type() protoreflect.EnumType {
+	return &file_schema_proto_enumTypes[5]
+}
+
+func (x FieldOperator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FieldOperator.Descriptor instead.
+func (FieldOperator) EnumDescriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{5}
+}
+
+// Identifies the protocol a push endpoint speaks.
+type PushEndpointKind int32
+
+const (
+	// URL handed to an app by its UnifiedPush distributor. Receives JSON
+	// documents: {"event", "title", "message", "task_id", "priority"}.
+	PushEndpointKind_UNIFIED_PUSH PushEndpointKind = 0
+	// ntfy topic URL (ex: https://ntfy.example.com/my-tasks). Receives plain
+	// text messages with their title, tags and priority as headers.
+	PushEndpointKind_NTFY PushEndpointKind = 1
+)
+
+// Enum value maps for PushEndpointKind.
+var (
+	PushEndpointKind_name = map[int32]string{
+		0: "UNIFIED_PUSH",
+		1: "NTFY",
+	}
+	PushEndpointKind_value = map[string]int32{
+		"UNIFIED_PUSH": 0,
+		"NTFY":         1,
+	}
+)
+
+func (x PushEndpointKind) Enum() *PushEndpointKind {
+	p := new(PushEndpointKind)
+	*p = x
+	return p
+}
+
+func (x PushEndpointKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PushEndpointKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_schema_proto_enumTypes[6].Descriptor()
+}
+
+func (PushEndpointKind) Type() protoreflect.EnumType {
+	return &file_schema_proto_enumTypes[6]
+}
+
+func (x PushEndpointKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PushEndpointKind.Descriptor instead.
+func (PushEndpointKind) EnumDescriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{6}
+}
+
+// Identifies what a notification is about.
+type PushEvent int32
+
+const (
+	PushEvent_PUSH_REMINDER      PushEvent = 0 // The do date of a task is reached.
+	PushEvent_PUSH_DUE           PushEvent = 1 // A task is due (all-day dates at 09:00).
+	PushEvent_PUSH_SHARED_CHANGE PushEvent = 2 // Someone else changed a task assigned to the user.
+	PushEvent_PUSH_REVEALED      PushEvent = 3 // A hidden task became visible again.
+)
+
+// Enum value maps for PushEvent.
+var (
+	PushEvent_name = map[int32]string{
+		0: "PUSH_REMINDER",
+		1: "PUSH_DUE",
+		2: "PUSH_SHARED_CHANGE",
+		3: "PUSH_REVEALED",
+	}
+	PushEvent_value = map[string]int32{
+		"PUSH_REMINDER":      0,
+		"PUSH_DUE":           1,
+		"PUSH_SHARED_CHANGE": 2,
+		"PUSH_REVEALED":      3,
+	}
+)
+
+func (x PushEvent) Enum() *PushEvent {
+	p := new(PushEvent)
+	*p = x
+	return p
+}
+
+func (x PushEvent) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PushEvent) Descriptor() protoreflect.EnumDescriptor {
+	return file_schema_proto_enumTypes[7].Descriptor()
+}
+
+func (PushEvent) Type() protoreflect.EnumType {
+	return &file_schema_proto_enumTypes[7]
+}
+
+func (x PushEvent) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PushEvent.Descriptor instead.
+func (PushEvent) EnumDescriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{7}
+}
+
 // Represents a universally unique identifier (UUID) used to identify both
 // users and tasks.
 type UUID struct {
@@ -515,15 +885,27 @@ func (x *TaskRecurrence) GetActive() bool {
 
 // Represents the data associated with a task.
 type TaskData struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`                    // Task title.
-	Desc          string                 `protobuf:"bytes,2,opt,name=desc,proto3" json:"desc,omitempty"`                      // Task description in markdown format.
-	Priority      uint32                 `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"`             // Task priority (0=undefined, 1=highest, 0xFFFFFFFF=lowest).
-	State         TaskState              `protobuf:"varint,4,opt,name=state,proto3,enum=TaskState" json:"state,omitempty"`    // Current state of the task.
-	Recurrence    *TaskRecurrence        `protobuf:"bytes,5,opt,name=recurrence,proto3" json:"recurrence,omitempty"`          // Recurrence details of the task.
-	DoDate        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=do_date,json=doDate,proto3" json:"do_date,omitempty"`    // Date when the task should be started.
-	DueDate       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"` // Deadline for the task.
-	Tags          []string               `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`                      // Tags associated with the task.
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Title      string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`                 // Task title.
+	Desc       string                 `protobuf:"bytes,2,opt,name=desc,proto3" json:"desc,omitempty"`                   // Task description in markdown format.
+	Priority   uint32                 `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"`          // Task priority (0=undefined, 1=highest, 0xFFFFFFFF=lowest).
+	State      TaskState              `protobuf:"varint,4,opt,name=state,proto3,enum=TaskState" json:"state,omitempty"` // Current state of the task.
+	Recurrence *TaskRecurrence        `protobuf:"bytes,5,opt,name=recurrence,proto3" json:"recurrence,omitempty"`       // Recurrence details of the task.
+	// Instant do resolves to for the user viewing the task (unset if none).
+	// Only read on writes when do is unset, for clients predating TaskDate.
+	DoDate *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=do_date,json=doDate,proto3" json:"do_date,omitempty"`
+	// Instant due resolves to for the user viewing the task (unset if none).
+	// Only read on writes when due is unset, for clients predating TaskDate.
+	DueDate *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	Tags    []string               `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"` // Tags associated with the task.
+	// User responsible for the task (unset if unassigned). Besides the owner,
+	// it has to own tasks sharing one of the task's tags.
+	Assignee *UUID `protobuf:"bytes,10,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	// Task is left out of listings until then (unset if visible).
+	HiddenUntil   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=hidden_until,json=hiddenUntil,proto3" json:"hidden_until,omitempty"`
+	Fields        []*CustomFieldValue    `protobuf:"bytes,12,rep,name=fields,proto3" json:"fields,omitempty"` // Values of custom fields.
+	Do            *TaskDate              `protobuf:"bytes,13,opt,name=do,proto3" json:"do,omitempty"`         // Date when the task should be started (unset if none).
+	Due           *TaskDate              `protobuf:"bytes,14,opt,name=due,proto3" json:"due,omitempty"`       // Deadline for the task (unset if none).
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -600,44 +982,4312 @@ func (x *TaskData) GetDoDate() *timestamppb.Timestamp {
 	return nil
 }
 
-func (x *TaskData) GetDueDate() *timestamppb.Timestamp {
-	if x != nil {
-		return x.DueDate
-	}
-	return nil
+func (x *TaskData) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+func (x *TaskData) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *TaskData) GetAssignee() *UUID {
+	if x != nil {
+		return x.Assignee
+	}
+	return nil
+}
+
+func (x *TaskData) GetHiddenUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.HiddenUntil
+	}
+	return nil
+}
+
+func (x *TaskData) GetFields() []*CustomFieldValue {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *TaskData) GetDo() *TaskDate {
+	if x != nil {
+		return x.Do
+	}
+	return nil
+}
+
+func (x *TaskData) GetDue() *TaskDate {
+	if x != nil {
+		return x.Due
+	}
+	return nil
+}
+
+// Represents a calendar day (same layout as google.type.Date).
+type CalendarDate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Year          int32                  `protobuf:"varint,1,opt,name=year,proto3" json:"year,omitempty"`
+	Month         int32                  `protobuf:"varint,2,opt,name=month,proto3" json:"month,omitempty"` // 1-12
+	Day           int32                  `protobuf:"varint,3,opt,name=day,proto3" json:"day,omitempty"`     // 1-31
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalendarDate) Reset() {
+	*x = CalendarDate{}
+	mi := &file_schema_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalendarDate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalendarDate) ProtoMessage() {}
+
+func (x *CalendarDate) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalendarDate.ProtoReflect.Descriptor instead.
+func (*CalendarDate) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CalendarDate) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *CalendarDate) GetMonth() int32 {
+	if x != nil {
+		return x.Month
+	}
+	return 0
+}
+
+func (x *CalendarDate) GetDay() int32 {
+	if x != nil {
+		return x.Day
+	}
+	return 0
+}
+
+// Represents a time of day (same layout as google.type.TimeOfDay).
+type TimeOfDay struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hours         int32                  `protobuf:"varint,1,opt,name=hours,proto3" json:"hours,omitempty"`     // 0-23
+	Minutes       int32                  `protobuf:"varint,2,opt,name=minutes,proto3" json:"minutes,omitempty"` // 0-59
+	Seconds       int32                  `protobuf:"varint,3,opt,name=seconds,proto3" json:"seconds,omitempty"` // 0-59
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeOfDay) Reset() {
+	*x = TimeOfDay{}
+	mi := &file_schema_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeOfDay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeOfDay) ProtoMessage() {}
+
+func (x *TimeOfDay) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeOfDay.ProtoReflect.Descriptor instead.
+func (*TimeOfDay) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TimeOfDay) GetHours() int32 {
+	if x != nil {
+		return x.Hours
+	}
+	return 0
+}
+
+func (x *TimeOfDay) GetMinutes() int32 {
+	if x != nil {
+		return x.Minutes
+	}
+	return 0
+}
+
+func (x *TimeOfDay) GetSeconds() int32 {
+	if x != nil {
+		return x.Seconds
+	}
+	return 0
+}
+
+// Represents a task date as the user expressed it:
+//   - All-day: a date without a time (ex: due friday).
+//   - Floating: a date and time without a time zone, happening at that
+//     wall-clock time wherever the user is (ex: do at 09:00).
+//   - Absolute: a date and time in a time zone, designating an instant
+//     (ex: due friday 17:00 America/Montreal).
+//
+// All-day and floating dates follow the time zone of the user
+// (see Preferences.time_zone).
+type TaskDate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          *CalendarDate          `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Time          *TimeOfDay             `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`                         // Unset for all-day dates.
+	TimeZone      string                 `protobuf:"bytes,3,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"` // IANA time zone of absolute dates.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskDate) Reset() {
+	*x = TaskDate{}
+	mi := &file_schema_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskDate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskDate) ProtoMessage() {}
+
+func (x *TaskDate) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskDate.ProtoReflect.Descriptor instead.
+func (*TaskDate) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TaskDate) GetDate() *CalendarDate {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *TaskDate) GetTime() *TimeOfDay {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *TaskDate) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+// Represents user defined metadata tasks can hold (ex: story points).
+type CustomFieldDefinition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                       // Unique per user.
+	Kind  CustomFieldKind        `protobuf:"varint,2,opt,name=kind,proto3,enum=CustomFieldKind" json:"kind,omitempty"` // Can't change once the field is created.
+	// Allowed values of FIELD_ENUM fields. Removing an option leaves the tasks
+	// already holding it untouched.
+	Options       []string `protobuf:"bytes,3,rep,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomFieldDefinition) Reset() {
+	*x = CustomFieldDefinition{}
+	mi := &file_schema_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomFieldDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomFieldDefinition) ProtoMessage() {}
+
+func (x *CustomFieldDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomFieldDefinition.ProtoReflect.Descriptor instead.
+func (*CustomFieldDefinition) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CustomFieldDefinition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CustomFieldDefinition) GetKind() CustomFieldKind {
+	if x != nil {
+		return x.Kind
+	}
+	return CustomFieldKind_FIELD_STRING
+}
+
+func (x *CustomFieldDefinition) GetOptions() []string {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type CustomField struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *UUID                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Data          *CustomFieldDefinition `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomField) Reset() {
+	*x = CustomField{}
+	mi := &file_schema_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomField) ProtoMessage() {}
+
+func (x *CustomField) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomField.ProtoReflect.Descriptor instead.
+func (*CustomField) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CustomField) GetId() *UUID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *CustomField) GetData() *CustomFieldDefinition {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type CustomFieldList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fields        []*CustomField         `protobuf:"bytes,1,rep,name=fields,proto3" json:"fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomFieldList) Reset() {
+	*x = CustomFieldList{}
+	mi := &file_schema_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomFieldList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomFieldList) ProtoMessage() {}
+
+func (x *CustomFieldList) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomFieldList.ProtoReflect.Descriptor instead.
+func (*CustomFieldList) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CustomFieldList) GetFields() []*CustomField {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// Represents the value of a custom field on a task.
+type CustomFieldValue struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	FieldId *UUID                  `protobuf:"bytes,1,opt,name=field_id,json=fieldId,proto3" json:"field_id,omitempty"`
+	// Types that are valid to be assigned to Value:
+	//
+	//	*CustomFieldValue_Text
+	//	*CustomFieldValue_Number
+	//	*CustomFieldValue_Date
+	Value         isCustomFieldValue_Value `protobuf_oneof:"value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomFieldValue) Reset() {
+	*x = CustomFieldValue{}
+	mi := &file_schema_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomFieldValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomFieldValue) ProtoMessage() {}
+
+func (x *CustomFieldValue) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomFieldValue.ProtoReflect.Descriptor instead.
+func (*CustomFieldValue) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CustomFieldValue) GetFieldId() *UUID {
+	if x != nil {
+		return x.FieldId
+	}
+	return nil
+}
+
+func (x *CustomFieldValue) GetValue() isCustomFieldValue_Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *CustomFieldValue) GetText() string {
+	if x != nil {
+		if x, ok := x.Value.(*CustomFieldValue_Text); ok {
+			return x.Text
+		}
+	}
+	return ""
+}
+
+func (x *CustomFieldValue) GetNumber() float64 {
+	if x != nil {
+		if x, ok := x.Value.(*CustomFieldValue_Number); ok {
+			return x.Number
+		}
+	}
+	return 0
+}
+
+func (x *CustomFieldValue) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		if x, ok := x.Value.(*CustomFieldValue_Date); ok {
+			return x.Date
+		}
+	}
+	return nil
+}
+
+type isCustomFieldValue_Value interface {
+	isCustomFieldValue_Value()
+}
+
+type CustomFieldValue_Text struct {
+	Text string `protobuf:"bytes,2,opt,name=text,proto3,oneof"` // FIELD_STRING, FIELD_ENUM and FIELD_URL.
+}
+
+type CustomFieldValue_Number struct {
+	Number float64 `protobuf:"fixed64,3,opt,name=number,proto3,oneof"` // FIELD_NUMBER.
+}
+
+type CustomFieldValue_Date struct {
+	Date *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=date,proto3,oneof"` // FIELD_DATE.
+}
+
+func (*CustomFieldValue_Text) isCustomFieldValue_Value() {}
+
+func (*CustomFieldValue_Number) isCustomFieldValue_Value() {}
+
+func (*CustomFieldValue_Date) isCustomFieldValue_Value() {}
+
+// Represents the completion of the checklist (GitHub-style task list) found
+// in the description of a task.
+type TaskProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Done          uint32                 `protobuf:"varint,1,opt,name=done,proto3" json:"done,omitempty"`   // Amount of checked items.
+	Total         uint32                 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"` // Total amount of items.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskProgress) Reset() {
+	*x = TaskProgress{}
+	mi := &file_schema_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskProgress) ProtoMessage() {}
+
+func (x *TaskProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskProgress.ProtoReflect.Descriptor instead.
+func (*TaskProgress) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TaskProgress) GetDone() uint32 {
+	if x != nil {
+		return x.Done
+	}
+	return 0
+}
+
+func (x *TaskProgress) GetTotal() uint32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// Represents metadata associated with a task.
+type TaskMetadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CreatedOn     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=created_on,json=createdOn,proto3" json:"created_on,omitempty"` // Timestamp when the task was created.
+	UpdatedOn     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=updated_on,json=updatedOn,proto3" json:"updated_on,omitempty"` // Timestamp when the task was last updated.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskMetadata) Reset() {
+	*x = TaskMetadata{}
+	mi := &file_schema_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskMetadata) ProtoMessage() {}
+
+func (x *TaskMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskMetadata.ProtoReflect.Descriptor instead.
+func (*TaskMetadata) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TaskMetadata) GetCreatedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedOn
+	}
+	return nil
+}
+
+func (x *TaskMetadata) GetUpdatedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedOn
+	}
+	return nil
+}
+
+// Represents a single handoff of a task from one assignee to another.
+type TaskAssignment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assignee      *UUID                  `protobuf:"bytes,1,opt,name=assignee,proto3" json:"assignee,omitempty"`                       // New assignee (unset if the task got unassigned).
+	AssignedBy    *UUID                  `protobuf:"bytes,2,opt,name=assigned_by,json=assignedBy,proto3" json:"assigned_by,omitempty"` // User who made the change.
+	AssignedOn    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=assigned_on,json=assignedOn,proto3" json:"assigned_on,omitempty"` // Timestamp of the handoff.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskAssignment) Reset() {
+	*x = TaskAssignment{}
+	mi := &file_schema_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskAssignment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskAssignment) ProtoMessage() {}
+
+func (x *TaskAssignment) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskAssignment.ProtoReflect.Descriptor instead.
+func (*TaskAssignment) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TaskAssignment) GetAssignee() *UUID {
+	if x != nil {
+		return x.Assignee
+	}
+	return nil
+}
+
+func (x *TaskAssignment) GetAssignedBy() *UUID {
+	if x != nil {
+		return x.AssignedBy
+	}
+	return nil
+}
+
+func (x *TaskAssignment) GetAssignedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AssignedOn
+	}
+	return nil
+}
+
+// Represents the assignment history of a task (oldest first).
+type TaskAssignmentList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assignments   []*TaskAssignment      `protobuf:"bytes,1,rep,name=assignments,proto3" json:"assignments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskAssignmentList) Reset() {
+	*x = TaskAssignmentList{}
+	mi := &file_schema_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskAssignmentList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskAssignmentList) ProtoMessage() {}
+
+func (x *TaskAssignmentList) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskAssignmentList.ProtoReflect.Descriptor instead.
+func (*TaskAssignmentList) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TaskAssignmentList) GetAssignments() []*TaskAssignment {
+	if x != nil {
+		return x.Assignments
+	}
+	return nil
+}
+
+// Represents a request to update a task.
+type TaskUpdateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    *UUID                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`     // Unique identifier of the task to update.
+	Data  *TaskData              `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"` // Updated task data.
+	// Deprecated: Marked as deprecated in schema.proto.
+	Masks []TaskFieldMask `protobuf:"varint,3,rep,packed,name=masks,proto3,enum=TaskFieldMask" json:"masks,omitempty"` // Fields to update.
+	// Fields to update named after TaskData (ex: "due", "recurrence.active").
+	// Listing a field left unset in data clears it. Can't be combined with masks.
+	// "do_date" and "due_date" are synonyms of "do" and "due".
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,4,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskUpdateRequest) Reset() {
+	*x = TaskUpdateRequest{}
+	mi := &file_schema_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskUpdateRequest) ProtoMessage() {}
+
+func (x *TaskUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskUpdateRequest.ProtoReflect.Descriptor instead.
+func (*TaskUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TaskUpdateRequest) GetId() *UUID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *TaskUpdateRequest) GetData() *TaskData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// Deprecated: Marked as deprecated in schema.proto.
+func (x *TaskUpdateRequest) GetMasks() []TaskFieldMask {
+	if x != nil {
+		return x.Masks
+	}
+	return nil
+}
+
+func (x *TaskUpdateRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+// Represents a response to a task update request.
+type TaskUpdateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only part of the Tasks metadata that changes
+	UpdatedOn *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=updated_on,json=updatedOn,proto3" json:"updated_on,omitempty"`
+	// If the update request marked as complete a recurring task, the new task
+	// resulting from the completion gets sent back to the client.
+	NewTask       *Task `protobuf:"bytes,3,opt,name=new_task,json=newTask,proto3" json:"new_task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskUpdateResponse) Reset() {
+	*x = TaskUpdateResponse{}
+	mi := &file_schema_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskUpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskUpdateResponse) ProtoMessage() {}
+
+func (x *TaskUpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskUpdateResponse.ProtoReflect.Descriptor instead.
+func (*TaskUpdateResponse) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *TaskUpdateResponse) GetUpdatedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedOn
+	}
+	return nil
+}
+
+func (x *TaskUpdateResponse) GetNewTask() *Task {
+	if x != nil {
+		return x.NewTask
+	}
+	return nil
+}
+
+// Represents a task with associated data and metadata.
+type Task struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       *UUID                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`             // Unique identifier for the task.
+	Data     *TaskData              `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`         // Task data.
+	Metadata *TaskMetadata          `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"` // Metadata about the task.
+	Progress *TaskProgress          `protobuf:"bytes,4,opt,name=progress,proto3" json:"progress,omitempty"` // Derived from the checklist in TaskData.desc.
+	// How pressing the task is (higher is more urgent) according to the
+	// urgency coefficients of the user viewing it. Done tasks score 0.
+	Urgency       float64 `protobuf:"fixed64,5,opt,name=urgency,proto3" json:"urgency,omitempty"`
+	IsOverdue     bool    `protobuf:"varint,6,opt,name=is_overdue,json=isOverdue,proto3" json:"is_overdue,omitempty"` // Due date passed and the task isn't done.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Task) Reset() {
+	*x = Task{}
+	mi := &file_schema_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task) ProtoMessage() {}
+
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Task) GetId() *UUID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Task) GetData() *TaskData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Task) GetMetadata() *TaskMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Task) GetProgress() *TaskProgress {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
+}
+
+func (x *Task) GetUrgency() float64 {
+	if x != nil {
+		return x.Urgency
+	}
+	return 0
+}
+
+func (x *Task) GetIsOverdue() bool {
+	if x != nil {
+		return x.IsOverdue
+	}
+	return false
+}
+
+// Represents a request to toggle a single checklist item of a task's
+// description without resending the whole description.
+type ChecklistToggleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *UUID                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`        // Unique identifier of the task.
+	Index         uint32                 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"` // Position of the item in the checklist (0 = first item).
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChecklistToggleRequest) Reset() {
+	*x = ChecklistToggleRequest{}
+	mi := &file_schema_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChecklistToggleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChecklistToggleRequest) ProtoMessage() {}
+
+func (x *ChecklistToggleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChecklistToggleRequest.ProtoReflect.Descriptor instead.
+func (*ChecklistToggleRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ChecklistToggleRequest) GetId() *UUID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *ChecklistToggleRequest) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+// Represents the outcome of a checklist item toggle.
+type ChecklistToggleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Checked       bool                   `protobuf:"varint,1,opt,name=checked,proto3" json:"checked,omitempty"`  // New state of the toggled item.
+	Progress      *TaskProgress          `protobuf:"bytes,2,opt,name=progress,proto3" json:"progress,omitempty"` // Updated checklist progress.
+	UpdatedOn     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_on,json=updatedOn,proto3" json:"updated_on,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChecklistToggleResponse) Reset() {
+	*x = ChecklistToggleResponse{}
+	mi := &file_schema_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChecklistToggleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChecklistToggleResponse) ProtoMessage() {}
+
+func (x *ChecklistToggleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChecklistToggleResponse.ProtoReflect.Descriptor instead.
+func (*ChecklistToggleResponse) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ChecklistToggleResponse) GetChecked() bool {
+	if x != nil {
+		return x.Checked
+	}
+	return false
+}
+
+func (x *ChecklistToggleResponse) GetProgress() *TaskProgress {
+	if x != nil {
+		return x.Progress
+	}
+	return nil
+}
+
+func (x *ChecklistToggleResponse) GetUpdatedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedOn
+	}
+	return nil
+}
+
+// Represents the response to creating a new task.
+type NewTaskResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unique identifier of the newly created task.
+	Id *UUID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Metadata of the newly created task.
+	Metadata      *TaskMetadata `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NewTaskResponse) Reset() {
+	*x = NewTaskResponse{}
+	mi := &file_schema_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewTaskResponse) ProtoMessage() {}
+
+func (x *NewTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewTaskResponse.ProtoReflect.Descriptor instead.
+func (*NewTaskResponse) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *NewTaskResponse) GetId() *UUID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *NewTaskResponse) GetMetadata() *TaskMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// Represents a single line of text to turn into a task
+// (ex: "buy milk tomorrow 5pm #errands !high every week").
+type QuickAddRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Text  string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	// IANA time zone used to interpret dates (ex: America/Montreal).
+	// Defaults to the time zone of the user (see Preferences.time_zone).
+	TimeZone      string `protobuf:"bytes,2,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuickAddRequest) Reset() {
+	*x = QuickAddRequest{}
+	mi := &file_schema_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuickAddRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuickAddRequest) ProtoMessage() {}
+
+func (x *QuickAddRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuickAddRequest.ProtoReflect.Descriptor instead.
+func (*QuickAddRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *QuickAddRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *QuickAddRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+// Portion of a quick add text which isn't part of the title. Clients can use
+// these to highlight what got understood while the user types.
+type QuickAddMatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         uint32                 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"` // Byte offset of the first matched character.
+	End           uint32                 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`     // Byte offset following the last matched character.
+	Kind          QuickAddMatchKind      `protobuf:"varint,3,opt,name=kind,proto3,enum=QuickAddMatchKind" json:"kind,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuickAddMatch) Reset() {
+	*x = QuickAddMatch{}
+	mi := &file_schema_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuickAddMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuickAddMatch) ProtoMessage() {}
+
+func (x *QuickAddMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuickAddMatch.ProtoReflect.Descriptor instead.
+func (*QuickAddMatch) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *QuickAddMatch) GetStart() uint32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *QuickAddMatch) GetEnd() uint32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *QuickAddMatch) GetKind() QuickAddMatchKind {
+	if x != nil {
+		return x.Kind
+	}
+	return QuickAddMatchKind_QUICK_ADD_DUE_DATE
+}
+
+// Represents what got understood from a quick add text.
+type QuickAddResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Parsed        *TaskData              `protobuf:"bytes,1,opt,name=parsed,proto3" json:"parsed,omitempty"` // Task data extracted from the text.
+	Matches       []*QuickAddMatch       `protobuf:"bytes,2,rep,name=matches,proto3" json:"matches,omitempty"`
+	Task          *Task                  `protobuf:"bytes,3,opt,name=task,proto3" json:"task,omitempty"` // Created task (unset when only parsing).
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuickAddResponse) Reset() {
+	*x = QuickAddResponse{}
+	mi := &file_schema_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuickAddResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuickAddResponse) ProtoMessage() {}
+
+func (x *QuickAddResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuickAddResponse.ProtoReflect.Descriptor instead.
+func (*QuickAddResponse) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *QuickAddResponse) GetParsed() *TaskData {
+	if x != nil {
+		return x.Parsed
+	}
+	return nil
+}
+
+func (x *QuickAddResponse) GetMatches() []*QuickAddMatch {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *QuickAddResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+// Represents time spent on a task.
+type TimeEntryData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        *UUID                  `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"` // Task the time was spent on.
+	StartedOn     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=started_on,json=startedOn,proto3" json:"started_on,omitempty"`
+	EndedOn       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=ended_on,json=endedOn,proto3" json:"ended_on,omitempty"` // Unset while the timer is running.
+	Note          string                 `protobuf:"bytes,4,opt,name=note,proto3" json:"note,omitempty"`                      // Optional description of the work done.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeEntryData) Reset() {
+	*x = TimeEntryData{}
+	mi := &file_schema_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeEntryData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeEntryData) ProtoMessage() {}
+
+func (x *TimeEntryData) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeEntryData.ProtoReflect.Descriptor instead.
+func (*TimeEntryData) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *TimeEntryData) GetTaskId() *UUID {
+	if x != nil {
+		return x.TaskId
+	}
+	return nil
+}
+
+func (x *TimeEntryData) GetStartedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedOn
+	}
+	return nil
+}
+
+func (x *TimeEntryData) GetEndedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndedOn
+	}
+	return nil
+}
+
+func (x *TimeEntryData) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+// Represents a time entry with its identifier.
+type TimeEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    *UUID                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Data  *TimeEntryData         `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// Time elapsed between start and end (or now if the timer is still running).
+	Duration      *durationpb.Duration `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeEntry) Reset() {
+	*x = TimeEntry{}
+	mi := &file_schema_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeEntry) ProtoMessage() {}
+
+func (x *TimeEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeEntry.ProtoReflect.Descriptor instead.
+func (*TimeEntry) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *TimeEntry) GetId() *UUID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *TimeEntry) GetData() *TimeEntryData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *TimeEntry) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+// Represents a list of time entries.
+type TimeEntryList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*TimeEntry           `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeEntryList) Reset() {
+	*x = TimeEntryList{}
+	mi := &file_schema_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeEntryList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeEntryList) ProtoMessage() {}
+
+func (x *TimeEntryList) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeEntryList.ProtoReflect.Descriptor instead.
+func (*TimeEntryList) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *TimeEntryList) GetEntries() []*TimeEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// Represents a request to start tracking time on a task.
+type StartTimerRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId *UUID                  `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Note   string                 `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"`
+	// Moves the task from PENDING to ONGOING (other states are left untouched).
+	StartTask     bool `protobuf:"varint,3,opt,name=start_task,json=startTask,proto3" json:"start_task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartTimerRequest) Reset() {
+	*x = StartTimerRequest{}
+	mi := &file_schema_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartTimerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartTimerRequest) ProtoMessage() {}
+
+func (x *StartTimerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartTimerRequest.ProtoReflect.Descriptor instead.
+func (*StartTimerRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *StartTimerRequest) GetTaskId() *UUID {
+	if x != nil {
+		return x.TaskId
+	}
+	return nil
+}
+
+func (x *StartTimerRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *StartTimerRequest) GetStartTask() bool {
+	if x != nil {
+		return x.StartTask
+	}
+	return false
+}
+
+// Represents the outcome of starting a timer.
+type StartTimerResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Entry *TimeEntry             `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"` // Newly started timer.
+	// Timer which was running prior to this request and got stopped since only
+	// one timer can run at once (unset if none was running).
+	Stopped       *TimeEntry `protobuf:"bytes,2,opt,name=stopped,proto3" json:"stopped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartTimerResponse) Reset() {
+	*x = StartTimerResponse{}
+	mi := &file_schema_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartTimerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartTimerResponse) ProtoMessage() {}
+
+func (x *StartTimerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartTimerResponse.ProtoReflect.Descriptor instead.
+func (*StartTimerResponse) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *StartTimerResponse) GetEntry() *TimeEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *StartTimerResponse) GetStopped() *TimeEntry {
+	if x != nil {
+		return x.Stopped
+	}
+	return nil
+}
+
+// Represents a period of time. Unset bounds leave the period open-ended.
+type TimeRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"` // Inclusive.
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`     // Exclusive.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeRange) Reset() {
+	*x = TimeRange{}
+	mi := &file_schema_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeRange) ProtoMessage() {}
+
+func (x *TimeRange) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeRange.ProtoReflect.Descriptor instead.
+func (*TimeRange) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *TimeRange) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *TimeRange) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+// Represents a request to list time entries overlapping a period.
+type TimeEntryQuery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Range         *TimeRange             `protobuf:"bytes,1,opt,name=range,proto3" json:"range,omitempty"`
+	TaskId        *UUID                  `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"` // Only list entries of this task (optional).
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeEntryQuery) Reset() {
+	*x = TimeEntryQuery{}
+	mi := &file_schema_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeEntryQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeEntryQuery) ProtoMessage() {}
+
+func (x *TimeEntryQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeEntryQuery.ProtoReflect.Descriptor instead.
+func (*TimeEntryQuery) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *TimeEntryQuery) GetRange() *TimeRange {
+	if x != nil {
+		return x.Range
+	}
+	return nil
+}
+
+func (x *TimeEntryQuery) GetTaskId() *UUID {
+	if x != nil {
+		return x.TaskId
+	}
+	return nil
+}
+
+// Time spent on a task within a period.
+type TaskTimeTotal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        *UUID                  `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Duration      *durationpb.Duration   `protobuf:"bytes,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskTimeTotal) Reset() {
+	*x = TaskTimeTotal{}
+	mi := &file_schema_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskTimeTotal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskTimeTotal) ProtoMessage() {}
+
+func (x *TaskTimeTotal) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskTimeTotal.ProtoReflect.Descriptor instead.
+func (*TaskTimeTotal) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *TaskTimeTotal) GetTaskId() *UUID {
+	if x != nil {
+		return x.TaskId
+	}
+	return nil
+}
+
+func (x *TaskTimeTotal) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+// Time spent on tasks bearing a tag within a period.
+type TagTimeTotal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           string                 `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	Duration      *durationpb.Duration   `protobuf:"bytes,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagTimeTotal) Reset() {
+	*x = TagTimeTotal{}
+	mi := &file_schema_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagTimeTotal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagTimeTotal) ProtoMessage() {}
+
+func (x *TagTimeTotal) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagTimeTotal.ProtoReflect.Descriptor instead.
+func (*TagTimeTotal) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *TagTimeTotal) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *TagTimeTotal) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+// Represents time spent within a period. Entries partially within the period
+// only count for the overlapping part. A task bearing multiple tags counts
+// toward each of them.
+type TimeTotals struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         *durationpb.Duration   `protobuf:"bytes,1,opt,name=total,proto3" json:"total,omitempty"`
+	Tasks         []*TaskTimeTotal       `protobuf:"bytes,2,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	Tags          []*TagTimeTotal        `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeTotals) Reset() {
+	*x = TimeTotals{}
+	mi := &file_schema_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeTotals) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeTotals) ProtoMessage() {}
+
+func (x *TimeTotals) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeTotals.ProtoReflect.Descriptor instead.
+func (*TimeTotals) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *TimeTotals) GetTotal() *durationpb.Duration {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *TimeTotals) GetTasks() []*TaskTimeTotal {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *TimeTotals) GetTags() []*TagTimeTotal {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// Represents a window of time a task date must fall in. Relative bounds are
+// counted in days from the day the filter gets evaluated so that a "Today"
+// filter stays accurate over time. Tasks without the date never match.
+type DateWindow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromDay       *int32                 `protobuf:"varint,1,opt,name=from_day,json=fromDay,proto3,oneof" json:"from_day,omitempty"` // First day (0=today, -1=yesterday).
+	ToDay         *int32                 `protobuf:"varint,2,opt,name=to_day,json=toDay,proto3,oneof" json:"to_day,omitempty"`       // Last day (inclusive).
+	After         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`                           // Absolute lower bound (inclusive).
+	Before        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=before,proto3" json:"before,omitempty"`                         // Absolute upper bound (exclusive).
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DateWindow) Reset() {
+	*x = DateWindow{}
+	mi := &file_schema_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DateWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DateWindow) ProtoMessage() {}
+
+func (x *DateWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DateWindow.ProtoReflect.Descriptor instead.
+func (*DateWindow) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DateWindow) GetFromDay() int32 {
+	if x != nil && x.FromDay != nil {
+		return *x.FromDay
+	}
+	return 0
+}
+
+func (x *DateWindow) GetToDay() int32 {
+	if x != nil && x.ToDay != nil {
+		return *x.ToDay
+	}
+	return 0
+}
+
+func (x *DateWindow) GetAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *DateWindow) GetBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+type TaskSort struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           TaskSortKey            `protobuf:"varint,1,opt,name=key,proto3,enum=TaskSortKey" json:"key,omitempty"`
+	Descending    bool                   `protobuf:"varint,2,opt,name=descending,proto3" json:"descending,omitempty"`
+	FieldId       *UUID                  `protobuf:"bytes,3,opt,name=field_id,json=fieldId,proto3" json:"field_id,omitempty"` // Custom field to sort by (SORT_CUSTOM_FIELD).
+	Tag           string                 `protobuf:"bytes,4,opt,name=tag,proto3" json:"tag,omitempty"`                        // Tag view to follow (SORT_MANUAL, empty for the main list).
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskSort) Reset() {
+	*x = TaskSort{}
+	mi := &file_schema_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskSort) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskSort) ProtoMessage() {}
+
+func (x *TaskSort) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskSort.ProtoReflect.Descriptor instead.
+func (*TaskSort) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *TaskSort) GetKey() TaskSortKey {
+	if x != nil {
+		return x.Key
+	}
+	return TaskSortKey_SORT_CREATED_ON
+}
+
+func (x *TaskSort) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *TaskSort) GetFieldId() *UUID {
+	if x != nil {
+		return x.FieldId
+	}
+	return nil
+}
+
+func (x *TaskSort) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+// Represents a criterion on the value of a custom field. The field is
+// identified by value.field_id.
+type CustomFieldCondition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Op            FieldOperator          `protobuf:"varint,1,opt,name=op,proto3,enum=FieldOperator" json:"op,omitempty"`
+	Value         *CustomFieldValue      `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomFieldCondition) Reset() {
+	*x = CustomFieldCondition{}
+	mi := &file_schema_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomFieldCondition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomFieldCondition) ProtoMessage() {}
+
+func (x *CustomFieldCondition) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomFieldCondition.ProtoReflect.Descriptor instead.
+func (*CustomFieldCondition) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CustomFieldCondition) GetOp() FieldOperator {
+	if x != nil {
+		return x.Op
+	}
+	return FieldOperator_FIELD_EQUALS
+}
+
+func (x *CustomFieldCondition) GetValue() *CustomFieldValue {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// Represents criteria tasks must meet. Every criterion which is set must be
+// met (unset criteria match every task).
+type TaskFilter struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	States   []TaskState            `protobuf:"varint,1,rep,packed,name=states,proto3,enum=TaskState" json:"states,omitempty"` // Task is in any of these states.
+	TagsAll  []string               `protobuf:"bytes,2,rep,name=tags_all,json=tagsAll,proto3" json:"tags_all,omitempty"`       // Task bears every one of these tags.
+	TagsAny  []string               `protobuf:"bytes,3,rep,name=tags_any,json=tagsAny,proto3" json:"tags_any,omitempty"`       // Task bears at least one of these tags.
+	TagsNone []string               `protobuf:"bytes,4,rep,name=tags_none,json=tagsNone,proto3" json:"tags_none,omitempty"`    // Task bears none of these tags.
+	// Priority bounds (inclusive, 0=unbounded). Keep in mind 1 is the highest
+	// priority. Tasks with an undefined priority only match unbounded filters.
+	PriorityMin uint32      `protobuf:"varint,5,opt,name=priority_min,json=priorityMin,proto3" json:"priority_min,omitempty"`
+	PriorityMax uint32      `protobuf:"varint,6,opt,name=priority_max,json=priorityMax,proto3" json:"priority_max,omitempty"`
+	Due         *DateWindow `protobuf:"bytes,7,opt,name=due,proto3" json:"due,omitempty"`
+	Do          *DateWindow `protobuf:"bytes,8,opt,name=do,proto3" json:"do,omitempty"`
+	Sort        []*TaskSort `protobuf:"bytes,9,rep,name=sort,proto3" json:"sort,omitempty"` // Applied in order (ties fall to the next).
+	// IANA time zone used to compute day boundaries (defaults to the time zone
+	// of the user).
+	TimeZone string `protobuf:"bytes,10,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	// Include tasks hidden until a later date (see TaskData.hidden_until).
+	IncludeHidden bool `protobuf:"varint,11,opt,name=include_hidden,json=includeHidden,proto3" json:"include_hidden,omitempty"`
+	// Task meets every one of these conditions.
+	Fields        []*CustomFieldCondition `protobuf:"bytes,12,rep,name=fields,proto3" json:"fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskFilter) Reset() {
+	*x = TaskFilter{}
+	mi := &file_schema_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskFilter) ProtoMessage() {}
+
+func (x *TaskFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskFilter.ProtoReflect.Descriptor instead.
+func (*TaskFilter) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *TaskFilter) GetStates() []TaskState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *TaskFilter) GetTagsAll() []string {
+	if x != nil {
+		return x.TagsAll
+	}
+	return nil
+}
+
+func (x *TaskFilter) GetTagsAny() []string {
+	if x != nil {
+		return x.TagsAny
+	}
+	return nil
+}
+
+func (x *TaskFilter) GetTagsNone() []string {
+	if x != nil {
+		return x.TagsNone
+	}
+	return nil
+}
+
+func (x *TaskFilter) GetPriorityMin() uint32 {
+	if x != nil {
+		return x.PriorityMin
+	}
+	return 0
+}
+
+func (x *TaskFilter) GetPriorityMax() uint32 {
+	if x != nil {
+		return x.PriorityMax
+	}
+	return 0
+}
+
+func (x *TaskFilter) GetDue() *DateWindow {
+	if x != nil {
+		return x.Due
+	}
+	return nil
+}
+
+func (x *TaskFilter) GetDo() *DateWindow {
+	if x != nil {
+		return x.Do
+	}
+	return nil
+}
+
+func (x *TaskFilter) GetSort() []*TaskSort {
+	if x != nil {
+		return x.Sort
+	}
+	return nil
+}
+
+func (x *TaskFilter) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *TaskFilter) GetIncludeHidden() bool {
+	if x != nil {
+		return x.IncludeHidden
+	}
+	return false
+}
+
+func (x *TaskFilter) GetFields() []*CustomFieldCondition {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// Represents a named filter stored on the server so every client can share it.
+type SavedFilterData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Unique per user.
+	Filter        *TaskFilter            `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SavedFilterData) Reset() {
+	*x = SavedFilterData{}
+	mi := &file_schema_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SavedFilterData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavedFilterData) ProtoMessage() {}
+
+func (x *SavedFilterData) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavedFilterData.ProtoReflect.Descriptor instead.
+func (*SavedFilterData) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SavedFilterData) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SavedFilterData) GetFilter() *TaskFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type SavedFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *UUID                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Data          *SavedFilterData       `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	CreatedOn     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_on,json=createdOn,proto3" json:"created_on,omitempty"`
+	UpdatedOn     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_on,json=updatedOn,proto3" json:"updated_on,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SavedFilter) Reset() {
+	*x = SavedFilter{}
+	mi := &file_schema_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SavedFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavedFilter) ProtoMessage() {}
+
+func (x *SavedFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavedFilter.ProtoReflect.Descriptor instead.
+func (*SavedFilter) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *SavedFilter) GetId() *UUID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *SavedFilter) GetData() *SavedFilterData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SavedFilter) GetCreatedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedOn
+	}
+	return nil
+}
+
+func (x *SavedFilter) GetUpdatedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedOn
+	}
+	return nil
+}
+
+type SavedFilterList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filters       []*SavedFilter         `protobuf:"bytes,1,rep,name=filters,proto3" json:"filters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SavedFilterList) Reset() {
+	*x = SavedFilterList{}
+	mi := &file_schema_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SavedFilterList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavedFilterList) ProtoMessage() {}
+
+func (x *SavedFilterList) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavedFilterList.ProtoReflect.Descriptor instead.
+func (*SavedFilterList) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SavedFilterList) GetFilters() []*SavedFilter {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+// Represents where tasks come from when listing, exporting or feeding them.
+type TaskSource struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Source:
+	//
+	//	*TaskSource_SavedFilter
+	//	*TaskSource_Filter
+	Source        isTaskSource_Source `protobuf_oneof:"source"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskSource) Reset() {
+	*x = TaskSource{}
+	mi := &file_schema_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskSource) ProtoMessage() {}
+
+func (x *TaskSource) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskSource.ProtoReflect.Descriptor instead.
+func (*TaskSource) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *TaskSource) GetSource() isTaskSource_Source {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+func (x *TaskSource) GetSavedFilter() *UUID {
+	if x != nil {
+		if x, ok := x.Source.(*TaskSource_SavedFilter); ok {
+			return x.SavedFilter
+		}
+	}
+	return nil
+}
+
+func (x *TaskSource) GetFilter() *TaskFilter {
+	if x != nil {
+		if x, ok := x.Source.(*TaskSource_Filter); ok {
+			return x.Filter
+		}
+	}
+	return nil
+}
+
+type isTaskSource_Source interface {
+	isTaskSource_Source()
+}
+
+type TaskSource_SavedFilter struct {
+	SavedFilter *UUID `protobuf:"bytes,1,opt,name=saved_filter,json=savedFilter,proto3,oneof"` // Identifier of a SavedFilter.
+}
+
+type TaskSource_Filter struct {
+	Filter *TaskFilter `protobuf:"bytes,2,opt,name=filter,proto3,oneof"` // Ad-hoc filter.
+}
+
+func (*TaskSource_SavedFilter) isTaskSource_Source() {}
+
+func (*TaskSource_Filter) isTaskSource_Source() {}
+
+// Represents a task to create when instantiating a template. Text fields
+// (title, desc, tags, subtasks) may contain placeholders (ex: {{version}})
+// substituted upon instantiation.
+type TemplateTask struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Title    string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Desc     string                 `protobuf:"bytes,2,opt,name=desc,proto3" json:"desc,omitempty"`
+	Priority uint32                 `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"`
+	Tags     []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Dates relative to the moment the template gets instantiated (ex: "+3d",
+	// "+1w2d@17:00", "-2h"). Units are m, h, d, w, mo and y. Dates without
+	// time of day are due at the end of the day or started at its beginning.
+	DoDate  string `protobuf:"bytes,5,opt,name=do_date,json=doDate,proto3" json:"do_date,omitempty"`
+	DueDate string `protobuf:"bytes,6,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	// Subtasks get appended to the description as checklist items since tasks
+	// can't contain other tasks.
+	Subtasks      []string        `protobuf:"bytes,7,rep,name=subtasks,proto3" json:"subtasks,omitempty"`
+	Recurrence    *TaskRecurrence `protobuf:"bytes,8,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TemplateTask) Reset() {
+	*x = TemplateTask{}
+	mi := &file_schema_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TemplateTask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TemplateTask) ProtoMessage() {}
+
+func (x *TemplateTask) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TemplateTask.ProtoReflect.Descriptor instead.
+func (*TemplateTask) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *TemplateTask) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *TemplateTask) GetDesc() string {
+	if x != nil {
+		return x.Desc
+	}
+	return ""
+}
+
+func (x *TemplateTask) GetPriority() uint32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *TemplateTask) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *TemplateTask) GetDoDate() string {
+	if x != nil {
+		return x.DoDate
+	}
+	return ""
+}
+
+func (x *TemplateTask) GetDueDate() string {
+	if x != nil {
+		return x.DueDate
+	}
+	return ""
+}
+
+func (x *TemplateTask) GetSubtasks() []string {
+	if x != nil {
+		return x.Subtasks
+	}
+	return nil
+}
+
+func (x *TemplateTask) GetRecurrence() *TaskRecurrence {
+	if x != nil {
+		return x.Recurrence
+	}
+	return nil
+}
+
+// Represents a bundle of tasks to create at once (ex: a release checklist).
+type TemplateData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Unique per user.
+	Desc          string                 `protobuf:"bytes,2,opt,name=desc,proto3" json:"desc,omitempty"`
+	Tasks         []*TemplateTask        `protobuf:"bytes,3,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TemplateData) Reset() {
+	*x = TemplateData{}
+	mi := &file_schema_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TemplateData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TemplateData) ProtoMessage() {}
+
+func (x *TemplateData) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TemplateData.ProtoReflect.Descriptor instead.
+func (*TemplateData) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *TemplateData) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TemplateData) GetDesc() string {
+	if x != nil {
+		return x.Desc
+	}
+	return ""
+}
+
+func (x *TemplateData) GetTasks() []*TemplateTask {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type Template struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        *UUID                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Data      *TemplateData          `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	CreatedOn *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_on,json=createdOn,proto3" json:"created_on,omitempty"`
+	UpdatedOn *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_on,json=updatedOn,proto3" json:"updated_on,omitempty"`
+	// Placeholders used by the template which must be provided when
+	// instantiating it.
+	Variables     []string `protobuf:"bytes,5,rep,name=variables,proto3" json:"variables,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Template) Reset() {
+	*x = Template{}
+	mi := &file_schema_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Template) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Template) ProtoMessage() {}
+
+func (x *Template) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Template.ProtoReflect.Descriptor instead.
+func (*Template) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *Template) GetId() *UUID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Template) GetData() *TemplateData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Template) GetCreatedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedOn
+	}
+	return nil
+}
+
+func (x *Template) GetUpdatedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedOn
+	}
+	return nil
+}
+
+func (x *Template) GetVariables() []string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+type TemplateList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Templates     []*Template            `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TemplateList) Reset() {
+	*x = TemplateList{}
+	mi := &file_schema_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TemplateList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TemplateList) ProtoMessage() {}
+
+func (x *TemplateList) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TemplateList.ProtoReflect.Descriptor instead.
+func (*TemplateList) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *TemplateList) GetTemplates() []*Template {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+type InstantiateTemplateRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        *UUID                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Variables map[string]string      `protobuf:"bytes,2,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Values of the placeholders.
+	// Moment relative dates are computed from (defaults to now).
+	Anchor *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=anchor,proto3" json:"anchor,omitempty"`
+	// IANA time zone used to compute day boundaries (defaults to the time zone
+	// of the user).
+	TimeZone      string `protobuf:"bytes,4,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstantiateTemplateRequest) Reset() {
+	*x = InstantiateTemplateRequest{}
+	mi := &file_schema_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstantiateTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstantiateTemplateRequest) ProtoMessage() {}
+
+func (x *InstantiateTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstantiateTemplateRequest.ProtoReflect.Descriptor instead.
+func (*InstantiateTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *InstantiateTemplateRequest) GetId() *UUID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *InstantiateTemplateRequest) GetVariables() map[string]string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *InstantiateTemplateRequest) GetAnchor() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Anchor
+	}
+	return nil
+}
+
+func (x *InstantiateTemplateRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+// Portable representation of a template (JSON encoded TemplateData) meant to
+// be shared between users and servers.
+type TemplateDocument struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Json          string                 `protobuf:"bytes,1,opt,name=json,proto3" json:"json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TemplateDocument) Reset() {
+	*x = TemplateDocument{}
+	mi := &file_schema_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TemplateDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TemplateDocument) ProtoMessage() {}
+
+func (x *TemplateDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TemplateDocument.ProtoReflect.Descriptor instead.
+func (*TemplateDocument) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *TemplateDocument) GetJson() string {
+	if x != nil {
+		return x.Json
+	}
+	return ""
+}
+
+// Represents a request to hide a task until it becomes relevant.
+type SnoozeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    *UUID                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Unique identifier of the task.
+	// Types that are valid to be assigned to Until:
+	//
+	//	*SnoozeRequest_Duration
+	//	*SnoozeRequest_Date
+	Until         isSnoozeRequest_Until `protobuf_oneof:"until"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnoozeRequest) Reset() {
+	*x = SnoozeRequest{}
+	mi := &file_schema_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnoozeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnoozeRequest) ProtoMessage() {}
+
+func (x *SnoozeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnoozeRequest.ProtoReflect.Descriptor instead.
+func (*SnoozeRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *SnoozeRequest) GetId() *UUID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *SnoozeRequest) GetUntil() isSnoozeRequest_Until {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *SnoozeRequest) GetDuration() *durationpb.Duration {
+	if x != nil {
+		if x, ok := x.Until.(*SnoozeRequest_Duration); ok {
+			return x.Duration
+		}
+	}
+	return nil
+}
+
+func (x *SnoozeRequest) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		if x, ok := x.Until.(*SnoozeRequest_Date); ok {
+			return x.Date
+		}
+	}
+	return nil
+}
+
+type isSnoozeRequest_Until interface {
+	isSnoozeRequest_Until()
+}
+
+type SnoozeRequest_Duration struct {
+	Duration *durationpb.Duration `protobuf:"bytes,2,opt,name=duration,proto3,oneof"` // Hide the task for this long.
+}
+
+type SnoozeRequest_Date struct {
+	Date *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=date,proto3,oneof"` // Hide the task until then.
+}
+
+func (*SnoozeRequest_Duration) isSnoozeRequest_Until() {}
+
+func (*SnoozeRequest_Date) isSnoozeRequest_Until() {}
+
+type SnoozeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HiddenUntil   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=hidden_until,json=hiddenUntil,proto3" json:"hidden_until,omitempty"`
+	UpdatedOn     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=updated_on,json=updatedOn,proto3" json:"updated_on,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnoozeResponse) Reset() {
+	*x = SnoozeResponse{}
+	mi := &file_schema_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnoozeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnoozeResponse) ProtoMessage() {}
+
+func (x *SnoozeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnoozeResponse.ProtoReflect.Descriptor instead.
+func (*SnoozeResponse) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *SnoozeResponse) GetHiddenUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.HiddenUntil
+	}
+	return nil
+}
+
+func (x *SnoozeResponse) GetUpdatedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedOn
+	}
+	return nil
+}
+
+// Represents a request to move a task within a manually ordered list. Without
+// a sibling, the task moves to the end of the list.
+type MoveTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    *UUID                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`   // Unique identifier of the task.
+	Tag   string                 `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"` // Tag view the order applies to (empty for the main list).
+	// Types that are valid to be assigned to Sibling:
+	//
+	//	*MoveTaskRequest_Before
+	//	*MoveTaskRequest_After
+	Sibling       isMoveTaskRequest_Sibling `protobuf_oneof:"sibling"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveTaskRequest) Reset() {
+	*x = MoveTaskRequest{}
+	mi := &file_schema_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveTaskRequest) ProtoMessage() {}
+
+func (x *MoveTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveTaskRequest.ProtoReflect.Descriptor instead.
+func (*MoveTaskRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *MoveTaskRequest) GetId() *UUID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *MoveTaskRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *MoveTaskRequest) GetSibling() isMoveTaskRequest_Sibling {
+	if x != nil {
+		return x.Sibling
+	}
+	return nil
+}
+
+func (x *MoveTaskRequest) GetBefore() *UUID {
+	if x != nil {
+		if x, ok := x.Sibling.(*MoveTaskRequest_Before); ok {
+			return x.Before
+		}
+	}
+	return nil
+}
+
+func (x *MoveTaskRequest) GetAfter() *UUID {
+	if x != nil {
+		if x, ok := x.Sibling.(*MoveTaskRequest_After); ok {
+			return x.After
+		}
+	}
+	return nil
+}
+
+type isMoveTaskRequest_Sibling interface {
+	isMoveTaskRequest_Sibling()
+}
+
+type MoveTaskRequest_Before struct {
+	Before *UUID `protobuf:"bytes,3,opt,name=before,proto3,oneof"` // Place the task right before this one.
+}
+
+type MoveTaskRequest_After struct {
+	After *UUID `protobuf:"bytes,4,opt,name=after,proto3,oneof"` // Place the task right after this one.
+}
+
+func (*MoveTaskRequest_Before) isMoveTaskRequest_Sibling() {}
+
+func (*MoveTaskRequest_After) isMoveTaskRequest_Sibling() {}
+
+// Weights of the factors making up the urgency of a task (see Task.urgency).
+// Unset coefficients keep their default value (in parentheses), a coefficient
+// of 0 ignores its factor.
+type UrgencyCoefficients struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Divided by the task priority: a priority of 1 gets the full amount. (6)
+	Priority *float64 `protobuf:"fixed64,1,opt,name=priority,proto3,oneof" json:"priority,omitempty"`
+	// Scaled from 0.2 (due in 14 days or more) to 1 (overdue by 7 days or
+	// more). (12)
+	Due       *float64 `protobuf:"fixed64,2,opt,name=due,proto3,oneof" json:"due,omitempty"`
+	Age       *float64 `protobuf:"fixed64,3,opt,name=age,proto3,oneof" json:"age,omitempty"`             // Scaled up to 1 at a year old. (2)
+	Ongoing   *float64 `protobuf:"fixed64,4,opt,name=ongoing,proto3,oneof" json:"ongoing,omitempty"`     // Task is ONGOING. (4)
+	Blocked   *float64 `protobuf:"fixed64,5,opt,name=blocked,proto3,oneof" json:"blocked,omitempty"`     // Task is BLOCKED. (-5)
+	Scheduled *float64 `protobuf:"fixed64,6,opt,name=scheduled,proto3,oneof" json:"scheduled,omitempty"` // Do date is reached. (5)
+	Hidden    *float64 `protobuf:"fixed64,7,opt,name=hidden,proto3,oneof" json:"hidden,omitempty"`       // Task is hidden for now. (-3)
+	// Scaled by the number of tags: 0.8 for one, 0.9 for two, 1 for more. (1)
+	Tags *float64 `protobuf:"fixed64,8,opt,name=tags,proto3,oneof" json:"tags,omitempty"`
+	// Added for each tag of the task bearing one of these names. Entries are
+	// merged with the defaults. ({"next": 15})
+	Tag           map[string]float64 `protobuf:"bytes,9,rep,name=tag,proto3" json:"tag,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UrgencyCoefficients) Reset() {
+	*x = UrgencyCoefficients{}
+	mi := &file_schema_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UrgencyCoefficients) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UrgencyCoefficients) ProtoMessage() {}
+
+func (x *UrgencyCoefficients) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UrgencyCoefficients.ProtoReflect.Descriptor instead.
+func (*UrgencyCoefficients) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *UrgencyCoefficients) GetPriority() float64 {
+	if x != nil && x.Priority != nil {
+		return *x.Priority
+	}
+	return 0
+}
+
+func (x *UrgencyCoefficients) GetDue() float64 {
+	if x != nil && x.Due != nil {
+		return *x.Due
+	}
+	return 0
+}
+
+func (x *UrgencyCoefficients) GetAge() float64 {
+	if x != nil && x.Age != nil {
+		return *x.Age
+	}
+	return 0
+}
+
+func (x *UrgencyCoefficients) GetOngoing() float64 {
+	if x != nil && x.Ongoing != nil {
+		return *x.Ongoing
+	}
+	return 0
+}
+
+func (x *UrgencyCoefficients) GetBlocked() float64 {
+	if x != nil && x.Blocked != nil {
+		return *x.Blocked
+	}
+	return 0
+}
+
+func (x *UrgencyCoefficients) GetScheduled() float64 {
+	if x != nil && x.Scheduled != nil {
+		return *x.Scheduled
+	}
+	return 0
+}
+
+func (x *UrgencyCoefficients) GetHidden() float64 {
+	if x != nil && x.Hidden != nil {
+		return *x.Hidden
+	}
+	return 0
+}
+
+func (x *UrgencyCoefficients) GetTags() float64 {
+	if x != nil && x.Tags != nil {
+		return *x.Tags
+	}
+	return 0
+}
+
+func (x *UrgencyCoefficients) GetTag() map[string]float64 {
+	if x != nil {
+		return x.Tag
+	}
+	return nil
+}
+
+// Represents the settings of a user shared by every client.
+type Preferences struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Urgency *UrgencyCoefficients   `protobuf:"bytes,1,opt,name=urgency,proto3" json:"urgency,omitempty"`
+	// IANA time zone (ex: America/Montreal) defining the calendar days of the
+	// user. Requests without a time zone of their own use it. (UTC)
+	// Stored as the rafta/time_zone setting.
+	TimeZone string `protobuf:"bytes,2,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	// BCP 47 language tag (ex: fr-CA) clients display their interface in. The
+	// server only stores it, what it writes (ex: digest emails) is in English.
+	// (en) Stored as the rafta/locale setting.
+	Locale string `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	// Local time of day (HH:MM, ex: 07:30) the user gets emailed a digest of
+	// their overdue tasks and of those due today. No digest when empty, or when
+	// the server can't send emails. Stored as the rafta/digest_time setting.
+	DigestTime    string `protobuf:"bytes,4,opt,name=digest_time,json=digestTime,proto3" json:"digest_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Preferences) Reset() {
+	*x = Preferences{}
+	mi := &file_schema_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Preferences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Preferences) ProtoMessage() {}
+
+func (x *Preferences) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Preferences.ProtoReflect.Descriptor instead.
+func (*Preferences) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *Preferences) GetUrgency() *UrgencyCoefficients {
+	if x != nil {
+		return x.Urgency
+	}
+	return nil
+}
+
+func (x *Preferences) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *Preferences) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *Preferences) GetDigestTime() string {
+	if x != nil {
+		return x.DigestTime
+	}
+	return ""
+}
+
+// Represents a single entry of the key/value store clients keep their
+// settings in (ex: default view, theme). Namespaces keep the settings of
+// different clients apart. The "rafta" namespace is reserved for the
+// well-known settings the server itself honors (see Preferences):
+//   - time_zone: IANA time zone.
+//   - locale: BCP 47 language tag.
+//   - digest_time: time of day (HH:MM) of the daily digest email.
+//
+// Namespaces and keys are made of letters, digits, '.', '_' and '-'. A user
+// holds at most 512 settings totaling 256KiB with values of at most 16KiB.
+type Setting struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`                  // At most 64 bytes.
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`                              // At most 128 bytes.
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`                          // Empty when deleted.
+	UpdatedOn     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_on,json=updatedOn,proto3" json:"updated_on,omitempty"` // Ignored on writes.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Setting) Reset() {
+	*x = Setting{}
+	mi := &file_schema_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Setting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Setting) ProtoMessage() {}
+
+func (x *Setting) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Setting.ProtoReflect.Descriptor instead.
+func (*Setting) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *Setting) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Setting) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Setting) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Setting) GetUpdatedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedOn
+	}
+	return nil
+}
+
+type SettingList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      []*Setting             `protobuf:"bytes,1,rep,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SettingList) Reset() {
+	*x = SettingList{}
+	mi := &file_schema_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SettingList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SettingList) ProtoMessage() {}
+
+func (x *SettingList) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SettingList.ProtoReflect.Descriptor instead.
+func (*SettingList) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *SettingList) GetSettings() []*Setting {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+// Represents a request for the settings of a namespace (all of them if
+// empty).
+type SettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SettingsRequest) Reset() {
+	*x = SettingsRequest{}
+	mi := &file_schema_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SettingsRequest) ProtoMessage() {}
+
+func (x *SettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SettingsRequest.ProtoReflect.Descriptor instead.
+func (*SettingsRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *SettingsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// Represents where and which notifications of a user get delivered.
+type PushEndpointData struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Unique per user (ex: "phone").
+	Kind  PushEndpointKind       `protobuf:"varint,2,opt,name=kind,proto3,enum=PushEndpointKind" json:"kind,omitempty"`
+	Url   string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"` // http(s) URL notifications get POSTed to.
+	// Access token of protected ntfy topics. Write-only: never sent back and
+	// left untouched by updates when empty.
+	Token  string      `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`
+	Events []PushEvent `protobuf:"varint,5,rep,packed,name=events,proto3,enum=PushEvent" json:"events,omitempty"` // Events notified (all of them if empty).
+	// Disabled endpoints receive nothing. Endpoints get disabled after 5
+	// deliveries in a row failed (retries included) or as soon as they report
+	// being gone (HTTP 404/410).
+	Disabled      bool `protobuf:"varint,6,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushEndpointData) Reset() {
+	*x = PushEndpointData{}
+	mi := &file_schema_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushEndpointData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushEndpointData) ProtoMessage() {}
+
+func (x *PushEndpointData) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushEndpointData.ProtoReflect.Descriptor instead.
+func (*PushEndpointData) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *PushEndpointData) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PushEndpointData) GetKind() PushEndpointKind {
+	if x != nil {
+		return x.Kind
+	}
+	return PushEndpointKind_UNIFIED_PUSH
+}
+
+func (x *PushEndpointData) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *PushEndpointData) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *PushEndpointData) GetEvents() []PushEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *PushEndpointData) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+// Represents the delivery health of a push endpoint. Updating an endpoint
+// resets it.
+type PushEndpointStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Failures      uint32                 `protobuf:"varint,1,opt,name=failures,proto3" json:"failures,omitempty"`                            // Consecutive failed deliveries.
+	LastError     string                 `protobuf:"bytes,2,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`          // Empty after a success.
+	LastDelivery  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_delivery,json=lastDelivery,proto3" json:"last_delivery,omitempty"` // Last successful delivery.
+	HasToken      bool                   `protobuf:"varint,4,opt,name=has_token,json=hasToken,proto3" json:"has_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushEndpointStatus) Reset() {
+	*x = PushEndpointStatus{}
+	mi := &file_schema_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushEndpointStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushEndpointStatus) ProtoMessage() {}
+
+func (x *PushEndpointStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushEndpointStatus.ProtoReflect.Descriptor instead.
+func (*PushEndpointStatus) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *PushEndpointStatus) GetFailures() uint32 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+func (x *PushEndpointStatus) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *PushEndpointStatus) GetLastDelivery() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastDelivery
+	}
+	return nil
+}
+
+func (x *PushEndpointStatus) GetHasToken() bool {
+	if x != nil {
+		return x.HasToken
+	}
+	return false
+}
+
+type PushEndpoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *UUID                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Data          *PushEndpointData      `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Status        *PushEndpointStatus    `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // Ignored on writes.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushEndpoint) Reset() {
+	*x = PushEndpoint{}
+	mi := &file_schema_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushEndpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushEndpoint) ProtoMessage() {}
+
+func (x *PushEndpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushEndpoint.ProtoReflect.Descriptor instead.
+func (*PushEndpoint) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *PushEndpoint) GetId() *UUID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *PushEndpoint) GetData() *PushEndpointData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *PushEndpoint) GetStatus() *PushEndpointStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type PushEndpointList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Endpoints     []*PushEndpoint        `protobuf:"bytes,1,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PushEndpointList) Reset() {
+	*x = PushEndpointList{}
+	mi := &file_schema_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PushEndpointList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushEndpointList) ProtoMessage() {}
+
+func (x *PushEndpointList) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushEndpointList.ProtoReflect.Descriptor instead.
+func (*PushEndpointList) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *PushEndpointList) GetEndpoints() []*PushEndpoint {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+// Represents a TLS client certificate a user logs in with instead of a
+// password (ex: scripts on trusted machines). Requests over a connection
+// presenting it get authenticated like Basic credentials (ex: to Login) when
+// they carry no authorization header. Only works when the server asks for
+// client certificates.
+type ClientCertificateData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Unique per user (ex: "backup-script").
+	Pem           string                 `protobuf:"bytes,2,opt,name=pem,proto3" json:"pem,omitempty"`   // PEM encoded certificate (never its key). Write-only.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClientCertificateData) Reset() {
+	*x = ClientCertificateData{}
+	mi := &file_schema_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClientCertificateData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientCertificateData) ProtoMessage() {}
+
+func (x *ClientCertificateData) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientCertificateData.ProtoReflect.Descriptor instead.
+func (*ClientCertificateData) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ClientCertificateData) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ClientCertificateData) GetPem() string {
+	if x != nil {
+		return x.Pem
+	}
+	return ""
+}
+
+type ClientCertificate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *UUID                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Fingerprint   string                 `protobuf:"bytes,3,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"` // SHA-256 of the DER certificate (hex).
+	Subject       string                 `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`
+	NotAfter      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"` // Expiry of the certificate.
+	CreatedOn     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_on,json=createdOn,proto3" json:"created_on,omitempty"`
+	LastUsed      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_used,json=lastUsed,proto3" json:"last_used,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClientCertificate) Reset() {
+	*x = ClientCertificate{}
+	mi := &file_schema_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClientCertificate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientCertificate) ProtoMessage() {}
+
+func (x *ClientCertificate) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientCertificate.ProtoReflect.Descriptor instead.
+func (*ClientCertificate) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ClientCertificate) GetId() *UUID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *ClientCertificate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ClientCertificate) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+func (x *ClientCertificate) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *ClientCertificate) GetNotAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NotAfter
+	}
+	return nil
+}
+
+func (x *ClientCertificate) GetCreatedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedOn
+	}
+	return nil
+}
+
+func (x *ClientCertificate) GetLastUsed() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsed
+	}
+	return nil
+}
+
+type ClientCertificateList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Certificates  []*ClientCertificate   `protobuf:"bytes,1,rep,name=certificates,proto3" json:"certificates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClientCertificateList) Reset() {
+	*x = ClientCertificateList{}
+	mi := &file_schema_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClientCertificateList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientCertificateList) ProtoMessage() {}
+
+func (x *ClientCertificateList) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientCertificateList.ProtoReflect.Descriptor instead.
+func (*ClientCertificateList) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ClientCertificateList) GetCertificates() []*ClientCertificate {
+	if x != nil {
+		return x.Certificates
+	}
+	return nil
+}
+
+// Represents a long-lived token scripts and integrations authenticate with
+// (as a Bearer token) in place of access tokens. Tokens only work for the
+// endpoints within their scopes:
+//   - tasks:read: tasks, filters, templates, custom fields, time entries and
+//     agendas.
+//   - tasks:write: creating, changing and deleting any of the above.
+//   - account: user info, preferences, settings and push endpoints.
+//   - admin: the Admin service (the user must still have the ADMIN role).
+//
+// Managing credentials (passwords, tokens, certificates) and deleting the
+// account always requires logging in.
+type PersonalTokenData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                            // Unique per user (ex: "backup-script").
+	Scopes        []string               `protobuf:"bytes,2,rep,name=scopes,proto3" json:"scopes,omitempty"`                        // At least one.
+	ExpiresOn     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_on,json=expiresOn,proto3" json:"expires_on,omitempty"` // Never expires when unset.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PersonalTokenData) Reset() {
+	*x = PersonalTokenData{}
+	mi := &file_schema_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PersonalTokenData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PersonalTokenData) ProtoMessage() {}
+
+func (x *PersonalTokenData) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PersonalTokenData.ProtoReflect.Descriptor instead.
+func (*PersonalTokenData) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *PersonalTokenData) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PersonalTokenData) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *PersonalTokenData) GetExpiresOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresOn
+	}
+	return nil
+}
+
+type PersonalToken struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *UUID                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Data          *PersonalTokenData     `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	CreatedOn     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_on,json=createdOn,proto3" json:"created_on,omitempty"`
+	LastUsed      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_used,json=lastUsed,proto3" json:"last_used,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PersonalToken) Reset() {
+	*x = PersonalToken{}
+	mi := &file_schema_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PersonalToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PersonalToken) ProtoMessage() {}
+
+func (x *PersonalToken) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PersonalToken.ProtoReflect.Descriptor instead.
+func (*PersonalToken) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *PersonalToken) GetId() *UUID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *PersonalToken) GetData() *PersonalTokenData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *PersonalToken) GetCreatedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedOn
+	}
+	return nil
+}
+
+func (x *PersonalToken) GetLastUsed() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsed
+	}
+	return nil
+}
+
+type NewPersonalTokenResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Token *PersonalToken         `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// Only ever sent here: the server keeps a hash of it.
+	Secret        string `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NewPersonalTokenResponse) Reset() {
+	*x = NewPersonalTokenResponse{}
+	mi := &file_schema_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewPersonalTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewPersonalTokenResponse) ProtoMessage() {}
+
+func (x *NewPersonalTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewPersonalTokenResponse.ProtoReflect.Descriptor instead.
+func (*NewPersonalTokenResponse) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *NewPersonalTokenResponse) GetToken() *PersonalToken {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *NewPersonalTokenResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type PersonalTokenList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tokens        []*PersonalToken       `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PersonalTokenList) Reset() {
+	*x = PersonalTokenList{}
+	mi := &file_schema_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PersonalTokenList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PersonalTokenList) ProtoMessage() {}
+
+func (x *PersonalTokenList) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PersonalTokenList.ProtoReflect.Descriptor instead.
+func (*PersonalTokenList) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *PersonalTokenList) GetTokens() []*PersonalToken {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+// Represents a device a user logged in from. Sessions last as long as their
+// refresh token keeps getting refreshed and end on logout or revocation, at
+// which point their tokens stop working.
+type Session struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    *UUID                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Sent by clients as "device-name" metadata when logging in (their user
+	// agent otherwise).
+	Device        string                 `protobuf:"bytes,2,opt,name=device,proto3" json:"device,omitempty"`
+	Ip            string                 `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"` // Address last used from.
+	CreatedOn     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_on,json=createdOn,proto3" json:"created_on,omitempty"`
+	LastUsed      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_used,json=lastUsed,proto3" json:"last_used,omitempty"`    // Last login or refresh.
+	ExpiresOn     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_on,json=expiresOn,proto3" json:"expires_on,omitempty"` // Unless refreshed before.
+	Current       bool                   `protobuf:"varint,7,opt,name=current,proto3" json:"current,omitempty"`                     // Session of the request.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_schema_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *Session) GetId() *UUID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Session) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *Session) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *Session) GetCreatedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedOn
+	}
+	return nil
+}
+
+func (x *Session) GetLastUsed() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsed
+	}
+	return nil
+}
+
+func (x *Session) GetExpiresOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresOn
+	}
+	return nil
+}
+
+func (x *Session) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+type SessionList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*Session             `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionList) Reset() {
+	*x = SessionList{}
+	mi := &file_schema_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionList) ProtoMessage() {}
+
+func (x *SessionList) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionList.ProtoReflect.Descriptor instead.
+func (*SessionList) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{72}
 }
 
-func (x *TaskData) GetTags() []string {
+func (x *SessionList) GetSessions() []*Session {
 	if x != nil {
-		return x.Tags
+		return x.Sessions
 	}
 	return nil
 }
 
-// Represents metadata associated with a task.
-type TaskMetadata struct {
+// Secret to add to an authenticator app to enable two-factor authentication.
+type TOTPEnrollment struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	CreatedOn     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=created_on,json=createdOn,proto3" json:"created_on,omitempty"` // Timestamp when the task was created.
-	UpdatedOn     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=updated_on,json=updatedOn,proto3" json:"updated_on,omitempty"` // Timestamp when the task was last updated.
+	Secret        string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"` // Base32, for apps that can't scan the URI.
+	Uri           string                 `protobuf:"bytes,2,opt,name=uri,proto3" json:"uri,omitempty"`       // otpauth:// URI, usually shown as a QR code.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TaskMetadata) Reset() {
-	*x = TaskMetadata{}
-	mi := &file_schema_proto_msgTypes[8]
+func (x *TOTPEnrollment) Reset() {
+	*x = TOTPEnrollment{}
+	mi := &file_schema_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TaskMetadata) String() string {
+func (x *TOTPEnrollment) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TaskMetadata) ProtoMessage() {}
+func (*TOTPEnrollment) ProtoMessage() {}
 
-func (x *TaskMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_proto_msgTypes[8]
+func (x *TOTPEnrollment) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -648,50 +5298,48 @@ func (x *TaskMetadata) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TaskMetadata.ProtoReflect.Descriptor instead.
-func (*TaskMetadata) Descriptor() ([]byte, []int) {
-	return file_schema_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use TOTPEnrollment.ProtoReflect.Descriptor instead.
+func (*TOTPEnrollment) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{73}
 }
 
-func (x *TaskMetadata) GetCreatedOn() *timestamppb.Timestamp {
+func (x *TOTPEnrollment) GetSecret() string {
 	if x != nil {
-		return x.CreatedOn
+		return x.Secret
 	}
-	return nil
+	return ""
 }
 
-func (x *TaskMetadata) GetUpdatedOn() *timestamppb.Timestamp {
+func (x *TOTPEnrollment) GetUri() string {
 	if x != nil {
-		return x.UpdatedOn
+		return x.Uri
 	}
-	return nil
+	return ""
 }
 
-// Represents a request to update a task.
-type TaskUpdateRequest struct {
+// Either a code from an authenticator app or a recovery code.
+type TOTPCode struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            *UUID                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                  // Unique identifier of the task to update.
-	Data          *TaskData              `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`                              // Updated task data.
-	Masks         []TaskFieldMask        `protobuf:"varint,3,rep,packed,name=masks,proto3,enum=TaskFieldMask" json:"masks,omitempty"` // Fields to update.
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TaskUpdateRequest) Reset() {
-	*x = TaskUpdateRequest{}
-	mi := &file_schema_proto_msgTypes[9]
+func (x *TOTPCode) Reset() {
+	*x = TOTPCode{}
+	mi := &file_schema_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TaskUpdateRequest) String() string {
+func (x *TOTPCode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TaskUpdateRequest) ProtoMessage() {}
+func (*TOTPCode) ProtoMessage() {}
 
-func (x *TaskUpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_proto_msgTypes[9]
+func (x *TOTPCode) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -702,59 +5350,89 @@ func (x *TaskUpdateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TaskUpdateRequest.ProtoReflect.Descriptor instead.
-func (*TaskUpdateRequest) Descriptor() ([]byte, []int) {
-	return file_schema_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use TOTPCode.ProtoReflect.Descriptor instead.
+func (*TOTPCode) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{74}
 }
 
-func (x *TaskUpdateRequest) GetId() *UUID {
+func (x *TOTPCode) GetCode() string {
 	if x != nil {
-		return x.Id
+		return x.Code
 	}
-	return nil
+	return ""
 }
 
-func (x *TaskUpdateRequest) GetData() *TaskData {
+// Single-use codes standing in for an authenticator app. They are only shown
+// once.
+type RecoveryCodes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Codes         []string               `protobuf:"bytes,1,rep,name=codes,proto3" json:"codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecoveryCodes) Reset() {
+	*x = RecoveryCodes{}
+	mi := &file_schema_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecoveryCodes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecoveryCodes) ProtoMessage() {}
+
+func (x *RecoveryCodes) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[75]
 	if x != nil {
-		return x.Data
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *TaskUpdateRequest) GetMasks() []TaskFieldMask {
+// Deprecated: Use RecoveryCodes.ProtoReflect.Descriptor instead.
+func (*RecoveryCodes) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *RecoveryCodes) GetCodes() []string {
 	if x != nil {
-		return x.Masks
+		return x.Codes
 	}
 	return nil
 }
 
-// Represents a response to a task update request.
-type TaskUpdateResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Only part of the Tasks metadata that changes
-	UpdatedOn *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=updated_on,json=updatedOn,proto3" json:"updated_on,omitempty"`
-	// If the update request marked as complete a recurring task, the new task
-	// resulting from the completion gets sent back to the client.
-	NewTask       *Task `protobuf:"bytes,3,opt,name=new_task,json=newTask,proto3" json:"new_task,omitempty"`
+// Represents a request for the tasks planned over a range of calendar days.
+type AgendaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         string                 `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`                       // First day (YYYY-MM-DD), today if empty.
+	Days          uint32                 `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"`                        // Number of days covered (7 if 0, at most 366).
+	TimeZone      string                 `protobuf:"bytes,3,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"` // Overrides the time zone of the user.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TaskUpdateResponse) Reset() {
-	*x = TaskUpdateResponse{}
-	mi := &file_schema_proto_msgTypes[10]
+func (x *AgendaRequest) Reset() {
+	*x = AgendaRequest{}
+	mi := &file_schema_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TaskUpdateResponse) String() string {
+func (x *AgendaRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TaskUpdateResponse) ProtoMessage() {}
+func (*AgendaRequest) ProtoMessage() {}
 
-func (x *TaskUpdateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_proto_msgTypes[10]
+func (x *AgendaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -765,50 +5443,60 @@ func (x *TaskUpdateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TaskUpdateResponse.ProtoReflect.Descriptor instead.
-func (*TaskUpdateResponse) Descriptor() ([]byte, []int) {
-	return file_schema_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use AgendaRequest.ProtoReflect.Descriptor instead.
+func (*AgendaRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{76}
 }
 
-func (x *TaskUpdateResponse) GetUpdatedOn() *timestamppb.Timestamp {
+func (x *AgendaRequest) GetStart() string {
 	if x != nil {
-		return x.UpdatedOn
+		return x.Start
 	}
-	return nil
+	return ""
 }
 
-func (x *TaskUpdateResponse) GetNewTask() *Task {
+func (x *AgendaRequest) GetDays() uint32 {
 	if x != nil {
-		return x.NewTask
+		return x.Days
 	}
-	return nil
+	return 0
 }
 
-// Represents a task with associated data and metadata.
-type Task struct {
+func (x *AgendaRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+// Represents a single calendar day of an agenda. Days last 23 or 25 hours
+// when daylight saving time starts or ends.
+type AgendaDay struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            *UUID                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`             // Unique identifier for the task.
-	Data          *TaskData              `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`         // Task data.
-	Metadata      *TaskMetadata          `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"` // Metadata about the task.
+	Date          string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`   // YYYY-MM-DD
+	Start         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"` // Local midnight.
+	End           *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`     // Next local midnight.
+	Due           []*Task                `protobuf:"bytes,4,rep,name=due,proto3" json:"due,omitempty"`     // Tasks due that day.
+	Do            []*Task                `protobuf:"bytes,5,rep,name=do,proto3" json:"do,omitempty"`       // Tasks to do that day.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Task) Reset() {
-	*x = Task{}
-	mi := &file_schema_proto_msgTypes[11]
+func (x *AgendaDay) Reset() {
+	*x = AgendaDay{}
+	mi := &file_schema_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Task) String() string {
+func (x *AgendaDay) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Task) ProtoMessage() {}
+func (*AgendaDay) ProtoMessage() {}
 
-func (x *Task) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_proto_msgTypes[11]
+func (x *AgendaDay) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -819,58 +5507,71 @@ func (x *Task) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Task.ProtoReflect.Descriptor instead.
-func (*Task) Descriptor() ([]byte, []int) {
-	return file_schema_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use AgendaDay.ProtoReflect.Descriptor instead.
+func (*AgendaDay) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{77}
 }
 
-func (x *Task) GetId() *UUID {
+func (x *AgendaDay) GetDate() string {
 	if x != nil {
-		return x.Id
+		return x.Date
+	}
+	return ""
+}
+
+func (x *AgendaDay) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
 	}
 	return nil
 }
 
-func (x *Task) GetData() *TaskData {
+func (x *AgendaDay) GetEnd() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Data
+		return x.End
 	}
 	return nil
 }
 
-func (x *Task) GetMetadata() *TaskMetadata {
+func (x *AgendaDay) GetDue() []*Task {
 	if x != nil {
-		return x.Metadata
+		return x.Due
 	}
 	return nil
 }
 
-// Represents the response to creating a new task.
-type NewTaskResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Unique identifier of the newly created task.
-	Id *UUID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	// Metadata of the newly created task.
-	Metadata      *TaskMetadata `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+func (x *AgendaDay) GetDo() []*Task {
+	if x != nil {
+		return x.Do
+	}
+	return nil
+}
+
+type Agenda struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TimeZone string                 `protobuf:"bytes,1,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"` // Time zone the days were computed in.
+	Days     []*AgendaDay           `protobuf:"bytes,2,rep,name=days,proto3" json:"days,omitempty"`
+	// Unfinished tasks due before the first day (and before now).
+	Overdue       []*Task `protobuf:"bytes,3,rep,name=overdue,proto3" json:"overdue,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *NewTaskResponse) Reset() {
-	*x = NewTaskResponse{}
-	mi := &file_schema_proto_msgTypes[12]
+func (x *Agenda) Reset() {
+	*x = Agenda{}
+	mi := &file_schema_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *NewTaskResponse) String() string {
+func (x *Agenda) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NewTaskResponse) ProtoMessage() {}
+func (*Agenda) ProtoMessage() {}
 
-func (x *NewTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_proto_msgTypes[12]
+func (x *Agenda) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -881,21 +5582,28 @@ func (x *NewTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NewTaskResponse.ProtoReflect.Descriptor instead.
-func (*NewTaskResponse) Descriptor() ([]byte, []int) {
-	return file_schema_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use Agenda.ProtoReflect.Descriptor instead.
+func (*Agenda) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{78}
 }
 
-func (x *NewTaskResponse) GetId() *UUID {
+func (x *Agenda) GetTimeZone() string {
 	if x != nil {
-		return x.Id
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *Agenda) GetDays() []*AgendaDay {
+	if x != nil {
+		return x.Days
 	}
 	return nil
 }
 
-func (x *NewTaskResponse) GetMetadata() *TaskMetadata {
+func (x *Agenda) GetOverdue() []*Task {
 	if x != nil {
-		return x.Metadata
+		return x.Overdue
 	}
 	return nil
 }
@@ -910,7 +5618,7 @@ type TaskList struct {
 
 func (x *TaskList) Reset() {
 	*x = TaskList{}
-	mi := &file_schema_proto_msgTypes[13]
+	mi := &file_schema_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -922,7 +5630,7 @@ func (x *TaskList) String() string {
 func (*TaskList) ProtoMessage() {}
 
 func (x *TaskList) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_proto_msgTypes[13]
+	mi := &file_schema_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -935,7 +5643,7 @@ func (x *TaskList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TaskList.ProtoReflect.Descriptor instead.
 func (*TaskList) Descriptor() ([]byte, []int) {
-	return file_schema_proto_rawDescGZIP(), []int{13}
+	return file_schema_proto_rawDescGZIP(), []int{79}
 }
 
 func (x *TaskList) GetTasks() []*Task {
@@ -955,7 +5663,7 @@ type UserList struct {
 
 func (x *UserList) Reset() {
 	*x = UserList{}
-	mi := &file_schema_proto_msgTypes[14]
+	mi := &file_schema_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -967,7 +5675,7 @@ func (x *UserList) String() string {
 func (*UserList) ProtoMessage() {}
 
 func (x *UserList) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_proto_msgTypes[14]
+	mi := &file_schema_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -980,7 +5688,7 @@ func (x *UserList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserList.ProtoReflect.Descriptor instead.
 func (*UserList) Descriptor() ([]byte, []int) {
-	return file_schema_proto_rawDescGZIP(), []int{14}
+	return file_schema_proto_rawDescGZIP(), []int{80}
 }
 
 func (x *UserList) GetUsers() []*User {
@@ -1001,7 +5709,7 @@ type JWT struct {
 
 func (x *JWT) Reset() {
 	*x = JWT{}
-	mi := &file_schema_proto_msgTypes[15]
+	mi := &file_schema_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1013,7 +5721,7 @@ func (x *JWT) String() string {
 func (*JWT) ProtoMessage() {}
 
 func (x *JWT) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_proto_msgTypes[15]
+	mi := &file_schema_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1026,7 +5734,7 @@ func (x *JWT) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JWT.ProtoReflect.Descriptor instead.
 func (*JWT) Descriptor() ([]byte, []int) {
-	return file_schema_proto_rawDescGZIP(), []int{15}
+	return file_schema_proto_rawDescGZIP(), []int{81}
 }
 
 func (x *JWT) GetAccess() string {
@@ -1054,7 +5762,7 @@ type LoginResponse struct {
 
 func (x *LoginResponse) Reset() {
 	*x = LoginResponse{}
-	mi := &file_schema_proto_msgTypes[16]
+	mi := &file_schema_proto_msgTypes[82]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1066,7 +5774,7 @@ func (x *LoginResponse) String() string {
 func (*LoginResponse) ProtoMessage() {}
 
 func (x *LoginResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_proto_msgTypes[16]
+	mi := &file_schema_proto_msgTypes[82]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1079,7 +5787,7 @@ func (x *LoginResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
 func (*LoginResponse) Descriptor() ([]byte, []int) {
-	return file_schema_proto_rawDescGZIP(), []int{16}
+	return file_schema_proto_rawDescGZIP(), []int{82}
 }
 
 func (x *LoginResponse) GetUser() *User {
@@ -1096,6 +5804,61 @@ func (x *LoginResponse) GetTokens() *JWT {
 	return nil
 }
 
+// ID token a client got from the OpenID Connect provider of the server.
+type OIDCLoginRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	IdToken string                 `protobuf:"bytes,1,opt,name=id_token,json=idToken,proto3" json:"id_token,omitempty"`
+	// Nonce the client sent to the provider, checked against the token when
+	// set.
+	Nonce         string `protobuf:"bytes,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OIDCLoginRequest) Reset() {
+	*x = OIDCLoginRequest{}
+	mi := &file_schema_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OIDCLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OIDCLoginRequest) ProtoMessage() {}
+
+func (x *OIDCLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OIDCLoginRequest.ProtoReflect.Descriptor instead.
+func (*OIDCLoginRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *OIDCLoginRequest) GetIdToken() string {
+	if x != nil {
+		return x.IdToken
+	}
+	return ""
+}
+
+func (x *OIDCLoginRequest) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
 // Represents a request to sign up a new user.
 type UserSignupRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1107,7 +5870,7 @@ type UserSignupRequest struct {
 
 func (x *UserSignupRequest) Reset() {
 	*x = UserSignupRequest{}
-	mi := &file_schema_proto_msgTypes[17]
+	mi := &file_schema_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1119,7 +5882,7 @@ func (x *UserSignupRequest) String() string {
 func (*UserSignupRequest) ProtoMessage() {}
 
 func (x *UserSignupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_proto_msgTypes[17]
+	mi := &file_schema_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1132,7 +5895,7 @@ func (x *UserSignupRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserSignupRequest.ProtoReflect.Descriptor instead.
 func (*UserSignupRequest) Descriptor() ([]byte, []int) {
-	return file_schema_proto_rawDescGZIP(), []int{17}
+	return file_schema_proto_rawDescGZIP(), []int{84}
 }
 
 func (x *UserSignupRequest) GetUser() *UserData {
@@ -1159,7 +5922,7 @@ type RefreshRequest struct {
 
 func (x *RefreshRequest) Reset() {
 	*x = RefreshRequest{}
-	mi := &file_schema_proto_msgTypes[18]
+	mi := &file_schema_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1171,7 +5934,7 @@ func (x *RefreshRequest) String() string {
 func (*RefreshRequest) ProtoMessage() {}
 
 func (x *RefreshRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_proto_msgTypes[18]
+	mi := &file_schema_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1184,7 +5947,7 @@ func (x *RefreshRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefreshRequest.ProtoReflect.Descriptor instead.
 func (*RefreshRequest) Descriptor() ([]byte, []int) {
-	return file_schema_proto_rawDescGZIP(), []int{18}
+	return file_schema_proto_rawDescGZIP(), []int{85}
 }
 
 func (x *RefreshRequest) GetRefreshToken() string {
@@ -1205,7 +5968,7 @@ type ChangePasswdRequest struct {
 
 func (x *ChangePasswdRequest) Reset() {
 	*x = ChangePasswdRequest{}
-	mi := &file_schema_proto_msgTypes[19]
+	mi := &file_schema_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1217,7 +5980,7 @@ func (x *ChangePasswdRequest) String() string {
 func (*ChangePasswdRequest) ProtoMessage() {}
 
 func (x *ChangePasswdRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_proto_msgTypes[19]
+	mi := &file_schema_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1230,7 +5993,7 @@ func (x *ChangePasswdRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChangePasswdRequest.ProtoReflect.Descriptor instead.
 func (*ChangePasswdRequest) Descriptor() ([]byte, []int) {
-	return file_schema_proto_rawDescGZIP(), []int{19}
+	return file_schema_proto_rawDescGZIP(), []int{86}
 }
 
 func (x *ChangePasswdRequest) GetId() *UUID {
@@ -1257,7 +6020,7 @@ type PasswdMessage struct {
 
 func (x *PasswdMessage) Reset() {
 	*x = PasswdMessage{}
-	mi := &file_schema_proto_msgTypes[20]
+	mi := &file_schema_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1269,7 +6032,7 @@ func (x *PasswdMessage) String() string {
 func (*PasswdMessage) ProtoMessage() {}
 
 func (x *PasswdMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_proto_msgTypes[20]
+	mi := &file_schema_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1282,7 +6045,7 @@ func (x *PasswdMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PasswdMessage.ProtoReflect.Descriptor instead.
 func (*PasswdMessage) Descriptor() ([]byte, []int) {
-	return file_schema_proto_rawDescGZIP(), []int{20}
+	return file_schema_proto_rawDescGZIP(), []int{87}
 }
 
 func (x *PasswdMessage) GetSecret() string {
@@ -1296,7 +6059,7 @@ var File_schema_proto protoreflect.FileDescriptor
 
 const file_schema_proto_rawDesc = "" +
 	"\n" +
-	"\fschema.proto\x1a(protobuf/src/google/protobuf/empty.proto\x1a,protobuf/src/google/protobuf/timestamp.proto\"\x1c\n" +
+	"\fschema.proto\x1a+protobuf/src/google/protobuf/duration.proto\x1a(protobuf/src/google/protobuf/empty.proto\x1a-protobuf/src/google/protobuf/field_mask.proto\x1a,protobuf/src/google/protobuf/timestamp.proto\"\x1c\n" +
 	"\x04UUID\x12\x14\n" +
 	"\x05value\x18\x01 \x01(\tR\x05value\"4\n" +
 	"\bUserData\x12\x12\n" +
@@ -1318,7 +6081,7 @@ const file_schema_proto_rawDesc = "" +
 	"\bmetadata\x18\x03 \x01(\v2\r.UserMetadataR\bmetadata\"B\n" +
 	"\x0eTaskRecurrence\x12\x18\n" +
 	"\apattern\x18\x01 \x01(\tR\apattern\x12\x16\n" +
-	"\x06active\x18\x02 \x01(\bR\x06active\"\xa3\x02\n" +
+	"\x06active\x18\x02 \x01(\bR\x06active\"\xe8\x03\n" +
 	"\bTaskData\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12\x12\n" +
 	"\x04desc\x18\x02 \x01(\tR\x04desc\x12\x1a\n" +
@@ -1330,27 +6093,363 @@ const file_schema_proto_rawDesc = "" +
 	"recurrence\x123\n" +
 	"\ado_date\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x06doDate\x125\n" +
 	"\bdue_date\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x12\x12\n" +
-	"\x04tags\x18\t \x03(\tR\x04tags\"\x84\x01\n" +
+	"\x04tags\x18\t \x03(\tR\x04tags\x12!\n" +
+	"\bassignee\x18\n" +
+	" \x01(\v2\x05.UUIDR\bassignee\x12=\n" +
+	"\fhidden_until\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vhiddenUntil\x12)\n" +
+	"\x06fields\x18\f \x03(\v2\x11.CustomFieldValueR\x06fields\x12\x19\n" +
+	"\x02do\x18\r \x01(\v2\t.TaskDateR\x02do\x12\x1b\n" +
+	"\x03due\x18\x0e \x01(\v2\t.TaskDateR\x03due\"J\n" +
+	"\fCalendarDate\x12\x12\n" +
+	"\x04year\x18\x01 \x01(\x05R\x04year\x12\x14\n" +
+	"\x05month\x18\x02 \x01(\x05R\x05month\x12\x10\n" +
+	"\x03day\x18\x03 \x01(\x05R\x03day\"U\n" +
+	"\tTimeOfDay\x12\x14\n" +
+	"\x05hours\x18\x01 \x01(\x05R\x05hours\x12\x18\n" +
+	"\aminutes\x18\x02 \x01(\x05R\aminutes\x12\x18\n" +
+	"\aseconds\x18\x03 \x01(\x05R\aseconds\"j\n" +
+	"\bTaskDate\x12!\n" +
+	"\x04date\x18\x01 \x01(\v2\r.CalendarDateR\x04date\x12\x1e\n" +
+	"\x04time\x18\x02 \x01(\v2\n" +
+	".TimeOfDayR\x04time\x12\x1b\n" +
+	"\ttime_zone\x18\x03 \x01(\tR\btimeZone\"k\n" +
+	"\x15CustomFieldDefinition\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12$\n" +
+	"\x04kind\x18\x02 \x01(\x0e2\x10.CustomFieldKindR\x04kind\x12\x18\n" +
+	"\aoptions\x18\x03 \x03(\tR\aoptions\"P\n" +
+	"\vCustomField\x12\x15\n" +
+	"\x02id\x18\x01 \x01(\v2\x05.UUIDR\x02id\x12*\n" +
+	"\x04data\x18\x02 \x01(\v2\x16.CustomFieldDefinitionR\x04data\"7\n" +
+	"\x0fCustomFieldList\x12$\n" +
+	"\x06fields\x18\x01 \x03(\v2\f.CustomFieldR\x06fields\"\x9f\x01\n" +
+	"\x10CustomFieldValue\x12 \n" +
+	"\bfield_id\x18\x01 \x01(\v2\x05.UUIDR\afieldId\x12\x14\n" +
+	"\x04text\x18\x02 \x01(\tH\x00R\x04text\x12\x18\n" +
+	"\x06number\x18\x03 \x01(\x01H\x00R\x06number\x120\n" +
+	"\x04date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x04dateB\a\n" +
+	"\x05value\"8\n" +
+	"\fTaskProgress\x12\x12\n" +
+	"\x04done\x18\x01 \x01(\rR\x04done\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\rR\x05total\"\x84\x01\n" +
 	"\fTaskMetadata\x129\n" +
 	"\n" +
 	"created_on\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedOn\x129\n" +
 	"\n" +
-	"updated_on\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedOn\"o\n" +
+	"updated_on\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedOn\"\x98\x01\n" +
+	"\x0eTaskAssignment\x12!\n" +
+	"\bassignee\x18\x01 \x01(\v2\x05.UUIDR\bassignee\x12&\n" +
+	"\vassigned_by\x18\x02 \x01(\v2\x05.UUIDR\n" +
+	"assignedBy\x12;\n" +
+	"\vassigned_on\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"assignedOn\"G\n" +
+	"\x12TaskAssignmentList\x121\n" +
+	"\vassignments\x18\x01 \x03(\v2\x0f.TaskAssignmentR\vassignments\"\xb0\x01\n" +
 	"\x11TaskUpdateRequest\x12\x15\n" +
 	"\x02id\x18\x01 \x01(\v2\x05.UUIDR\x02id\x12\x1d\n" +
-	"\x04data\x18\x02 \x01(\v2\t.TaskDataR\x04data\x12$\n" +
-	"\x05masks\x18\x03 \x03(\x0e2\x0e.TaskFieldMaskR\x05masks\"q\n" +
+	"\x04data\x18\x02 \x01(\v2\t.TaskDataR\x04data\x12(\n" +
+	"\x05masks\x18\x03 \x03(\x0e2\x0e.TaskFieldMaskB\x02\x18\x01R\x05masks\x12;\n" +
+	"\vupdate_mask\x18\x04 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"q\n" +
 	"\x12TaskUpdateResponse\x129\n" +
 	"\n" +
 	"updated_on\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedOn\x12 \n" +
-	"\bnew_task\x18\x03 \x01(\v2\x05.TaskR\anewTask\"g\n" +
+	"\bnew_task\x18\x03 \x01(\v2\x05.TaskR\anewTask\"\xcb\x01\n" +
 	"\x04Task\x12\x15\n" +
 	"\x02id\x18\x01 \x01(\v2\x05.UUIDR\x02id\x12\x1d\n" +
 	"\x04data\x18\x02 \x01(\v2\t.TaskDataR\x04data\x12)\n" +
-	"\bmetadata\x18\x03 \x01(\v2\r.TaskMetadataR\bmetadata\"S\n" +
+	"\bmetadata\x18\x03 \x01(\v2\r.TaskMetadataR\bmetadata\x12)\n" +
+	"\bprogress\x18\x04 \x01(\v2\r.TaskProgressR\bprogress\x12\x18\n" +
+	"\aurgency\x18\x05 \x01(\x01R\aurgency\x12\x1d\n" +
+	"\n" +
+	"is_overdue\x18\x06 \x01(\bR\tisOverdue\"E\n" +
+	"\x16ChecklistToggleRequest\x12\x15\n" +
+	"\x02id\x18\x01 \x01(\v2\x05.UUIDR\x02id\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\rR\x05index\"\x99\x01\n" +
+	"\x17ChecklistToggleResponse\x12\x18\n" +
+	"\achecked\x18\x01 \x01(\bR\achecked\x12)\n" +
+	"\bprogress\x18\x02 \x01(\v2\r.TaskProgressR\bprogress\x129\n" +
+	"\n" +
+	"updated_on\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedOn\"S\n" +
 	"\x0fNewTaskResponse\x12\x15\n" +
 	"\x02id\x18\x01 \x01(\v2\x05.UUIDR\x02id\x12)\n" +
-	"\bmetadata\x18\x02 \x01(\v2\r.TaskMetadataR\bmetadata\"'\n" +
+	"\bmetadata\x18\x02 \x01(\v2\r.TaskMetadataR\bmetadata\"B\n" +
+	"\x0fQuickAddRequest\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x1b\n" +
+	"\ttime_zone\x18\x02 \x01(\tR\btimeZone\"_\n" +
+	"\rQuickAddMatch\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\rR\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\rR\x03end\x12&\n" +
+	"\x04kind\x18\x03 \x01(\x0e2\x12.QuickAddMatchKindR\x04kind\"z\n" +
+	"\x10QuickAddResponse\x12!\n" +
+	"\x06parsed\x18\x01 \x01(\v2\t.TaskDataR\x06parsed\x12(\n" +
+	"\amatches\x18\x02 \x03(\v2\x0e.QuickAddMatchR\amatches\x12\x19\n" +
+	"\x04task\x18\x03 \x01(\v2\x05.TaskR\x04task\"\xb5\x01\n" +
+	"\rTimeEntryData\x12\x1e\n" +
+	"\atask_id\x18\x01 \x01(\v2\x05.UUIDR\x06taskId\x129\n" +
+	"\n" +
+	"started_on\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedOn\x125\n" +
+	"\bended_on\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendedOn\x12\x12\n" +
+	"\x04note\x18\x04 \x01(\tR\x04note\"}\n" +
+	"\tTimeEntry\x12\x15\n" +
+	"\x02id\x18\x01 \x01(\v2\x05.UUIDR\x02id\x12\"\n" +
+	"\x04data\x18\x02 \x01(\v2\x0e.TimeEntryDataR\x04data\x125\n" +
+	"\bduration\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\bduration\"5\n" +
+	"\rTimeEntryList\x12$\n" +
+	"\aentries\x18\x01 \x03(\v2\n" +
+	".TimeEntryR\aentries\"f\n" +
+	"\x11StartTimerRequest\x12\x1e\n" +
+	"\atask_id\x18\x01 \x01(\v2\x05.UUIDR\x06taskId\x12\x12\n" +
+	"\x04note\x18\x02 \x01(\tR\x04note\x12\x1d\n" +
+	"\n" +
+	"start_task\x18\x03 \x01(\bR\tstartTask\"\\\n" +
+	"\x12StartTimerResponse\x12 \n" +
+	"\x05entry\x18\x01 \x01(\v2\n" +
+	".TimeEntryR\x05entry\x12$\n" +
+	"\astopped\x18\x02 \x01(\v2\n" +
+	".TimeEntryR\astopped\"g\n" +
+	"\tTimeRange\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"R\n" +
+	"\x0eTimeEntryQuery\x12 \n" +
+	"\x05range\x18\x01 \x01(\v2\n" +
+	".TimeRangeR\x05range\x12\x1e\n" +
+	"\atask_id\x18\x02 \x01(\v2\x05.UUIDR\x06taskId\"f\n" +
+	"\rTaskTimeTotal\x12\x1e\n" +
+	"\atask_id\x18\x01 \x01(\v2\x05.UUIDR\x06taskId\x125\n" +
+	"\bduration\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\bduration\"W\n" +
+	"\fTagTimeTotal\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x125\n" +
+	"\bduration\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\bduration\"\x86\x01\n" +
+	"\n" +
+	"TimeTotals\x12/\n" +
+	"\x05total\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x05total\x12$\n" +
+	"\x05tasks\x18\x02 \x03(\v2\x0e.TaskTimeTotalR\x05tasks\x12!\n" +
+	"\x04tags\x18\x03 \x03(\v2\r.TagTimeTotalR\x04tags\"\xc6\x01\n" +
+	"\n" +
+	"DateWindow\x12\x1e\n" +
+	"\bfrom_day\x18\x01 \x01(\x05H\x00R\afromDay\x88\x01\x01\x12\x1a\n" +
+	"\x06to_day\x18\x02 \x01(\x05H\x01R\x05toDay\x88\x01\x01\x120\n" +
+	"\x05after\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05after\x122\n" +
+	"\x06before\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x06beforeB\v\n" +
+	"\t_from_dayB\t\n" +
+	"\a_to_day\"~\n" +
+	"\bTaskSort\x12\x1e\n" +
+	"\x03key\x18\x01 \x01(\x0e2\f.TaskSortKeyR\x03key\x12\x1e\n" +
+	"\n" +
+	"descending\x18\x02 \x01(\bR\n" +
+	"descending\x12 \n" +
+	"\bfield_id\x18\x03 \x01(\v2\x05.UUIDR\afieldId\x12\x10\n" +
+	"\x03tag\x18\x04 \x01(\tR\x03tag\"_\n" +
+	"\x14CustomFieldCondition\x12\x1e\n" +
+	"\x02op\x18\x01 \x01(\x0e2\x0e.FieldOperatorR\x02op\x12'\n" +
+	"\x05value\x18\x02 \x01(\v2\x11.CustomFieldValueR\x05value\"\x97\x03\n" +
+	"\n" +
+	"TaskFilter\x12\"\n" +
+	"\x06states\x18\x01 \x03(\x0e2\n" +
+	".TaskStateR\x06states\x12\x19\n" +
+	"\btags_all\x18\x02 \x03(\tR\atagsAll\x12\x19\n" +
+	"\btags_any\x18\x03 \x03(\tR\atagsAny\x12\x1b\n" +
+	"\ttags_none\x18\x04 \x03(\tR\btagsNone\x12!\n" +
+	"\fpriority_min\x18\x05 \x01(\rR\vpriorityMin\x12!\n" +
+	"\fpriority_max\x18\x06 \x01(\rR\vpriorityMax\x12\x1d\n" +
+	"\x03due\x18\a \x01(\v2\v.DateWindowR\x03due\x12\x1b\n" +
+	"\x02do\x18\b \x01(\v2\v.DateWindowR\x02do\x12\x1d\n" +
+	"\x04sort\x18\t \x03(\v2\t.TaskSortR\x04sort\x12\x1b\n" +
+	"\ttime_zone\x18\n" +
+	" \x01(\tR\btimeZone\x12%\n" +
+	"\x0einclude_hidden\x18\v \x01(\bR\rincludeHidden\x12-\n" +
+	"\x06fields\x18\f \x03(\v2\x15.CustomFieldConditionR\x06fields\"J\n" +
+	"\x0fSavedFilterData\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12#\n" +
+	"\x06filter\x18\x02 \x01(\v2\v.TaskFilterR\x06filter\"\xc0\x01\n" +
+	"\vSavedFilter\x12\x15\n" +
+	"\x02id\x18\x01 \x01(\v2\x05.UUIDR\x02id\x12$\n" +
+	"\x04data\x18\x02 \x01(\v2\x10.SavedFilterDataR\x04data\x129\n" +
+	"\n" +
+	"created_on\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedOn\x129\n" +
+	"\n" +
+	"updated_on\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedOn\"9\n" +
+	"\x0fSavedFilterList\x12&\n" +
+	"\afilters\x18\x01 \x03(\v2\f.SavedFilterR\afilters\"i\n" +
+	"\n" +
+	"TaskSource\x12*\n" +
+	"\fsaved_filter\x18\x01 \x01(\v2\x05.UUIDH\x00R\vsavedFilter\x12%\n" +
+	"\x06filter\x18\x02 \x01(\v2\v.TaskFilterH\x00R\x06filterB\b\n" +
+	"\x06source\"\xe9\x01\n" +
+	"\fTemplateTask\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x12\n" +
+	"\x04desc\x18\x02 \x01(\tR\x04desc\x12\x1a\n" +
+	"\bpriority\x18\x03 \x01(\rR\bpriority\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12\x17\n" +
+	"\ado_date\x18\x05 \x01(\tR\x06doDate\x12\x19\n" +
+	"\bdue_date\x18\x06 \x01(\tR\adueDate\x12\x1a\n" +
+	"\bsubtasks\x18\a \x03(\tR\bsubtasks\x12/\n" +
+	"\n" +
+	"recurrence\x18\b \x01(\v2\x0f.TaskRecurrenceR\n" +
+	"recurrence\"[\n" +
+	"\fTemplateData\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04desc\x18\x02 \x01(\tR\x04desc\x12#\n" +
+	"\x05tasks\x18\x03 \x03(\v2\r.TemplateTaskR\x05tasks\"\xd8\x01\n" +
+	"\bTemplate\x12\x15\n" +
+	"\x02id\x18\x01 \x01(\v2\x05.UUIDR\x02id\x12!\n" +
+	"\x04data\x18\x02 \x01(\v2\r.TemplateDataR\x04data\x129\n" +
+	"\n" +
+	"created_on\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedOn\x129\n" +
+	"\n" +
+	"updated_on\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedOn\x12\x1c\n" +
+	"\tvariables\x18\x05 \x03(\tR\tvariables\"7\n" +
+	"\fTemplateList\x12'\n" +
+	"\ttemplates\x18\x01 \x03(\v2\t.TemplateR\ttemplates\"\x8c\x02\n" +
+	"\x1aInstantiateTemplateRequest\x12\x15\n" +
+	"\x02id\x18\x01 \x01(\v2\x05.UUIDR\x02id\x12H\n" +
+	"\tvariables\x18\x02 \x03(\v2*.InstantiateTemplateRequest.VariablesEntryR\tvariables\x122\n" +
+	"\x06anchor\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x06anchor\x12\x1b\n" +
+	"\ttime_zone\x18\x04 \x01(\tR\btimeZone\x1a<\n" +
+	"\x0eVariablesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"&\n" +
+	"\x10TemplateDocument\x12\x12\n" +
+	"\x04json\x18\x01 \x01(\tR\x04json\"\x9a\x01\n" +
+	"\rSnoozeRequest\x12\x15\n" +
+	"\x02id\x18\x01 \x01(\v2\x05.UUIDR\x02id\x127\n" +
+	"\bduration\x18\x02 \x01(\v2\x19.google.protobuf.DurationH\x00R\bduration\x120\n" +
+	"\x04date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x04dateB\a\n" +
+	"\x05until\"\x8a\x01\n" +
+	"\x0eSnoozeResponse\x12=\n" +
+	"\fhidden_until\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\vhiddenUntil\x129\n" +
+	"\n" +
+	"updated_on\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedOn\"\x85\x01\n" +
+	"\x0fMoveTaskRequest\x12\x15\n" +
+	"\x02id\x18\x01 \x01(\v2\x05.UUIDR\x02id\x12\x10\n" +
+	"\x03tag\x18\x02 \x01(\tR\x03tag\x12\x1f\n" +
+	"\x06before\x18\x03 \x01(\v2\x05.UUIDH\x00R\x06before\x12\x1d\n" +
+	"\x05after\x18\x04 \x01(\v2\x05.UUIDH\x00R\x05afterB\t\n" +
+	"\asibling\"\xbb\x03\n" +
+	"\x13UrgencyCoefficients\x12\x1f\n" +
+	"\bpriority\x18\x01 \x01(\x01H\x00R\bpriority\x88\x01\x01\x12\x15\n" +
+	"\x03due\x18\x02 \x01(\x01H\x01R\x03due\x88\x01\x01\x12\x15\n" +
+	"\x03age\x18\x03 \x01(\x01H\x02R\x03age\x88\x01\x01\x12\x1d\n" +
+	"\aongoing\x18\x04 \x01(\x01H\x03R\aongoing\x88\x01\x01\x12\x1d\n" +
+	"\ablocked\x18\x05 \x01(\x01H\x04R\ablocked\x88\x01\x01\x12!\n" +
+	"\tscheduled\x18\x06 \x01(\x01H\x05R\tscheduled\x88\x01\x01\x12\x1b\n" +
+	"\x06hidden\x18\a \x01(\x01H\x06R\x06hidden\x88\x01\x01\x12\x17\n" +
+	"\x04tags\x18\b \x01(\x01H\aR\x04tags\x88\x01\x01\x12/\n" +
+	"\x03tag\x18\t \x03(\v2\x1d.UrgencyCoefficients.TagEntryR\x03tag\x1a6\n" +
+	"\bTagEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01B\v\n" +
+	"\t_priorityB\x06\n" +
+	"\x04_dueB\x06\n" +
+	"\x04_ageB\n" +
+	"\n" +
+	"\b_ongoingB\n" +
+	"\n" +
+	"\b_blockedB\f\n" +
+	"\n" +
+	"_scheduledB\t\n" +
+	"\a_hiddenB\a\n" +
+	"\x05_tags\"\x93\x01\n" +
+	"\vPreferences\x12.\n" +
+	"\aurgency\x18\x01 \x01(\v2\x14.UrgencyCoefficientsR\aurgency\x12\x1b\n" +
+	"\ttime_zone\x18\x02 \x01(\tR\btimeZone\x12\x16\n" +
+	"\x06locale\x18\x03 \x01(\tR\x06locale\x12\x1f\n" +
+	"\vdigest_time\x18\x04 \x01(\tR\n" +
+	"digestTime\"\x8a\x01\n" +
+	"\aSetting\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x129\n" +
+	"\n" +
+	"updated_on\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedOn\"3\n" +
+	"\vSettingList\x12$\n" +
+	"\bsettings\x18\x01 \x03(\v2\b.SettingR\bsettings\"/\n" +
+	"\x0fSettingsRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"\xb5\x01\n" +
+	"\x10PushEndpointData\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12%\n" +
+	"\x04kind\x18\x02 \x01(\x0e2\x11.PushEndpointKindR\x04kind\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x14\n" +
+	"\x05token\x18\x04 \x01(\tR\x05token\x12\"\n" +
+	"\x06events\x18\x05 \x03(\x0e2\n" +
+	".PushEventR\x06events\x12\x1a\n" +
+	"\bdisabled\x18\x06 \x01(\bR\bdisabled\"\xad\x01\n" +
+	"\x12PushEndpointStatus\x12\x1a\n" +
+	"\bfailures\x18\x01 \x01(\rR\bfailures\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x02 \x01(\tR\tlastError\x12?\n" +
+	"\rlast_delivery\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\flastDelivery\x12\x1b\n" +
+	"\thas_token\x18\x04 \x01(\bR\bhasToken\"y\n" +
+	"\fPushEndpoint\x12\x15\n" +
+	"\x02id\x18\x01 \x01(\v2\x05.UUIDR\x02id\x12%\n" +
+	"\x04data\x18\x02 \x01(\v2\x11.PushEndpointDataR\x04data\x12+\n" +
+	"\x06status\x18\x03 \x01(\v2\x13.PushEndpointStatusR\x06status\"?\n" +
+	"\x10PushEndpointList\x12+\n" +
+	"\tendpoints\x18\x01 \x03(\v2\r.PushEndpointR\tendpoints\"=\n" +
+	"\x15ClientCertificateData\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03pem\x18\x02 \x01(\tR\x03pem\"\xa7\x02\n" +
+	"\x11ClientCertificate\x12\x15\n" +
+	"\x02id\x18\x01 \x01(\v2\x05.UUIDR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vfingerprint\x18\x03 \x01(\tR\vfingerprint\x12\x18\n" +
+	"\asubject\x18\x04 \x01(\tR\asubject\x127\n" +
+	"\tnot_after\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bnotAfter\x129\n" +
+	"\n" +
+	"created_on\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedOn\x127\n" +
+	"\tlast_used\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\blastUsed\"O\n" +
+	"\x15ClientCertificateList\x126\n" +
+	"\fcertificates\x18\x01 \x03(\v2\x12.ClientCertificateR\fcertificates\"z\n" +
+	"\x11PersonalTokenData\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06scopes\x18\x02 \x03(\tR\x06scopes\x129\n" +
+	"\n" +
+	"expires_on\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresOn\"\xc2\x01\n" +
+	"\rPersonalToken\x12\x15\n" +
+	"\x02id\x18\x01 \x01(\v2\x05.UUIDR\x02id\x12&\n" +
+	"\x04data\x18\x02 \x01(\v2\x12.PersonalTokenDataR\x04data\x129\n" +
+	"\n" +
+	"created_on\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedOn\x127\n" +
+	"\tlast_used\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\blastUsed\"X\n" +
+	"\x18NewPersonalTokenResponse\x12$\n" +
+	"\x05token\x18\x01 \x01(\v2\x0e.PersonalTokenR\x05token\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\";\n" +
+	"\x11PersonalTokenList\x12&\n" +
+	"\x06tokens\x18\x01 \x03(\v2\x0e.PersonalTokenR\x06tokens\"\x91\x02\n" +
+	"\aSession\x12\x15\n" +
+	"\x02id\x18\x01 \x01(\v2\x05.UUIDR\x02id\x12\x16\n" +
+	"\x06device\x18\x02 \x01(\tR\x06device\x12\x0e\n" +
+	"\x02ip\x18\x03 \x01(\tR\x02ip\x129\n" +
+	"\n" +
+	"created_on\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedOn\x127\n" +
+	"\tlast_used\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\blastUsed\x129\n" +
+	"\n" +
+	"expires_on\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresOn\x12\x18\n" +
+	"\acurrent\x18\a \x01(\bR\acurrent\"3\n" +
+	"\vSessionList\x12$\n" +
+	"\bsessions\x18\x01 \x03(\v2\b.SessionR\bsessions\":\n" +
+	"\x0eTOTPEnrollment\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12\x10\n" +
+	"\x03uri\x18\x02 \x01(\tR\x03uri\"\x1e\n" +
+	"\bTOTPCode\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"%\n" +
+	"\rRecoveryCodes\x12\x14\n" +
+	"\x05codes\x18\x01 \x03(\tR\x05codes\"V\n" +
+	"\rAgendaRequest\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\tR\x05start\x12\x12\n" +
+	"\x04days\x18\x02 \x01(\rR\x04days\x12\x1b\n" +
+	"\ttime_zone\x18\x03 \x01(\tR\btimeZone\"\xaf\x01\n" +
+	"\tAgendaDay\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x120\n" +
+	"\x05start\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12\x17\n" +
+	"\x03due\x18\x04 \x03(\v2\x05.TaskR\x03due\x12\x15\n" +
+	"\x02do\x18\x05 \x03(\v2\x05.TaskR\x02do\"f\n" +
+	"\x06Agenda\x12\x1b\n" +
+	"\ttime_zone\x18\x01 \x01(\tR\btimeZone\x12\x1e\n" +
+	"\x04days\x18\x02 \x03(\v2\n" +
+	".AgendaDayR\x04days\x12\x1f\n" +
+	"\aoverdue\x18\x03 \x03(\v2\x05.TaskR\aoverdue\"'\n" +
 	"\bTaskList\x12\x1b\n" +
 	"\x05tasks\x18\x01 \x03(\v2\x05.TaskR\x05tasks\"'\n" +
 	"\bUserList\x12\x1b\n" +
@@ -1360,7 +6459,10 @@ const file_schema_proto_rawDesc = "" +
 	"\arefresh\x18\x02 \x01(\tR\arefresh\"H\n" +
 	"\rLoginResponse\x12\x19\n" +
 	"\x04user\x18\x01 \x01(\v2\x05.UserR\x04user\x12\x1c\n" +
-	"\x06tokens\x18\x02 \x01(\v2\x04.JWTR\x06tokens\"S\n" +
+	"\x06tokens\x18\x02 \x01(\v2\x04.JWTR\x06tokens\"C\n" +
+	"\x10OIDCLoginRequest\x12\x19\n" +
+	"\bid_token\x18\x01 \x01(\tR\aidToken\x12\x14\n" +
+	"\x05nonce\x18\x02 \x01(\tR\x05nonce\"S\n" +
 	"\x11UserSignupRequest\x12\x1d\n" +
 	"\x04user\x18\x01 \x01(\v2\t.UserDataR\x04user\x12\x1f\n" +
 	"\vuser_secret\x18\x02 \x01(\tR\n" +
@@ -1377,15 +6479,64 @@ const file_schema_proto_rawDesc = "" +
 	"\aPENDING\x10\x01\x12\v\n" +
 	"\aONGOING\x10\x02\x12\b\n" +
 	"\x04DONE\x10\x03\x12\v\n" +
-	"\aBLOCKED\x10\x04*W\n" +
+	"\aBLOCKED\x10\x04*\xa5\x01\n" +
 	"\rTaskFieldMask\x12\t\n" +
 	"\x05TITLE\x10\x00\x12\b\n" +
 	"\x04DESC\x10\x01\x12\f\n" +
 	"\bPRIORITY\x10\x02\x12\t\n" +
 	"\x05STATE\x10\x03\x12\x0e\n" +
 	"\n" +
-	"RECURRENCE\x10\x04\x12\b\n" +
-	"\x04TAGS\x10\a2\xc4\x03\n" +
+	"RECURRENCE\x10\x04\x12\v\n" +
+	"\aDO_DATE\x10\x05\x12\f\n" +
+	"\bDUE_DATE\x10\x06\x12\b\n" +
+	"\x04TAGS\x10\a\x12\f\n" +
+	"\bASSIGNEE\x10\b\x12\x10\n" +
+	"\fHIDDEN_UNTIL\x10\t\x12\x11\n" +
+	"\rCUSTOM_FIELDS\x10\n" +
+	"*d\n" +
+	"\x0fCustomFieldKind\x12\x10\n" +
+	"\fFIELD_STRING\x10\x00\x12\x10\n" +
+	"\fFIELD_NUMBER\x10\x01\x12\x0e\n" +
+	"\n" +
+	"FIELD_DATE\x10\x02\x12\x0e\n" +
+	"\n" +
+	"FIELD_ENUM\x10\x03\x12\r\n" +
+	"\tFIELD_URL\x10\x04*\x87\x01\n" +
+	"\x11QuickAddMatchKind\x12\x16\n" +
+	"\x12QUICK_ADD_DUE_DATE\x10\x00\x12\x15\n" +
+	"\x11QUICK_ADD_DO_DATE\x10\x01\x12\x11\n" +
+	"\rQUICK_ADD_TAG\x10\x02\x12\x16\n" +
+	"\x12QUICK_ADD_PRIORITY\x10\x03\x12\x18\n" +
+	"\x14QUICK_ADD_RECURRENCE\x10\x04*\xc9\x01\n" +
+	"\vTaskSortKey\x12\x13\n" +
+	"\x0fSORT_CREATED_ON\x10\x00\x12\x13\n" +
+	"\x0fSORT_UPDATED_ON\x10\x01\x12\x11\n" +
+	"\rSORT_DUE_DATE\x10\x02\x12\x10\n" +
+	"\fSORT_DO_DATE\x10\x03\x12\x11\n" +
+	"\rSORT_PRIORITY\x10\x04\x12\x0e\n" +
+	"\n" +
+	"SORT_TITLE\x10\x05\x12\x0e\n" +
+	"\n" +
+	"SORT_STATE\x10\x06\x12\x15\n" +
+	"\x11SORT_CUSTOM_FIELD\x10\a\x12\x0f\n" +
+	"\vSORT_MANUAL\x10\b\x12\x10\n" +
+	"\fSORT_URGENCY\x10\t*\x9e\x01\n" +
+	"\rFieldOperator\x12\x10\n" +
+	"\fFIELD_EQUALS\x10\x00\x12\x14\n" +
+	"\x10FIELD_NOT_EQUALS\x10\x01\x12\x13\n" +
+	"\x0fFIELD_LESS_THAN\x10\x02\x12\x16\n" +
+	"\x12FIELD_GREATER_THAN\x10\x03\x12\x12\n" +
+	"\x0eFIELD_CONTAINS\x10\x04\x12\x10\n" +
+	"\fFIELD_IS_SET\x10\x05\x12\x12\n" +
+	"\x0eFIELD_IS_UNSET\x10\x06*.\n" +
+	"\x10PushEndpointKind\x12\x10\n" +
+	"\fUNIFIED_PUSH\x10\x00\x12\b\n" +
+	"\x04NTFY\x10\x01*W\n" +
+	"\tPushEvent\x12\x11\n" +
+	"\rPUSH_REMINDER\x10\x00\x12\f\n" +
+	"\bPUSH_DUE\x10\x01\x12\x16\n" +
+	"\x12PUSH_SHARED_CHANGE\x10\x02\x12\x11\n" +
+	"\rPUSH_REVEALED\x10\x032\x90\x19\n" +
 	"\x05Rafta\x120\n" +
 	"\vGetAllTasks\x12\x16.google.protobuf.Empty\x1a\t.TaskList\x12\x17\n" +
 	"\aGetTask\x12\x05.UUID\x1a\x05.Task\x12,\n" +
@@ -1398,7 +6549,69 @@ const file_schema_proto_rawDesc = "" +
 	"\n" +
 	"DeleteTask\x12\x05.UUID\x1a\x16.google.protobuf.Empty\x125\n" +
 	"\n" +
-	"UpdateTask\x12\x12.TaskUpdateRequest\x1a\x13.TaskUpdateResponse2\x9d\x03\n" +
+	"UpdateTask\x12\x12.TaskUpdateRequest\x1a\x13.TaskUpdateResponse\x125\n" +
+	"\x10GetAssignedTasks\x12\x16.google.protobuf.Empty\x1a\t.TaskList\x120\n" +
+	"\x12GetTaskAssignments\x12\x05.UUID\x1a\x13.TaskAssignmentList\x12H\n" +
+	"\x13ToggleChecklistItem\x12\x17.ChecklistToggleRequest\x1a\x18.ChecklistToggleResponse\x123\n" +
+	"\fQuickAddTask\x12\x10.QuickAddRequest\x1a\x11.QuickAddResponse\x124\n" +
+	"\rParseQuickAdd\x12\x10.QuickAddRequest\x1a\x11.QuickAddResponse\x125\n" +
+	"\n" +
+	"StartTimer\x12\x12.StartTimerRequest\x1a\x13.StartTimerResponse\x12/\n" +
+	"\tStopTimer\x12\x16.google.protobuf.Empty\x1a\n" +
+	".TimeEntry\x12*\n" +
+	"\fNewTimeEntry\x12\x0e.TimeEntryData\x1a\n" +
+	".TimeEntry\x12)\n" +
+	"\x0fUpdateTimeEntry\x12\n" +
+	".TimeEntry\x1a\n" +
+	".TimeEntry\x120\n" +
+	"\x0fDeleteTimeEntry\x12\x05.UUID\x1a\x16.google.protobuf.Empty\x121\n" +
+	"\x0eGetTimeEntries\x12\x0f.TimeEntryQuery\x1a\x0e.TimeEntryList\x12(\n" +
+	"\rGetTimeTotals\x12\n" +
+	".TimeRange\x1a\v.TimeTotals\x12+\n" +
+	"\tNewFilter\x12\x10.SavedFilterData\x1a\f.SavedFilter\x126\n" +
+	"\n" +
+	"GetFilters\x12\x16.google.protobuf.Empty\x1a\x10.SavedFilterList\x12*\n" +
+	"\fUpdateFilter\x12\f.SavedFilter\x1a\f.SavedFilter\x12-\n" +
+	"\fDeleteFilter\x12\x05.UUID\x1a\x16.google.protobuf.Empty\x12(\n" +
+	"\x0eEvaluateFilter\x12\v.TaskSource\x1a\t.TaskList\x12'\n" +
+	"\vNewTemplate\x12\r.TemplateData\x1a\t.Template\x125\n" +
+	"\fGetTemplates\x12\x16.google.protobuf.Empty\x1a\r.TemplateList\x12&\n" +
+	"\x0eUpdateTemplate\x12\t.Template\x1a\t.Template\x12/\n" +
+	"\x0eDeleteTemplate\x12\x05.UUID\x1a\x16.google.protobuf.Empty\x12=\n" +
+	"\x13InstantiateTemplate\x12\x1b.InstantiateTemplateRequest\x1a\t.TaskList\x12*\n" +
+	"\x0eExportTemplate\x12\x05.UUID\x1a\x11.TemplateDocument\x12.\n" +
+	"\x0eImportTemplate\x12\x11.TemplateDocument\x1a\t.Template\x12-\n" +
+	"\n" +
+	"SnoozeTask\x12\x0e.SnoozeRequest\x1a\x0f.SnoozeResponse\x126\n" +
+	"\x0eNewCustomField\x12\x16.CustomFieldDefinition\x1a\f.CustomField\x12;\n" +
+	"\x0fGetCustomFields\x12\x16.google.protobuf.Empty\x1a\x10.CustomFieldList\x12/\n" +
+	"\x11UpdateCustomField\x12\f.CustomField\x1a\f.CustomField\x122\n" +
+	"\x11DeleteCustomField\x12\x05.UUID\x1a\x16.google.protobuf.Empty\x124\n" +
+	"\bMoveTask\x12\x10.MoveTaskRequest\x1a\x16.google.protobuf.Empty\x126\n" +
+	"\x0eGetPreferences\x12\x16.google.protobuf.Empty\x1a\f.Preferences\x12/\n" +
+	"\x11UpdatePreferences\x12\f.Preferences\x1a\f.Preferences\x12$\n" +
+	"\tGetAgenda\x12\x0e.AgendaRequest\x1a\a.Agenda\x12-\n" +
+	"\vGetSettings\x12\x10.SettingsRequest\x1a\f.SettingList\x12)\n" +
+	"\vSetSettings\x12\f.SettingList\x1a\f.SettingList\x12-\n" +
+	"\rWatchSettings\x12\x10.SettingsRequest\x1a\b.Setting0\x01\x123\n" +
+	"\x0fNewPushEndpoint\x12\x11.PushEndpointData\x1a\r.PushEndpoint\x12=\n" +
+	"\x10GetPushEndpoints\x12\x16.google.protobuf.Empty\x1a\x11.PushEndpointList\x122\n" +
+	"\x12UpdatePushEndpoint\x12\r.PushEndpoint\x1a\r.PushEndpoint\x123\n" +
+	"\x12DeletePushEndpoint\x12\x05.UUID\x1a\x16.google.protobuf.Empty\x12(\n" +
+	"\x10TestPushEndpoint\x12\x05.UUID\x1a\r.PushEndpoint\x12B\n" +
+	"\x14NewClientCertificate\x12\x16.ClientCertificateData\x1a\x12.ClientCertificate\x12G\n" +
+	"\x15GetClientCertificates\x12\x16.google.protobuf.Empty\x1a\x16.ClientCertificateList\x128\n" +
+	"\x17DeleteClientCertificate\x12\x05.UUID\x1a\x16.google.protobuf.Empty\x12A\n" +
+	"\x10NewPersonalToken\x12\x12.PersonalTokenData\x1a\x19.NewPersonalTokenResponse\x12?\n" +
+	"\x11GetPersonalTokens\x12\x16.google.protobuf.Empty\x1a\x12.PersonalTokenList\x124\n" +
+	"\x13RevokePersonalToken\x12\x05.UUID\x1a\x16.google.protobuf.Empty\x124\n" +
+	"\fListSessions\x12\x16.google.protobuf.Empty\x1a\f.SessionList\x12.\n" +
+	"\rRevokeSession\x12\x05.UUID\x1a\x16.google.protobuf.Empty\x12H\n" +
+	"\x16RevokeAllOtherSessions\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\x125\n" +
+	"\n" +
+	"EnrollTOTP\x12\x16.google.protobuf.Empty\x1a\x0f.TOTPEnrollment\x12(\n" +
+	"\vConfirmTOTP\x12\t.TOTPCode\x1a\x0e.RecoveryCodes\x120\n" +
+	"\vDisableTOTP\x12\t.TOTPCode\x1a\x16.google.protobuf.Empty2\xdb\x03\n" +
 	"\x05Admin\x120\n" +
 	"\vGetAllUsers\x12\x16.google.protobuf.Empty\x1a\t.UserList\x12\x17\n" +
 	"\aGetUser\x12\x05.UUID\x1a\x05.User\x12 \n" +
@@ -1410,12 +6623,15 @@ const file_schema_proto_rawDesc = "" +
 	"\n" +
 	"UpdateUser\x12\x05.User\x1a\x16.google.protobuf.Empty\x12!\n" +
 	"\fGetUserRoles\x12\x05.UUID\x1a\n" +
-	".UserRoles\x120\n" +
-	"\x0fUpdateUserRoles\x12\x05.UUID\x1a\x16.google.protobuf.Empty2\x8e\x01\n" +
+	".UserRoles\x12B\n" +
+	"\x0fUpdateUserRoles\x12\x17.UpdateUserRolesRequest\x1a\x16.google.protobuf.Empty\x12*\n" +
+	"\tResetTOTP\x12\x05.UUID\x1a\x16.google.protobuf.Empty2\xf8\x01\n" +
 	"\x04Auth\x12,\n" +
 	"\x06Signup\x12\x12.UserSignupRequest\x1a\x0e.LoginResponse\x12/\n" +
-	"\x05Login\x12\x16.google.protobuf.Empty\x1a\x0e.LoginResponse\x12'\n" +
-	"\aRefresh\x12\x16.google.protobuf.Empty\x1a\x04.JWTB,Z*github.com/ChausseBenjamin/rafta/pkg/modelb\x06proto3"
+	"\x05Login\x12\x16.google.protobuf.Empty\x1a\x0e.LoginResponse\x12.\n" +
+	"\tOIDCLogin\x12\x11.OIDCLoginRequest\x1a\x0e.LoginResponse\x12'\n" +
+	"\aRefresh\x12\x16.google.protobuf.Empty\x1a\x04.JWT\x128\n" +
+	"\x06Logout\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.EmptyB,Z*github.com/ChausseBenjamin/rafta/pkg/modelb\x06proto3"
 
 var (
 	file_schema_proto_rawDescOnce sync.Once
@@ -1429,111 +6645,417 @@ func file_schema_proto_rawDescGZIP() []byte {
 	return file_schema_proto_rawDescData
 }
 
-var file_schema_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_schema_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_schema_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_schema_proto_msgTypes = make([]protoimpl.MessageInfo, 90)
 var file_schema_proto_goTypes = []any{
-	(TaskState)(0),                 // 0: TaskState
-	(TaskFieldMask)(0),             // 1: TaskFieldMask
-	(*UUID)(nil),                   // 2: UUID
-	(*UserData)(nil),               // 3: UserData
-	(*UserRoles)(nil),              // 4: UserRoles
-	(*UpdateUserRolesRequest)(nil), // 5: UpdateUserRolesRequest
-	(*UserMetadata)(nil),           // 6: UserMetadata
-	(*User)(nil),                   // 7: User
-	(*TaskRecurrence)(nil),         // 8: TaskRecurrence
-	(*TaskData)(nil),               // 9: TaskData
-	(*TaskMetadata)(nil),           // 10: TaskMetadata
-	(*TaskUpdateRequest)(nil),      // 11: TaskUpdateRequest
-	(*TaskUpdateResponse)(nil),     // 12: TaskUpdateResponse
-	(*Task)(nil),                   // 13: Task
-	(*NewTaskResponse)(nil),        // 14: NewTaskResponse
-	(*TaskList)(nil),               // 15: TaskList
-	(*UserList)(nil),               // 16: UserList
-	(*JWT)(nil),                    // 17: JWT
-	(*LoginResponse)(nil),          // 18: LoginResponse
-	(*UserSignupRequest)(nil),      // 19: UserSignupRequest
-	(*RefreshRequest)(nil),         // 20: RefreshRequest
-	(*ChangePasswdRequest)(nil),    // 21: ChangePasswdRequest
-	(*PasswdMessage)(nil),          // 22: PasswdMessage
-	(*timestamppb.Timestamp)(nil),  // 23: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),          // 24: google.protobuf.Empty
+	(TaskState)(0),                     // 0: TaskState
+	(TaskFieldMask)(0),                 // 1: TaskFieldMask
+	(CustomFieldKind)(0),               // 2: CustomFieldKind
+	(QuickAddMatchKind)(0),             // 3: QuickAddMatchKind
+	(TaskSortKey)(0),                   // 4: TaskSortKey
+	(FieldOperator)(0),                 // 5: FieldOperator
+	(PushEndpointKind)(0),              // 6: PushEndpointKind
+	(PushEvent)(0),                     // 7: PushEvent
+	(*UUID)(nil),                       // 8: UUID
+	(*UserData)(nil),                   // 9: UserData
+	(*UserRoles)(nil),                  // 10: UserRoles
+	(*UpdateUserRolesRequest)(nil),     // 11: UpdateUserRolesRequest
+	(*UserMetadata)(nil),               // 12: UserMetadata
+	(*User)(nil),                       // 13: User
+	(*TaskRecurrence)(nil),             // 14: TaskRecurrence
+	(*TaskData)(nil),                   // 15: TaskData
+	(*CalendarDate)(nil),               // 16: CalendarDate
+	(*TimeOfDay)(nil),                  // 17: TimeOfDay
+	(*TaskDate)(nil),                   // 18: TaskDate
+	(*CustomFieldDefinition)(nil),      // 19: CustomFieldDefinition
+	(*CustomField)(nil),                // 20: CustomField
+	(*CustomFieldList)(nil),            // 21: CustomFieldList
+	(*CustomFieldValue)(nil),           // 22: CustomFieldValue
+	(*TaskProgress)(nil),               // 23: TaskProgress
+	(*TaskMetadata)(nil),               // 24: TaskMetadata
+	(*TaskAssignment)(nil),             // 25: TaskAssignment
+	(*TaskAssignmentList)(nil),         // 26: TaskAssignmentList
+	(*TaskUpdateRequest)(nil),          // 27: TaskUpdateRequest
+	(*TaskUpdateResponse)(nil),         // 28: TaskUpdateResponse
+	(*Task)(nil),                       // 29: Task
+	(*ChecklistToggleRequest)(nil),     // 30: ChecklistToggleRequest
+	(*ChecklistToggleResponse)(nil),    // 31: ChecklistToggleResponse
+	(*NewTaskResponse)(nil),            // 32: NewTaskResponse
+	(*QuickAddRequest)(nil),            // 33: QuickAddRequest
+	(*QuickAddMatch)(nil),              // 34: QuickAddMatch
+	(*QuickAddResponse)(nil),           // 35: QuickAddResponse
+	(*TimeEntryData)(nil),              // 36: TimeEntryData
+	(*TimeEntry)(nil),                  // 37: TimeEntry
+	(*TimeEntryList)(nil),              // 38: TimeEntryList
+	(*StartTimerRequest)(nil),          // 39: StartTimerRequest
+	(*StartTimerResponse)(nil),         // 40: StartTimerResponse
+	(*TimeRange)(nil),                  // 41: TimeRange
+	(*TimeEntryQuery)(nil),             // 42: TimeEntryQuery
+	(*TaskTimeTotal)(nil),              // 43: TaskTimeTotal
+	(*TagTimeTotal)(nil),               // 44: TagTimeTotal
+	(*TimeTotals)(nil),                 // 45: TimeTotals
+	(*DateWindow)(nil),                 // 46: DateWindow
+	(*TaskSort)(nil),                   // 47: TaskSort
+	(*CustomFieldCondition)(nil),       // 48: CustomFieldCondition
+	(*TaskFilter)(nil),                 // 49: TaskFilter
+	(*SavedFilterData)(nil),            // 50: SavedFilterData
+	(*SavedFilter)(nil),                // 51: SavedFilter
+	(*SavedFilterList)(nil),            // 52: SavedFilterList
+	(*TaskSource)(nil),                 // 53: TaskSource
+	(*TemplateTask)(nil),               // 54: TemplateTask
+	(*TemplateData)(nil),               // 55: TemplateData
+	(*Template)(nil),                   // 56: Template
+	(*TemplateList)(nil),               // 57: TemplateList
+	(*InstantiateTemplateRequest)(nil), // 58: InstantiateTemplateRequest
+	(*TemplateDocument)(nil),           // 59: TemplateDocument
+	(*SnoozeRequest)(nil),              // 60: SnoozeRequest
+	(*SnoozeResponse)(nil),             // 61: SnoozeResponse
+	(*MoveTaskRequest)(nil),            // 62: MoveTaskRequest
+	(*UrgencyCoefficients)(nil),        // 63: UrgencyCoefficients
+	(*Preferences)(nil),                // 64: Preferences
+	(*Setting)(nil),                    // 65: Setting
+	(*SettingList)(nil),                // 66: SettingList
+	(*SettingsRequest)(nil),            // 67: SettingsRequest
+	(*PushEndpointData)(nil),           // 68: PushEndpointData
+	(*PushEndpointStatus)(nil),         // 69: PushEndpointStatus
+	(*PushEndpoint)(nil),               // 70: PushEndpoint
+	(*PushEndpointList)(nil),           // 71: PushEndpointList
+	(*ClientCertificateData)(nil),      // 72: ClientCertificateData
+	(*ClientCertificate)(nil),          // 73: ClientCertificate
+	(*ClientCertificateList)(nil),      // 74: ClientCertificateList
+	(*PersonalTokenData)(nil),          // 75: PersonalTokenData
+	(*PersonalToken)(nil),              // 76: PersonalToken
+	(*NewPersonalTokenResponse)(nil),   // 77: NewPersonalTokenResponse
+	(*PersonalTokenList)(nil),          // 78: PersonalTokenList
+	(*Session)(nil),                    // 79: Session
+	(*SessionList)(nil),                // 80: SessionList
+	(*TOTPEnrollment)(nil),             // 81: TOTPEnrollment
+	(*TOTPCode)(nil),                   // 82: TOTPCode
+	(*RecoveryCodes)(nil),              // 83: RecoveryCodes
+	(*AgendaRequest)(nil),              // 84: AgendaRequest
+	(*AgendaDay)(nil),                  // 85: AgendaDay
+	(*Agenda)(nil),                     // 86: Agenda
+	(*TaskList)(nil),                   // 87: TaskList
+	(*UserList)(nil),                   // 88: UserList
+	(*JWT)(nil),                        // 89: JWT
+	(*LoginResponse)(nil),              // 90: LoginResponse
+	(*OIDCLoginRequest)(nil),           // 91: OIDCLoginRequest
+	(*UserSignupRequest)(nil),          // 92: UserSignupRequest
+	(*RefreshRequest)(nil),             // 93: RefreshRequest
+	(*ChangePasswdRequest)(nil),        // 94: ChangePasswdRequest
+	(*PasswdMessage)(nil),              // 95: PasswdMessage
+	nil,                                // 96: InstantiateTemplateRequest.VariablesEntry
+	nil,                                // 97: UrgencyCoefficients.TagEntry
+	(*timestamppb.Timestamp)(nil),      // 98: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),      // 99: google.protobuf.FieldMask
+	(*durationpb.Duration)(nil),        // 100: google.protobuf.Duration
+	(*emptypb.Empty)(nil),              // 101: google.protobuf.Empty
 }
 var file_schema_proto_depIdxs = []int32{
-	2,  // 0: UpdateUserRolesRequest.user_id:type_name -> UUID
-	23, // 1: UserMetadata.created_on:type_name -> google.protobuf.Timestamp
-	23, // 2: UserMetadata.updated_on:type_name -> google.protobuf.Timestamp
-	2,  // 3: User.id:type_name -> UUID
-	3,  // 4: User.data:type_name -> UserData
-	6,  // 5: User.metadata:type_name -> UserMetadata
-	0,  // 6: TaskData.state:type_name -> TaskState
-	8,  // 7: TaskData.recurrence:type_name -> TaskRecurrence
-	23, // 8: TaskData.do_date:type_name -> google.protobuf.Timestamp
-	23, // 9: TaskData.due_date:type_name -> google.protobuf.Timestamp
-	23, // 10: TaskMetadata.created_on:type_name -> google.protobuf.Timestamp
-	23, // 11: TaskMetadata.updated_on:type_name -> google.protobuf.Timestamp
-	2,  // 12: TaskUpdateRequest.id:type_name -> UUID
-	9,  // 13: TaskUpdateRequest.data:type_name -> TaskData
-	1,  // 14: TaskUpdateRequest.masks:type_name -> TaskFieldMask
-	23, // 15: TaskUpdateResponse.updated_on:type_name -> google.protobuf.Timestamp
-	13, // 16: TaskUpdateResponse.new_task:type_name -> Task
-	2,  // 17: Task.id:type_name -> UUID
-	9,  // 18: Task.data:type_name -> TaskData
-	10, // 19: Task.metadata:type_name -> TaskMetadata
-	2,  // 20: NewTaskResponse.id:type_name -> UUID
-	10, // 21: NewTaskResponse.metadata:type_name -> TaskMetadata
-	13, // 22: TaskList.tasks:type_name -> Task
-	7,  // 23: UserList.users:type_name -> User
-	7,  // 24: LoginResponse.user:type_name -> User
-	17, // 25: LoginResponse.tokens:type_name -> JWT
-	3,  // 26: UserSignupRequest.user:type_name -> UserData
-	2,  // 27: ChangePasswdRequest.id:type_name -> UUID
-	24, // 28: Rafta.GetAllTasks:input_type -> google.protobuf.Empty
-	2,  // 29: Rafta.GetTask:input_type -> UUID
-	24, // 30: Rafta.GetUserInfo:input_type -> google.protobuf.Empty
-	24, // 31: Rafta.DeleteUser:input_type -> google.protobuf.Empty
-	22, // 32: Rafta.UpdateCredentials:input_type -> PasswdMessage
-	3,  // 33: Rafta.UpdateUserInfo:input_type -> UserData
-	9,  // 34: Rafta.NewTask:input_type -> TaskData
-	2,  // 35: Rafta.DeleteTask:input_type -> UUID
-	11, // 36: Rafta.UpdateTask:input_type -> TaskUpdateRequest
-	24, // 37: Admin.GetAllUsers:input_type -> google.protobuf.Empty
-	2,  // 38: Admin.GetUser:input_type -> UUID
-	2,  // 39: Admin.GetUserTasks:input_type -> UUID
-	21, // 40: Admin.UpdateCredentials:input_type -> ChangePasswdRequest
-	19, // 41: Admin.NewUser:input_type -> UserSignupRequest
-	2,  // 42: Admin.DeleteUser:input_type -> UUID
-	7,  // 43: Admin.UpdateUser:input_type -> User
-	2,  // 44: Admin.GetUserRoles:input_type -> UUID
-	2,  // 45: Admin.UpdateUserRoles:input_type -> UUID
-	19, // 46: Auth.Signup:input_type -> UserSignupRequest
-	24, // 47: Auth.Login:input_type -> google.protobuf.Empty
-	24, // 48: Auth.Refresh:input_type -> google.protobuf.Empty
-	15, // 49: Rafta.GetAllTasks:output_type -> TaskList
-	13, // 50: Rafta.GetTask:output_type -> Task
-	7,  // 51: Rafta.GetUserInfo:output_type -> User
-	24, // 52: Rafta.DeleteUser:output_type -> google.protobuf.Empty
-	23, // 53: Rafta.UpdateCredentials:output_type -> google.protobuf.Timestamp
-	23, // 54: Rafta.UpdateUserInfo:output_type -> google.protobuf.Timestamp
-	14, // 55: Rafta.NewTask:output_type -> NewTaskResponse
-	24, // 56: Rafta.DeleteTask:output_type -> google.protobuf.Empty
-	12, // 57: Rafta.UpdateTask:output_type -> TaskUpdateResponse
-	16, // 58: Admin.GetAllUsers:output_type -> UserList
-	7,  // 59: Admin.GetUser:output_type -> User
-	15, // 60: Admin.GetUserTasks:output_type -> TaskList
-	24, // 61: Admin.UpdateCredentials:output_type -> google.protobuf.Empty
-	24, // 62: Admin.NewUser:output_type -> google.protobuf.Empty
-	24, // 63: Admin.DeleteUser:output_type -> google.protobuf.Empty
-	24, // 64: Admin.UpdateUser:output_type -> google.protobuf.Empty
-	4,  // 65: Admin.GetUserRoles:output_type -> UserRoles
-	24, // 66: Admin.UpdateUserRoles:output_type -> google.protobuf.Empty
-	18, // 67: Auth.Signup:output_type -> LoginResponse
-	18, // 68: Auth.Login:output_type -> LoginResponse
-	17, // 69: Auth.Refresh:output_type -> JWT
-	49, // [49:70] is the sub-list for method output_type
-	28, // [28:49] is the sub-list for method input_type
-	28, // [28:28] is the sub-list for extension type_name
-	28, // [28:28] is the sub-list for extension extendee
-	0,  // [0:28] is the sub-list for field type_name
+	8,   // 0: UpdateUserRolesRequest.user_id:type_name -> UUID
+	98,  // 1: UserMetadata.created_on:type_name -> google.protobuf.Timestamp
+	98,  // 2: UserMetadata.updated_on:type_name -> google.protobuf.Timestamp
+	8,   // 3: User.id:type_name -> UUID
+	9,   // 4: User.data:type_name -> UserData
+	12,  // 5: User.metadata:type_name -> UserMetadata
+	0,   // 6: TaskData.state:type_name -> TaskState
+	14,  // 7: TaskData.recurrence:type_name -> TaskRecurrence
+	98,  // 8: TaskData.do_date:type_name -> google.protobuf.Timestamp
+	98,  // 9: TaskData.due_date:type_name -> google.protobuf.Timestamp
+	8,   // 10: TaskData.assignee:type_name -> UUID
+	98,  // 11: TaskData.hidden_until:type_name -> google.protobuf.Timestamp
+	22,  // 12: TaskData.fields:type_name -> CustomFieldValue
+	18,  // 13: TaskData.do:type_name -> TaskDate
+	18,  // 14: TaskData.due:type_name -> TaskDate
+	16,  // 15: TaskDate.date:type_name -> CalendarDate
+	17,  // 16: TaskDate.time:type_name -> TimeOfDay
+	2,   // 17: CustomFieldDefinition.kind:type_name -> CustomFieldKind
+	8,   // 18: CustomField.id:type_name -> UUID
+	19,  // 19: CustomField.data:type_name -> CustomFieldDefinition
+	20,  // 20: CustomFieldList.fields:type_name -> CustomField
+	8,   // 21: CustomFieldValue.field_id:type_name -> UUID
+	98,  // 22: CustomFieldValue.date:type_name -> google.protobuf.Timestamp
+	98,  // 23: TaskMetadata.created_on:type_name -> google.protobuf.Timestamp
+	98,  // 24: TaskMetadata.updated_on:type_name -> google.protobuf.Timestamp
+	8,   // 25: TaskAssignment.assignee:type_name -> UUID
+	8,   // 26: TaskAssignment.assigned_by:type_name -> UUID
+	98,  // 27: TaskAssignment.assigned_on:type_name -> google.protobuf.Timestamp
+	25,  // 28: TaskAssignmentList.assignments:type_name -> TaskAssignment
+	8,   // 29: TaskUpdateRequest.id:type_name -> UUID
+	15,  // 30: TaskUpdateRequest.data:type_name -> TaskData
+	1,   // 31: TaskUpdateRequest.masks:type_name -> TaskFieldMask
+	99,  // 32: TaskUpdateRequest.update_mask:type_name -> google.protobuf.FieldMask
+	98,  // 33: TaskUpdateResponse.updated_on:type_name -> google.protobuf.Timestamp
+	29,  // 34: TaskUpdateResponse.new_task:type_name -> Task
+	8,   // 35: Task.id:type_name -> UUID
+	15,  // 36: Task.data:type_name -> TaskData
+	24,  // 37: Task.metadata:type_name -> TaskMetadata
+	23,  // 38: Task.progress:type_name -> TaskProgress
+	8,   // 39: ChecklistToggleRequest.id:type_name -> UUID
+	23,  // 40: ChecklistToggleResponse.progress:type_name -> TaskProgress
+	98,  // 41: ChecklistToggleResponse.updated_on:type_name -> google.protobuf.Timestamp
+	8,   // 42: NewTaskResponse.id:type_name -> UUID
+	24,  // 43: NewTaskResponse.metadata:type_name -> TaskMetadata
+	3,   // 44: QuickAddMatch.kind:type_name -> QuickAddMatchKind
+	15,  // 45: QuickAddResponse.parsed:type_name -> TaskData
+	34,  // 46: QuickAddResponse.matches:type_name -> QuickAddMatch
+	29,  // 47: QuickAddResponse.task:type_name -> Task
+	8,   // 48: TimeEntryData.task_id:type_name -> UUID
+	98,  // 49: TimeEntryData.started_on:type_name -> google.protobuf.Timestamp
+	98,  // 50: TimeEntryData.ended_on:type_name -> google.protobuf.Timestamp
+	8,   // 51: TimeEntry.id:type_name -> UUID
+	36,  // 52: TimeEntry.data:type_name -> TimeEntryData
+	100, // 53: TimeEntry.duration:type_name -> google.protobuf.Duration
+	37,  // 54: TimeEntryList.entries:type_name -> TimeEntry
+	8,   // 55: StartTimerRequest.task_id:type_name -> UUID
+	37,  // 56: StartTimerResponse.entry:type_name -> TimeEntry
+	37,  // 57: StartTimerResponse.stopped:type_name -> TimeEntry
+	98,  // 58: TimeRange.from:type_name -> google.protobuf.Timestamp
+	98,  // 59: TimeRange.to:type_name -> google.protobuf.Timestamp
+	41,  // 60: TimeEntryQuery.range:type_name -> TimeRange
+	8,   // 61: TimeEntryQuery.task_id:type_name -> UUID
+	8,   // 62: TaskTimeTotal.task_id:type_name -> UUID
+	100, // 63: TaskTimeTotal.duration:type_name -> google.protobuf.Duration
+	100, // 64: TagTimeTotal.duration:type_name -> google.protobuf.Duration
+	100, // 65: TimeTotals.total:type_name -> google.protobuf.Duration
+	43,  // 66: TimeTotals.tasks:type_name -> TaskTimeTotal
+	44,  // 67: TimeTotals.tags:type_name -> TagTimeTotal
+	98,  // 68: DateWindow.after:type_name -> google.protobuf.Timestamp
+	98,  // 69: DateWindow.before:type_name -> google.protobuf.Timestamp
+	4,   // 70: TaskSort.key:type_name -> TaskSortKey
+	8,   // 71: TaskSort.field_id:type_name -> UUID
+	5,   // 72: CustomFieldCondition.op:type_name -> FieldOperator
+	22,  // 73: CustomFieldCondition.value:type_name -> CustomFieldValue
+	0,   // 74: TaskFilter.states:type_name -> TaskState
+	46,  // 75: TaskFilter.due:type_name -> DateWindow
+	46,  // 76: TaskFilter.do:type_name -> DateWindow
+	47,  // 77: TaskFilter.sort:type_name -> TaskSort
+	48,  // 78: TaskFilter.fields:type_name -> CustomFieldCondition
+	49,  // 79: SavedFilterData.filter:type_name -> TaskFilter
+	8,   // 80: SavedFilter.id:type_name -> UUID
+	50,  // 81: SavedFilter.data:type_name -> SavedFilterData
+	98,  // 82: SavedFilter.created_on:type_name -> google.protobuf.Timestamp
+	98,  // 83: SavedFilter.updated_on:type_name -> google.protobuf.Timestamp
+	51,  // 84: SavedFilterList.filters:type_name -> SavedFilter
+	8,   // 85: TaskSource.saved_filter:type_name -> UUID
+	49,  // 86: TaskSource.filter:type_name -> TaskFilter
+	14,  // 87: TemplateTask.recurrence:type_name -> TaskRecurrence
+	54,  // 88: TemplateData.tasks:type_name -> TemplateTask
+	8,   // 89: Template.id:type_name -> UUID
+	55,  // 90: Template.data:type_name -> TemplateData
+	98,  // 91: Template.created_on:type_name -> google.protobuf.Timestamp
+	98,  // 92: Template.updated_on:type_name -> google.protobuf.Timestamp
+	56,  // 93: TemplateList.templates:type_name -> Template
+	8,   // 94: InstantiateTemplateRequest.id:type_name -> UUID
+	96,  // 95: InstantiateTemplateRequest.variables:type_name -> InstantiateTemplateRequest.VariablesEntry
+	98,  // 96: InstantiateTemplateRequest.anchor:type_name -> google.protobuf.Timestamp
+	8,   // 97: SnoozeRequest.id:type_name -> UUID
+	100, // 98: SnoozeRequest.duration:type_name -> google.protobuf.Duration
+	98,  // 99: SnoozeRequest.date:type_name -> google.protobuf.Timestamp
+	98,  // 100: SnoozeResponse.hidden_until:type_name -> google.protobuf.Timestamp
+	98,  // 101: SnoozeResponse.updated_on:type_name -> google.protobuf.Timestamp
+	8,   // 102: MoveTaskRequest.id:type_name -> UUID
+	8,   // 103: MoveTaskRequest.before:type_name -> UUID
+	8,   // 104: MoveTaskRequest.after:type_name -> UUID
+	97,  // 105: UrgencyCoefficients.tag:type_name -> UrgencyCoefficients.TagEntry
+	63,  // 106: Preferences.urgency:type_name -> UrgencyCoefficients
+	98,  // 107: Setting.updated_on:type_name -> google.protobuf.Timestamp
+	65,  // 108: SettingList.settings:type_name -> Setting
+	6,   // 109: PushEndpointData.kind:type_name -> PushEndpointKind
+	7,   // 110: PushEndpointData.events:type_name -> PushEvent
+	98,  // 111: PushEndpointStatus.last_delivery:type_name -> google.protobuf.Timestamp
+	8,   // 112: PushEndpoint.id:type_name -> UUID
+	68,  // 113: PushEndpoint.data:type_name -> PushEndpointData
+	69,  // 114: PushEndpoint.status:type_name -> PushEndpointStatus
+	70,  // 115: PushEndpointList.endpoints:type_name -> PushEndpoint
+	8,   // 116: ClientCertificate.id:type_name -> UUID
+	98,  // 117: ClientCertificate.not_after:type_name -> google.protobuf.Timestamp
+	98,  // 118: ClientCertificate.created_on:type_name -> google.protobuf.Timestamp
+	98,  // 119: ClientCertificate.last_used:type_name -> google.protobuf.Timestamp
+	73,  // 120: ClientCertificateList.certificates:type_name -> ClientCertificate
+	98,  // 121: PersonalTokenData.expires_on:type_name -> google.protobuf.Timestamp
+	8,   // 122: PersonalToken.id:type_name -> UUID
+	75,  // 123: PersonalToken.data:type_name -> PersonalTokenData
+	98,  // 124: PersonalToken.created_on:type_name -> google.protobuf.Timestamp
+	98,  // 125: PersonalToken.last_used:type_name -> google.protobuf.Timestamp
+	76,  // 126: NewPersonalTokenResponse.token:type_name -> PersonalToken
+	76,  // 127: PersonalTokenList.tokens:type_name -> PersonalToken
+	8,   // 128: Session.id:type_name -> UUID
+	98,  // 129: Session.created_on:type_name -> google.protobuf.Timestamp
+	98,  // 130: Session.last_used:type_name -> google.protobuf.Timestamp
+	98,  // 131: Session.expires_on:type_name -> google.protobuf.Timestamp
+	79,  // 132: SessionList.sessions:type_name -> Session
+	98,  // 133: AgendaDay.start:type_name -> google.protobuf.Timestamp
+	98,  // 134: AgendaDay.end:type_name -> google.protobuf.Timestamp
+	29,  // 135: AgendaDay.due:type_name -> Task
+	29,  // 136: AgendaDay.do:type_name -> Task
+	85,  // 137: Agenda.days:type_name -> AgendaDay
+	29,  // 138: Agenda.overdue:type_name -> Task
+	29,  // 139: TaskList.tasks:type_name -> Task
+	13,  // 140: UserList.users:type_name -> User
+	13,  // 141: LoginResponse.user:type_name -> User
+	89,  // 142: LoginResponse.tokens:type_name -> JWT
+	9,   // 143: UserSignupRequest.user:type_name -> UserData
+	8,   // 144: ChangePasswdRequest.id:type_name -> UUID
+	101, // 145: Rafta.GetAllTasks:input_type -> google.protobuf.Empty
+	8,   // 146: Rafta.GetTask:input_type -> UUID
+	101, // 147: Rafta.GetUserInfo:input_type -> google.protobuf.Empty
+	101, // 148: Rafta.DeleteUser:input_type -> google.protobuf.Empty
+	95,  // 149: Rafta.UpdateCredentials:input_type -> PasswdMessage
+	9,   // 150: Rafta.UpdateUserInfo:input_type -> UserData
+	15,  // 151: Rafta.NewTask:input_type -> TaskData
+	8,   // 152: Rafta.DeleteTask:input_type -> UUID
+	27,  // 153: Rafta.UpdateTask:input_type -> TaskUpdateRequest
+	101, // 154: Rafta.GetAssignedTasks:input_type -> google.protobuf.Empty
+	8,   // 155: Rafta.GetTaskAssignments:input_type -> UUID
+	30,  // 156: Rafta.ToggleChecklistItem:input_type -> ChecklistToggleRequest
+	33,  // 157: Rafta.QuickAddTask:input_type -> QuickAddRequest
+	33,  // 158: Rafta.ParseQuickAdd:input_type -> QuickAddRequest
+	39,  // 159: Rafta.StartTimer:input_type -> StartTimerRequest
+	101, // 160: Rafta.StopTimer:input_type -> google.protobuf.Empty
+	36,  // 161: Rafta.NewTimeEntry:input_type -> TimeEntryData
+	37,  // 162: Rafta.UpdateTimeEntry:input_type -> TimeEntry
+	8,   // 163: Rafta.DeleteTimeEntry:input_type -> UUID
+	42,  // 164: Rafta.GetTimeEntries:input_type -> TimeEntryQuery
+	41,  // 165: Rafta.GetTimeTotals:input_type -> TimeRange
+	50,  // 166: Rafta.NewFilter:input_type -> SavedFilterData
+	101, // 167: Rafta.GetFilters:input_type -> google.protobuf.Empty
+	51,  // 168: Rafta.UpdateFilter:input_type -> SavedFilter
+	8,   // 169: Rafta.DeleteFilter:input_type -> UUID
+	53,  // 170: Rafta.EvaluateFilter:input_type -> TaskSource
+	55,  // 171: Rafta.NewTemplate:input_type -> TemplateData
+	101, // 172: Rafta.GetTemplates:input_type -> google.protobuf.Empty
+	56,  // 173: Rafta.UpdateTemplate:input_type -> Template
+	8,   // 174: Rafta.DeleteTemplate:input_type -> UUID
+	58,  // 175: Rafta.InstantiateTemplate:input_type -> InstantiateTemplateRequest
+	8,   // 176: Rafta.ExportTemplate:input_type -> UUID
+	59,  // 177: Rafta.ImportTemplate:input_type -> TemplateDocument
+	60,  // 178: Rafta.SnoozeTask:input_type -> SnoozeRequest
+	19,  // 179: Rafta.NewCustomField:input_type -> CustomFieldDefinition
+	101, // 180: Rafta.GetCustomFields:input_type -> google.protobuf.Empty
+	20,  // 181: Rafta.UpdateCustomField:input_type -> CustomField
+	8,   // 182: Rafta.DeleteCustomField:input_type -> UUID
+	62,  // 183: Rafta.MoveTask:input_type -> MoveTaskRequest
+	101, // 184: Rafta.GetPreferences:input_type -> google.protobuf.Empty
+	64,  // 185: Rafta.UpdatePreferences:input_type -> Preferences
+	84,  // 186: Rafta.GetAgenda:input_type -> AgendaRequest
+	67,  // 187: Rafta.GetSettings:input_type -> SettingsRequest
+	66,  // 188: Rafta.SetSettings:input_type -> SettingList
+	67,  // 189: Rafta.WatchSettings:input_type -> SettingsRequest
+	68,  // 190: Rafta.NewPushEndpoint:input_type -> PushEndpointData
+	101, // 191: Rafta.GetPushEndpoints:input_type -> google.protobuf.Empty
+	70,  // 192: Rafta.UpdatePushEndpoint:input_type -> PushEndpoint
+	8,   // 193: Rafta.DeletePushEndpoint:input_type -> UUID
+	8,   // 194: Rafta.TestPushEndpoint:input_type -> UUID
+	72,  // 195: Rafta.NewClientCertificate:input_type -> ClientCertificateData
+	101, // 196: Rafta.GetClientCertificates:input_type -> google.protobuf.Empty
+	8,   // 197: Rafta.DeleteClientCertificate:input_type -> UUID
+	75,  // 198: Rafta.NewPersonalToken:input_type -> PersonalTokenData
+	101, // 199: Rafta.GetPersonalTokens:input_type -> google.protobuf.Empty
+	8,   // 200: Rafta.RevokePersonalToken:input_type -> UUID
+	101, // 201: Rafta.ListSessions:input_type -> google.protobuf.Empty
+	8,   // 202: Rafta.RevokeSession:input_type -> UUID
+	101, // 203: Rafta.RevokeAllOtherSessions:input_type -> google.protobuf.Empty
+	101, // 204: Rafta.EnrollTOTP:input_type -> google.protobuf.Empty
+	82,  // 205: Rafta.ConfirmTOTP:input_type -> TOTPCode
+	82,  // 206: Rafta.DisableTOTP:input_type -> TOTPCode
+	101, // 207: Admin.GetAllUsers:input_type -> google.protobuf.Empty
+	8,   // 208: Admin.GetUser:input_type -> UUID
+	8,   // 209: Admin.GetUserTasks:input_type -> UUID
+	94,  // 210: Admin.UpdateCredentials:input_type -> ChangePasswdRequest
+	92,  // 211: Admin.NewUser:input_type -> UserSignupRequest
+	8,   // 212: Admin.DeleteUser:input_type -> UUID
+	13,  // 213: Admin.UpdateUser:input_type -> User
+	8,   // 214: Admin.GetUserRoles:input_type -> UUID
+	11,  // 215: Admin.UpdateUserRoles:input_type -> UpdateUserRolesRequest
+	8,   // 216: Admin.ResetTOTP:input_type -> UUID
+	92,  // 217: Auth.Signup:input_type -> UserSignupRequest
+	101, // 218: Auth.Login:input_type -> google.protobuf.Empty
+	91,  // 219: Auth.OIDCLogin:input_type -> OIDCLoginRequest
+	101, // 220: Auth.Refresh:input_type -> google.protobuf.Empty
+	101, // 221: Auth.Logout:input_type -> google.protobuf.Empty
+	87,  // 222: Rafta.GetAllTasks:output_type -> TaskList
+	29,  // 223: Rafta.GetTask:output_type -> Task
+	13,  // 224: Rafta.GetUserInfo:output_type -> User
+	101, // 225: Rafta.DeleteUser:output_type -> google.protobuf.Empty
+	98,  // 226: Rafta.UpdateCredentials:output_type -> google.protobuf.Timestamp
+	98,  // 227: Rafta.UpdateUserInfo:output_type -> google.protobuf.Timestamp
+	32,  // 228: Rafta.NewTask:output_type -> NewTaskResponse
+	101, // 229: Rafta.DeleteTask:output_type -> google.protobuf.Empty
+	28,  // 230: Rafta.UpdateTask:output_type -> TaskUpdateResponse
+	87,  // 231: Rafta.GetAssignedTasks:output_type -> TaskList
+	26,  // 232: Rafta.GetTaskAssignments:output_type -> TaskAssignmentList
+	31,  // 233: Rafta.ToggleChecklistItem:output_type -> ChecklistToggleResponse
+	35,  // 234: Rafta.QuickAddTask:output_type -> QuickAddResponse
+	35,  // 235: Rafta.ParseQuickAdd:output_type -> QuickAddResponse
+	40,  // 236: Rafta.StartTimer:output_type -> StartTimerResponse
+	37,  // 237: Rafta.StopTimer:output_type -> TimeEntry
+	37,  // 238: Rafta.NewTimeEntry:output_type -> TimeEntry
+	37,  // 239: Rafta.UpdateTimeEntry:output_type -> TimeEntry
+	101, // 240: Rafta.DeleteTimeEntry:output_type -> google.protobuf.Empty
+	38,  // 241: Rafta.GetTimeEntries:output_type -> TimeEntryList
+	45,  // 242: Rafta.GetTimeTotals:output_type -> TimeTotals
+	51,  // 243: Rafta.NewFilter:output_type -> SavedFilter
+	52,  // 244: Rafta.GetFilters:output_type -> SavedFilterList
+	51,  // 245: Rafta.UpdateFilter:output_type -> SavedFilter
+	101, // 246: Rafta.DeleteFilter:output_type -> google.protobuf.Empty
+	87,  // 247: Rafta.EvaluateFilter:output_type -> TaskList
+	56,  // 248: Rafta.NewTemplate:output_type -> Template
+	57,  // 249: Rafta.GetTemplates:output_type -> TemplateList
+	56,  // 250: Rafta.UpdateTemplate:output_type -> Template
+	101, // 251: Rafta.DeleteTemplate:output_type -> google.protobuf.Empty
+	87,  // 252: Rafta.InstantiateTemplate:output_type -> TaskList
+	59,  // 253: Rafta.ExportTemplate:output_type -> TemplateDocument
+	56,  // 254: Rafta.ImportTemplate:output_type -> Template
+	61,  // 255: Rafta.SnoozeTask:output_type -> SnoozeResponse
+	20,  // 256: Rafta.NewCustomField:output_type -> CustomField
+	21,  // 257: Rafta.GetCustomFields:output_type -> CustomFieldList
+	20,  // 258: Rafta.UpdateCustomField:output_type -> CustomField
+	101, // 259: Rafta.DeleteCustomField:output_type -> google.protobuf.Empty
+	101, // 260: Rafta.MoveTask:output_type -> google.protobuf.Empty
+	64,  // 261: Rafta.GetPreferences:output_type -> Preferences
+	64,  // 262: Rafta.UpdatePreferences:output_type -> Preferences
+	86,  // 263: Rafta.GetAgenda:output_type -> Agenda
+	66,  // 264: Rafta.GetSettings:output_type -> SettingList
+	66,  // 265: Rafta.SetSettings:output_type -> SettingList
+	65,  // 266: Rafta.WatchSettings:output_type -> Setting
+	70,  // 267: Rafta.NewPushEndpoint:output_type -> PushEndpoint
+	71,  // 268: Rafta.GetPushEndpoints:output_type -> PushEndpointList
+	70,  // 269: Rafta.UpdatePushEndpoint:output_type -> PushEndpoint
+	101, // 270: Rafta.DeletePushEndpoint:output_type -> google.protobuf.Empty
+	70,  // 271: Rafta.TestPushEndpoint:output_type -> PushEndpoint
+	73,  // 272: Rafta.NewClientCertificate:output_type -> ClientCertificate
+	74,  // 273: Rafta.GetClientCertificates:output_type -> ClientCertificateList
+	101, // 274: Rafta.DeleteClientCertificate:output_type -> google.protobuf.Empty
+	77,  // 275: Rafta.NewPersonalToken:output_type -> NewPersonalTokenResponse
+	78,  // 276: Rafta.GetPersonalTokens:output_type -> PersonalTokenList
+	101, // 277: Rafta.RevokePersonalToken:output_type -> google.protobuf.Empty
+	80,  // 278: Rafta.ListSessions:output_type -> SessionList
+	101, // 279: Rafta.RevokeSession:output_type -> google.protobuf.Empty
+	101, // 280: Rafta.RevokeAllOtherSessions:output_type -> google.protobuf.Empty
+	81,  // 281: Rafta.EnrollTOTP:output_type -> TOTPEnrollment
+	83,  // 282: Rafta.ConfirmTOTP:output_type -> RecoveryCodes
+	101, // 283: Rafta.DisableTOTP:output_type -> google.protobuf.Empty
+	88,  // 284: Admin.GetAllUsers:output_type -> UserList
+	13,  // 285: Admin.GetUser:output_type -> User
+	87,  // 286: Admin.GetUserTasks:output_type -> TaskList
+	101, // 287: Admin.UpdateCredentials:output_type -> google.protobuf.Empty
+	101, // 288: Admin.NewUser:output_type -> google.protobuf.Empty
+	101, // 289: Admin.DeleteUser:output_type -> google.protobuf.Empty
+	101, // 290: Admin.UpdateUser:output_type -> google.protobuf.Empty
+	10,  // 291: Admin.GetUserRoles:output_type -> UserRoles
+	101, // 292: Admin.UpdateUserRoles:output_type -> google.protobuf.Empty
+	101, // 293: Admin.ResetTOTP:output_type -> google.protobuf.Empty
+	90,  // 294: Auth.Signup:output_type -> LoginResponse
+	90,  // 295: Auth.Login:output_type -> LoginResponse
+	90,  // 296: Auth.OIDCLogin:output_type -> LoginResponse
+	89,  // 297: Auth.Refresh:output_type -> JWT
+	101, // 298: Auth.Logout:output_type -> google.protobuf.Empty
+	222, // [222:299] is the sub-list for method output_type
+	145, // [145:222] is the sub-list for method input_type
+	145, // [145:145] is the sub-list for extension type_name
+	145, // [145:145] is the sub-list for extension extendee
+	0,   // [0:145] is the sub-list for field type_name
 }
 
 func init() { file_schema_proto_init() }
@@ -1541,13 +7063,32 @@ func file_schema_proto_init() {
 	if File_schema_proto != nil {
 		return
 	}
+	file_schema_proto_msgTypes[14].OneofWrappers = []any{
+		(*CustomFieldValue_Text)(nil),
+		(*CustomFieldValue_Number)(nil),
+		(*CustomFieldValue_Date)(nil),
+	}
+	file_schema_proto_msgTypes[38].OneofWrappers = []any{}
+	file_schema_proto_msgTypes[45].OneofWrappers = []any{
+		(*TaskSource_SavedFilter)(nil),
+		(*TaskSource_Filter)(nil),
+	}
+	file_schema_proto_msgTypes[52].OneofWrappers = []any{
+		(*SnoozeRequest_Duration)(nil),
+		(*SnoozeRequest_Date)(nil),
+	}
+	file_schema_proto_msgTypes[54].OneofWrappers = []any{
+		(*MoveTaskRequest_Before)(nil),
+		(*MoveTaskRequest_After)(nil),
+	}
+	file_schema_proto_msgTypes[55].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_schema_proto_rawDesc), len(file_schema_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   21,
+			NumEnums:      8,
+			NumMessages:   90,
 			NumExtensions: 0,
 			NumServices:   3,
 		},
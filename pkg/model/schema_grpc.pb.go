@@ -21,15 +21,68 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Rafta_GetAllTasks_FullMethodName       = "/Rafta/GetAllTasks"
-	Rafta_GetTask_FullMethodName           = "/Rafta/GetTask"
-	Rafta_GetUserInfo_FullMethodName       = "/Rafta/GetUserInfo"
-	Rafta_DeleteUser_FullMethodName        = "/Rafta/DeleteUser"
-	Rafta_UpdateCredentials_FullMethodName = "/Rafta/UpdateCredentials"
-	Rafta_UpdateUserInfo_FullMethodName    = "/Rafta/UpdateUserInfo"
-	Rafta_NewTask_FullMethodName           = "/Rafta/NewTask"
-	Rafta_DeleteTask_FullMethodName        = "/Rafta/DeleteTask"
-	Rafta_UpdateTask_FullMethodName        = "/Rafta/UpdateTask"
+	Rafta_GetAllTasks_FullMethodName             = "/Rafta/GetAllTasks"
+	Rafta_GetTask_FullMethodName                 = "/Rafta/GetTask"
+	Rafta_GetUserInfo_FullMethodName             = "/Rafta/GetUserInfo"
+	Rafta_DeleteUser_FullMethodName              = "/Rafta/DeleteUser"
+	Rafta_UpdateCredentials_FullMethodName       = "/Rafta/UpdateCredentials"
+	Rafta_UpdateUserInfo_FullMethodName          = "/Rafta/UpdateUserInfo"
+	Rafta_NewTask_FullMethodName                 = "/Rafta/NewTask"
+	Rafta_DeleteTask_FullMethodName              = "/Rafta/DeleteTask"
+	Rafta_UpdateTask_FullMethodName              = "/Rafta/UpdateTask"
+	Rafta_GetAssignedTasks_FullMethodName        = "/Rafta/GetAssignedTasks"
+	Rafta_GetTaskAssignments_FullMethodName      = "/Rafta/GetTaskAssignments"
+	Rafta_ToggleChecklistItem_FullMethodName     = "/Rafta/ToggleChecklistItem"
+	Rafta_QuickAddTask_FullMethodName            = "/Rafta/QuickAddTask"
+	Rafta_ParseQuickAdd_FullMethodName           = "/Rafta/ParseQuickAdd"
+	Rafta_StartTimer_FullMethodName              = "/Rafta/StartTimer"
+	Rafta_StopTimer_FullMethodName               = "/Rafta/StopTimer"
+	Rafta_NewTimeEntry_FullMethodName            = "/Rafta/NewTimeEntry"
+	Rafta_UpdateTimeEntry_FullMethodName         = "/Rafta/UpdateTimeEntry"
+	Rafta_DeleteTimeEntry_FullMethodName         = "/Rafta/DeleteTimeEntry"
+	Rafta_GetTimeEntries_FullMethodName          = "/Rafta/GetTimeEntries"
+	Rafta_GetTimeTotals_FullMethodName           = "/Rafta/GetTimeTotals"
+	Rafta_NewFilter_FullMethodName               = "/Rafta/NewFilter"
+	Rafta_GetFilters_FullMethodName              = "/Rafta/GetFilters"
+	Rafta_UpdateFilter_FullMethodName            = "/Rafta/UpdateFilter"
+	Rafta_DeleteFilter_FullMethodName            = "/Rafta/DeleteFilter"
+	Rafta_EvaluateFilter_FullMethodName          = "/Rafta/EvaluateFilter"
+	Rafta_NewTemplate_FullMethodName             = "/Rafta/NewTemplate"
+	Rafta_GetTemplates_FullMethodName            = "/Rafta/GetTemplates"
+	Rafta_UpdateTemplate_FullMethodName          = "/Rafta/UpdateTemplate"
+	Rafta_DeleteTemplate_FullMethodName          = "/Rafta/DeleteTemplate"
+	Rafta_InstantiateTemplate_FullMethodName     = "/Rafta/InstantiateTemplate"
+	Rafta_ExportTemplate_FullMethodName          = "/Rafta/ExportTemplate"
+	Rafta_ImportTemplate_FullMethodName          = "/Rafta/ImportTemplate"
+	Rafta_SnoozeTask_FullMethodName              = "/Rafta/SnoozeTask"
+	Rafta_NewCustomField_FullMethodName          = "/Rafta/NewCustomField"
+	Rafta_GetCustomFields_FullMethodName         = "/Rafta/GetCustomFields"
+	Rafta_UpdateCustomField_FullMethodName       = "/Rafta/UpdateCustomField"
+	Rafta_DeleteCustomField_FullMethodName       = "/Rafta/DeleteCustomField"
+	Rafta_MoveTask_FullMethodName                = "/Rafta/MoveTask"
+	Rafta_GetPreferences_FullMethodName          = "/Rafta/GetPreferences"
+	Rafta_UpdatePreferences_FullMethodName       = "/Rafta/UpdatePreferences"
+	Rafta_GetAgenda_FullMethodName               = "/Rafta/GetAgenda"
+	Rafta_GetSettings_FullMethodName             = "/Rafta/GetSettings"
+	Rafta_SetSettings_FullMethodName             = "/Rafta/SetSettings"
+	Rafta_WatchSettings_FullMethodName           = "/Rafta/WatchSettings"
+	Rafta_NewPushEndpoint_FullMethodName         = "/Rafta/NewPushEndpoint"
+	Rafta_GetPushEndpoints_FullMethodName        = "/Rafta/GetPushEndpoints"
+	Rafta_UpdatePushEndpoint_FullMethodName      = "/Rafta/UpdatePushEndpoint"
+	Rafta_DeletePushEndpoint_FullMethodName      = "/Rafta/DeletePushEndpoint"
+	Rafta_TestPushEndpoint_FullMethodName        = "/Rafta/TestPushEndpoint"
+	Rafta_NewClientCertificate_FullMethodName    = "/Rafta/NewClientCertificate"
+	Rafta_GetClientCertificates_FullMethodName   = "/Rafta/GetClientCertificates"
+	Rafta_DeleteClientCertificate_FullMethodName = "/Rafta/DeleteClientCertificate"
+	Rafta_NewPersonalToken_FullMethodName        = "/Rafta/NewPersonalToken"
+	Rafta_GetPersonalTokens_FullMethodName       = "/Rafta/GetPersonalTokens"
+	Rafta_RevokePersonalToken_FullMethodName     = "/Rafta/RevokePersonalToken"
+	Rafta_ListSessions_FullMethodName            = "/Rafta/ListSessions"
+	Rafta_RevokeSession_FullMethodName           = "/Rafta/RevokeSession"
+	Rafta_RevokeAllOtherSessions_FullMethodName  = "/Rafta/RevokeAllOtherSessions"
+	Rafta_EnrollTOTP_FullMethodName              = "/Rafta/EnrollTOTP"
+	Rafta_ConfirmTOTP_FullMethodName             = "/Rafta/ConfirmTOTP"
+	Rafta_DisableTOTP_FullMethodName             = "/Rafta/DisableTOTP"
 )
 
 // RaftaClient is the client API for Rafta service.
@@ -38,15 +91,131 @@ const (
 //
 // Service for user and task management accessible to authenticated users.
 type RaftaClient interface {
+	// Lists every task of the user except the ones currently hidden. Hidden
+	// tasks can be listed through EvaluateFilter (see TaskFilter.include_hidden).
 	GetAllTasks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TaskList, error)
 	GetTask(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*Task, error)
 	GetUserInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*User, error)
 	DeleteUser(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Changes the password of the user and ends their other sessions.
 	UpdateCredentials(ctx context.Context, in *PasswdMessage, opts ...grpc.CallOption) (*timestamppb.Timestamp, error)
 	UpdateUserInfo(ctx context.Context, in *UserData, opts ...grpc.CallOption) (*timestamppb.Timestamp, error)
 	NewTask(ctx context.Context, in *TaskData, opts ...grpc.CallOption) (*NewTaskResponse, error)
 	DeleteTask(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	UpdateTask(ctx context.Context, in *TaskUpdateRequest, opts ...grpc.CallOption) (*TaskUpdateResponse, error)
+	// Lists every task assigned to the current user regardless of who owns it
+	// (hidden tasks excluded).
+	GetAssignedTasks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TaskList, error)
+	// Lists every (re)assignment of a task so its owner (and assignee) can
+	// follow handoffs.
+	GetTaskAssignments(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*TaskAssignmentList, error)
+	// Checks/unchecks the nth item of the checklist found in a task description.
+	// Items within code blocks aren't part of the checklist.
+	ToggleChecklistItem(ctx context.Context, in *ChecklistToggleRequest, opts ...grpc.CallOption) (*ChecklistToggleResponse, error)
+	// Creates a task from a single line of natural language text. Recurrence
+	// patterns get expressed as RFC 5545 RRULEs (ex: FREQ=WEEKLY;INTERVAL=2).
+	QuickAddTask(ctx context.Context, in *QuickAddRequest, opts ...grpc.CallOption) (*QuickAddResponse, error)
+	// Same as QuickAddTask without creating the task (useful for previews).
+	ParseQuickAdd(ctx context.Context, in *QuickAddRequest, opts ...grpc.CallOption) (*QuickAddResponse, error)
+	// Starts tracking time on a task. A user can only have one running timer:
+	// starting a new one stops the previous one.
+	StartTimer(ctx context.Context, in *StartTimerRequest, opts ...grpc.CallOption) (*StartTimerResponse, error)
+	// Stops the currently running timer.
+	StopTimer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TimeEntry, error)
+	// Records time spent on a task after the fact. Both bounds are required.
+	NewTimeEntry(ctx context.Context, in *TimeEntryData, opts ...grpc.CallOption) (*TimeEntry, error)
+	// Replaces the data of a time entry. Only the running timer may be left
+	// without an end.
+	UpdateTimeEntry(ctx context.Context, in *TimeEntry, opts ...grpc.CallOption) (*TimeEntry, error)
+	DeleteTimeEntry(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Lists time entries overlapping a period (oldest first).
+	GetTimeEntries(ctx context.Context, in *TimeEntryQuery, opts ...grpc.CallOption) (*TimeEntryList, error)
+	// Sums up time spent within a period per task and per tag.
+	GetTimeTotals(ctx context.Context, in *TimeRange, opts ...grpc.CallOption) (*TimeTotals, error)
+	// Saved filters (aka smart views) shared by every client of a user.
+	NewFilter(ctx context.Context, in *SavedFilterData, opts ...grpc.CallOption) (*SavedFilter, error)
+	GetFilters(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SavedFilterList, error)
+	UpdateFilter(ctx context.Context, in *SavedFilter, opts ...grpc.CallOption) (*SavedFilter, error)
+	DeleteFilter(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Lists the tasks matching either a saved filter or an ad-hoc one, in the
+	// order the filter defines.
+	EvaluateFilter(ctx context.Context, in *TaskSource, opts ...grpc.CallOption) (*TaskList, error)
+	// Templates are bundles of tasks created together.
+	NewTemplate(ctx context.Context, in *TemplateData, opts ...grpc.CallOption) (*Template, error)
+	GetTemplates(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TemplateList, error)
+	UpdateTemplate(ctx context.Context, in *Template, opts ...grpc.CallOption) (*Template, error)
+	DeleteTemplate(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Creates every task of a template. Either all tasks get created or none.
+	InstantiateTemplate(ctx context.Context, in *InstantiateTemplateRequest, opts ...grpc.CallOption) (*TaskList, error)
+	// Exports/imports templates to share them with other users.
+	ExportTemplate(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*TemplateDocument, error)
+	ImportTemplate(ctx context.Context, in *TemplateDocument, opts ...grpc.CallOption) (*Template, error)
+	// Hides a task from listings for a while. To reveal it early, update its
+	// HIDDEN_UNTIL field with an unset value.
+	SnoozeTask(ctx context.Context, in *SnoozeRequest, opts ...grpc.CallOption) (*SnoozeResponse, error)
+	// Custom fields hold metadata tasks don't natively support. Deleting a
+	// field removes its value from every task.
+	NewCustomField(ctx context.Context, in *CustomFieldDefinition, opts ...grpc.CallOption) (*CustomField, error)
+	GetCustomFields(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CustomFieldList, error)
+	UpdateCustomField(ctx context.Context, in *CustomField, opts ...grpc.CallOption) (*CustomField, error)
+	DeleteCustomField(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Moves a task within the manual order of the main list (also used by
+	// GetAllTasks) or of a tag view. Tasks never moved follow the others by
+	// creation date.
+	MoveTask(ctx context.Context, in *MoveTaskRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Retrieves the preferences of the user with defaults filled in.
+	GetPreferences(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Preferences, error)
+	// Replaces the preferences of the user.
+	UpdatePreferences(ctx context.Context, in *Preferences, opts ...grpc.CallOption) (*Preferences, error)
+	// Lists visible tasks grouped by the local calendar day they are due or to
+	// be done, along with overdue tasks. Tasks within a bucket are ordered by
+	// date.
+	GetAgenda(ctx context.Context, in *AgendaRequest, opts ...grpc.CallOption) (*Agenda, error)
+	// Retrieves the settings of the user sorted by namespace and key.
+	GetSettings(ctx context.Context, in *SettingsRequest, opts ...grpc.CallOption) (*SettingList, error)
+	// Creates, replaces or deletes (empty value) settings all at once.
+	SetSettings(ctx context.Context, in *SettingList, opts ...grpc.CallOption) (*SettingList, error)
+	// Sends the current settings of the user followed by every change made to
+	// them until the client hangs up. Watchers too slow to keep up get aborted
+	// and must watch again.
+	WatchSettings(ctx context.Context, in *SettingsRequest, opts ...grpc.CallOption) (Rafta_WatchSettingsClient, error)
+	// Push endpoints let clients unable to keep a connection open (ex: mobile
+	// apps in the background) receive notifications.
+	NewPushEndpoint(ctx context.Context, in *PushEndpointData, opts ...grpc.CallOption) (*PushEndpoint, error)
+	GetPushEndpoints(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PushEndpointList, error)
+	UpdatePushEndpoint(ctx context.Context, in *PushEndpoint, opts ...grpc.CallOption) (*PushEndpoint, error)
+	DeletePushEndpoint(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Sends a test notification right away (no retries) and reports whether the
+	// endpoint accepted it. The outcome doesn't count towards disabling it.
+	TestPushEndpoint(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*PushEndpoint, error)
+	// Client certificates let users authenticate with TLS instead of a
+	// password. Certificates issued by the CA of the server for the email of a
+	// user work without being registered (and can't be). Registering one
+	// requires presenting it over the connection the request is sent through.
+	NewClientCertificate(ctx context.Context, in *ClientCertificateData, opts ...grpc.CallOption) (*ClientCertificate, error)
+	GetClientCertificates(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ClientCertificateList, error)
+	DeleteClientCertificate(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Personal access tokens let scripts use the API without the password of
+	// the user. Revoked tokens stop working right away.
+	NewPersonalToken(ctx context.Context, in *PersonalTokenData, opts ...grpc.CallOption) (*NewPersonalTokenResponse, error)
+	GetPersonalTokens(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PersonalTokenList, error)
+	RevokePersonalToken(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Lists the sessions of the user, most recently used first.
+	ListSessions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SessionList, error)
+	// Ends a session, logging its device out.
+	RevokeSession(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Ends every session but the current one. Changing the password does it
+	// too.
+	RevokeAllOtherSessions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Starts enabling two-factor authentication with a new secret. Logins only
+	// require codes once a code from the secret was confirmed.
+	EnrollTOTP(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TOTPEnrollment, error)
+	// Enables two-factor authentication if the code matches the enrolled secret
+	// and provides the recovery codes of the user.
+	ConfirmTOTP(ctx context.Context, in *TOTPCode, opts ...grpc.CallOption) (*RecoveryCodes, error)
+	// Disables two-factor authentication, which requires a current code or a
+	// recovery code.
+	DisableTOTP(ctx context.Context, in *TOTPCode, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type raftaClient struct {
@@ -147,237 +316,2021 @@ func (c *raftaClient) UpdateTask(ctx context.Context, in *TaskUpdateRequest, opt
 	return out, nil
 }
 
+func (c *raftaClient) GetAssignedTasks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TaskList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskList)
+	err := c.cc.Invoke(ctx, Rafta_GetAssignedTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) GetTaskAssignments(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*TaskAssignmentList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskAssignmentList)
+	err := c.cc.Invoke(ctx, Rafta_GetTaskAssignments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) ToggleChecklistItem(ctx context.Context, in *ChecklistToggleRequest, opts ...grpc.CallOption) (*ChecklistToggleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChecklistToggleResponse)
+	err := c.cc.Invoke(ctx, Rafta_ToggleChecklistItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) QuickAddTask(ctx context.Context, in *QuickAddRequest, opts ...grpc.CallOption) (*QuickAddResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QuickAddResponse)
+	err := c.cc.Invoke(ctx, Rafta_QuickAddTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) ParseQuickAdd(ctx context.Context, in *QuickAddRequest, opts ...grpc.CallOption) (*QuickAddResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QuickAddResponse)
+	err := c.cc.Invoke(ctx, Rafta_ParseQuickAdd_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) StartTimer(ctx context.Context, in *StartTimerRequest, opts ...grpc.CallOption) (*StartTimerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartTimerResponse)
+	err := c.cc.Invoke(ctx, Rafta_StartTimer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) StopTimer(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TimeEntry, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TimeEntry)
+	err := c.cc.Invoke(ctx, Rafta_StopTimer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) NewTimeEntry(ctx context.Context, in *TimeEntryData, opts ...grpc.CallOption) (*TimeEntry, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TimeEntry)
+	err := c.cc.Invoke(ctx, Rafta_NewTimeEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) UpdateTimeEntry(ctx context.Context, in *TimeEntry, opts ...grpc.CallOption) (*TimeEntry, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TimeEntry)
+	err := c.cc.Invoke(ctx, Rafta_UpdateTimeEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) DeleteTimeEntry(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Rafta_DeleteTimeEntry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) GetTimeEntries(ctx context.Context, in *TimeEntryQuery, opts ...grpc.CallOption) (*TimeEntryList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TimeEntryList)
+	err := c.cc.Invoke(ctx, Rafta_GetTimeEntries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) GetTimeTotals(ctx context.Context, in *TimeRange, opts ...grpc.CallOption) (*TimeTotals, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TimeTotals)
+	err := c.cc.Invoke(ctx, Rafta_GetTimeTotals_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) NewFilter(ctx context.Context, in *SavedFilterData, opts ...grpc.CallOption) (*SavedFilter, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SavedFilter)
+	err := c.cc.Invoke(ctx, Rafta_NewFilter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) GetFilters(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SavedFilterList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SavedFilterList)
+	err := c.cc.Invoke(ctx, Rafta_GetFilters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) UpdateFilter(ctx context.Context, in *SavedFilter, opts ...grpc.CallOption) (*SavedFilter, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SavedFilter)
+	err := c.cc.Invoke(ctx, Rafta_UpdateFilter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) DeleteFilter(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Rafta_DeleteFilter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) EvaluateFilter(ctx context.Context, in *TaskSource, opts ...grpc.CallOption) (*TaskList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskList)
+	err := c.cc.Invoke(ctx, Rafta_EvaluateFilter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) NewTemplate(ctx context.Context, in *TemplateData, opts ...grpc.CallOption) (*Template, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Template)
+	err := c.cc.Invoke(ctx, Rafta_NewTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) GetTemplates(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TemplateList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TemplateList)
+	err := c.cc.Invoke(ctx, Rafta_GetTemplates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) UpdateTemplate(ctx context.Context, in *Template, opts ...grpc.CallOption) (*Template, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Template)
+	err := c.cc.Invoke(ctx, Rafta_UpdateTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) DeleteTemplate(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Rafta_DeleteTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) InstantiateTemplate(ctx context.Context, in *InstantiateTemplateRequest, opts ...grpc.CallOption) (*TaskList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskList)
+	err := c.cc.Invoke(ctx, Rafta_InstantiateTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) ExportTemplate(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*TemplateDocument, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TemplateDocument)
+	err := c.cc.Invoke(ctx, Rafta_ExportTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) ImportTemplate(ctx context.Context, in *TemplateDocument, opts ...grpc.CallOption) (*Template, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Template)
+	err := c.cc.Invoke(ctx, Rafta_ImportTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) SnoozeTask(ctx context.Context, in *SnoozeRequest, opts ...grpc.CallOption) (*SnoozeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SnoozeResponse)
+	err := c.cc.Invoke(ctx, Rafta_SnoozeTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) NewCustomField(ctx context.Context, in *CustomFieldDefinition, opts ...grpc.CallOption) (*CustomField, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CustomField)
+	err := c.cc.Invoke(ctx, Rafta_NewCustomField_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) GetCustomFields(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CustomFieldList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CustomFieldList)
+	err := c.cc.Invoke(ctx, Rafta_GetCustomFields_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) UpdateCustomField(ctx context.Context, in *CustomField, opts ...grpc.CallOption) (*CustomField, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CustomField)
+	err := c.cc.Invoke(ctx, Rafta_UpdateCustomField_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) DeleteCustomField(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Rafta_DeleteCustomField_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) MoveTask(ctx context.Context, in *MoveTaskRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Rafta_MoveTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) GetPreferences(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Preferences, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Preferences)
+	err := c.cc.Invoke(ctx, Rafta_GetPreferences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) UpdatePreferences(ctx context.Context, in *Preferences, opts ...grpc.CallOption) (*Preferences, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Preferences)
+	err := c.cc.Invoke(ctx, Rafta_UpdatePreferences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) GetAgenda(ctx context.Context, in *AgendaRequest, opts ...grpc.CallOption) (*Agenda, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Agenda)
+	err := c.cc.Invoke(ctx, Rafta_GetAgenda_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) GetSettings(ctx context.Context, in *SettingsRequest, opts ...grpc.CallOption) (*SettingList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SettingList)
+	err := c.cc.Invoke(ctx, Rafta_GetSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) SetSettings(ctx context.Context, in *SettingList, opts ...grpc.CallOption) (*SettingList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SettingList)
+	err := c.cc.Invoke(ctx, Rafta_SetSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) WatchSettings(ctx context.Context, in *SettingsRequest, opts ...grpc.CallOption) (Rafta_WatchSettingsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Rafta_ServiceDesc.Streams[0], Rafta_WatchSettings_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &raftaWatchSettingsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Rafta_WatchSettingsClient interface {
+	Recv() (*Setting, error)
+	grpc.ClientStream
+}
+
+type raftaWatchSettingsClient struct {
+	grpc.ClientStream
+}
+
+func (x *raftaWatchSettingsClient) Recv() (*Setting, error) {
+	m := new(Setting)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *raftaClient) NewPushEndpoint(ctx context.Context, in *PushEndpointData, opts ...grpc.CallOption) (*PushEndpoint, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PushEndpoint)
+	err := c.cc.Invoke(ctx, Rafta_NewPushEndpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) GetPushEndpoints(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PushEndpointList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PushEndpointList)
+	err := c.cc.Invoke(ctx, Rafta_GetPushEndpoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) UpdatePushEndpoint(ctx context.Context, in *PushEndpoint, opts ...grpc.CallOption) (*PushEndpoint, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PushEndpoint)
+	err := c.cc.Invoke(ctx, Rafta_UpdatePushEndpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) DeletePushEndpoint(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Rafta_DeletePushEndpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) TestPushEndpoint(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*PushEndpoint, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PushEndpoint)
+	err := c.cc.Invoke(ctx, Rafta_TestPushEndpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) NewClientCertificate(ctx context.Context, in *ClientCertificateData, opts ...grpc.CallOption) (*ClientCertificate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClientCertificate)
+	err := c.cc.Invoke(ctx, Rafta_NewClientCertificate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) GetClientCertificates(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ClientCertificateList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClientCertificateList)
+	err := c.cc.Invoke(ctx, Rafta_GetClientCertificates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) DeleteClientCertificate(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Rafta_DeleteClientCertificate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) NewPersonalToken(ctx context.Context, in *PersonalTokenData, opts ...grpc.CallOption) (*NewPersonalTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NewPersonalTokenResponse)
+	err := c.cc.Invoke(ctx, Rafta_NewPersonalToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) GetPersonalTokens(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PersonalTokenList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PersonalTokenList)
+	err := c.cc.Invoke(ctx, Rafta_GetPersonalTokens_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) RevokePersonalToken(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Rafta_RevokePersonalToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) ListSessions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SessionList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SessionList)
+	err := c.cc.Invoke(ctx, Rafta_ListSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) RevokeSession(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Rafta_RevokeSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) RevokeAllOtherSessions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Rafta_RevokeAllOtherSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) EnrollTOTP(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TOTPEnrollment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TOTPEnrollment)
+	err := c.cc.Invoke(ctx, Rafta_EnrollTOTP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) ConfirmTOTP(ctx context.Context, in *TOTPCode, opts ...grpc.CallOption) (*RecoveryCodes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecoveryCodes)
+	err := c.cc.Invoke(ctx, Rafta_ConfirmTOTP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *raftaClient) DisableTOTP(ctx context.Context, in *TOTPCode, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Rafta_DisableTOTP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RaftaServer is the server API for Rafta service.
 // All implementations must embed UnimplementedRaftaServer
 // for forward compatibility.
 //
 // Service for user and task management accessible to authenticated users.
 type RaftaServer interface {
+	// Lists every task of the user except the ones currently hidden. Hidden
+	// tasks can be listed through EvaluateFilter (see TaskFilter.include_hidden).
 	GetAllTasks(context.Context, *emptypb.Empty) (*TaskList, error)
 	GetTask(context.Context, *UUID) (*Task, error)
 	GetUserInfo(context.Context, *emptypb.Empty) (*User, error)
 	DeleteUser(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	// Changes the password of the user and ends their other sessions.
 	UpdateCredentials(context.Context, *PasswdMessage) (*timestamppb.Timestamp, error)
 	UpdateUserInfo(context.Context, *UserData) (*timestamppb.Timestamp, error)
 	NewTask(context.Context, *TaskData) (*NewTaskResponse, error)
 	DeleteTask(context.Context, *UUID) (*emptypb.Empty, error)
 	UpdateTask(context.Context, *TaskUpdateRequest) (*TaskUpdateResponse, error)
+	// Lists every task assigned to the current user regardless of who owns it
+	// (hidden tasks excluded).
+	GetAssignedTasks(context.Context, *emptypb.Empty) (*TaskList, error)
+	// Lists every (re)assignment of a task so its owner (and assignee) can
+	// follow handoffs.
+	GetTaskAssignments(context.Context, *UUID) (*TaskAssignmentList, error)
+	// Checks/unchecks the nth item of the checklist found in a task description.
+	// Items within code blocks aren't part of the checklist.
+	ToggleChecklistItem(context.Context, *ChecklistToggleRequest) (*ChecklistToggleResponse, error)
+	// Creates a task from a single line of natural language text. Recurrence
+	// patterns get expressed as RFC 5545 RRULEs (ex: FREQ=WEEKLY;INTERVAL=2).
+	QuickAddTask(context.Context, *QuickAddRequest) (*QuickAddResponse, error)
+	// Same as QuickAddTask without creating the task (useful for previews).
+	ParseQuickAdd(context.Context, *QuickAddRequest) (*QuickAddResponse, error)
+	// Starts tracking time on a task. A user can only have one running timer:
+	// starting a new one stops the previous one.
+	StartTimer(context.Context, *StartTimerRequest) (*StartTimerResponse, error)
+	// Stops the currently running timer.
+	StopTimer(context.Context, *emptypb.Empty) (*TimeEntry, error)
+	// Records time spent on a task after the fact. Both bounds are required.
+	NewTimeEntry(context.Context, *TimeEntryData) (*TimeEntry, error)
+	// Replaces the data of a time entry. Only the running timer may be left
+	// without an end.
+	UpdateTimeEntry(context.Context, *TimeEntry) (*TimeEntry, error)
+	DeleteTimeEntry(context.Context, *UUID) (*emptypb.Empty, error)
+	// Lists time entries overlapping a period (oldest first).
+	GetTimeEntries(context.Context, *TimeEntryQuery) (*TimeEntryList, error)
+	// Sums up time spent within a period per task and per tag.
+	GetTimeTotals(context.Context, *TimeRange) (*TimeTotals, error)
+	// Saved filters (aka smart views) shared by every client of a user.
+	NewFilter(context.Context, *SavedFilterData) (*SavedFilter, error)
+	GetFilters(context.Context, *emptypb.Empty) (*SavedFilterList, error)
+	UpdateFilter(context.Context, *SavedFilter) (*SavedFilter, error)
+	DeleteFilter(context.Context, *UUID) (*emptypb.Empty, error)
+	// Lists the tasks matching either a saved filter or an ad-hoc one, in the
+	// order the filter defines.
+	EvaluateFilter(context.Context, *TaskSource) (*TaskList, error)
+	// Templates are bundles of tasks created together.
+	NewTemplate(context.Context, *TemplateData) (*Template, error)
+	GetTemplates(context.Context, *emptypb.Empty) (*TemplateList, error)
+	UpdateTemplate(context.Context, *Template) (*Template, error)
+	DeleteTemplate(context.Context, *UUID) (*emptypb.Empty, error)
+	// Creates every task of a template. Either all tasks get created or none.
+	InstantiateTemplate(context.Context, *InstantiateTemplateRequest) (*TaskList, error)
+	// Exports/imports templates to share them with other users.
+	ExportTemplate(context.Context, *UUID) (*TemplateDocument, error)
+	ImportTemplate(context.Context, *TemplateDocument) (*Template, error)
+	// Hides a task from listings for a while. To reveal it early, update its
+	// HIDDEN_UNTIL field with an unset value.
+	SnoozeTask(context.Context, *SnoozeRequest) (*SnoozeResponse, error)
+	// Custom fields hold metadata tasks don't natively support. Deleting a
+	// field removes its value from every task.
+	NewCustomField(context.Context, *CustomFieldDefinition) (*CustomField, error)
+	GetCustomFields(context.Context, *emptypb.Empty) (*CustomFieldList, error)
+	UpdateCustomField(context.Context, *CustomField) (*CustomField, error)
+	DeleteCustomField(context.Context, *UUID) (*emptypb.Empty, error)
+	// Moves a task within the manual order of the main list (also used by
+	// GetAllTasks) or of a tag view. Tasks never moved follow the others by
+	// creation date.
+	MoveTask(context.Context, *MoveTaskRequest) (*emptypb.Empty, error)
+	// Retrieves the preferences of the user with defaults filled in.
+	GetPreferences(context.Context, *emptypb.Empty) (*Preferences, error)
+	// Replaces the preferences of the user.
+	UpdatePreferences(context.Context, *Preferences) (*Preferences, error)
+	// Lists visible tasks grouped by the local calendar day they are due or to
+	// be done, along with overdue tasks. Tasks within a bucket are ordered by
+	// date.
+	GetAgenda(context.Context, *AgendaRequest) (*Agenda, error)
+	// Retrieves the settings of the user sorted by namespace and key.
+	GetSettings(context.Context, *SettingsRequest) (*SettingList, error)
+	// Creates, replaces or deletes (empty value) settings all at once.
+	SetSettings(context.Context, *SettingList) (*SettingList, error)
+	// Sends the current settings of the user followed by every change made to
+	// them until the client hangs up. Watchers too slow to keep up get aborted
+	// and must watch again.
+	WatchSettings(*SettingsRequest, Rafta_WatchSettingsServer) error
+	// Push endpoints let clients unable to keep a connection open (ex: mobile
+	// apps in the background) receive notifications.
+	NewPushEndpoint(context.Context, *PushEndpointData) (*PushEndpoint, error)
+	GetPushEndpoints(context.Context, *emptypb.Empty) (*PushEndpointList, error)
+	UpdatePushEndpoint(context.Context, *PushEndpoint) (*PushEndpoint, error)
+	DeletePushEndpoint(context.Context, *UUID) (*emptypb.Empty, error)
+	// Sends a test notification right away (no retries) and reports whether the
+	// endpoint accepted it. The outcome doesn't count towards disabling it.
+	TestPushEndpoint(context.Context, *UUID) (*PushEndpoint, error)
+	// Client certificates let users authenticate with TLS instead of a
+	// password. Certificates issued by the CA of the server for the email of a
+	// user work without being registered (and can't be). Registering one
+	// requires presenting it over the connection the request is sent through.
+	NewClientCertificate(context.Context, *ClientCertificateData) (*ClientCertificate, error)
+	GetClientCertificates(context.Context, *emptypb.Empty) (*ClientCertificateList, error)
+	DeleteClientCertificate(context.Context, *UUID) (*emptypb.Empty, error)
+	// Personal access tokens let scripts use the API without the password of
+	// the user. Revoked tokens stop working right away.
+	NewPersonalToken(context.Context, *PersonalTokenData) (*NewPersonalTokenResponse, error)
+	GetPersonalTokens(context.Context, *emptypb.Empty) (*PersonalTokenList, error)
+	RevokePersonalToken(context.Context, *UUID) (*emptypb.Empty, error)
+	// Lists the sessions of the user, most recently used first.
+	ListSessions(context.Context, *emptypb.Empty) (*SessionList, error)
+	// Ends a session, logging its device out.
+	RevokeSession(context.Context, *UUID) (*emptypb.Empty, error)
+	// Ends every session but the current one. Changing the password does it
+	// too.
+	RevokeAllOtherSessions(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	// Starts enabling two-factor authentication with a new secret. Logins only
+	// require codes once a code from the secret was confirmed.
+	EnrollTOTP(context.Context, *emptypb.Empty) (*TOTPEnrollment, error)
+	// Enables two-factor authentication if the code matches the enrolled secret
+	// and provides the recovery codes of the user.
+	ConfirmTOTP(context.Context, *TOTPCode) (*RecoveryCodes, error)
+	// Disables two-factor authentication, which requires a current code or a
+	// recovery code.
+	DisableTOTP(context.Context, *TOTPCode) (*emptypb.Empty, error)
+	mustEmbedUnimplementedRaftaServer()
+}
+
+// UnimplementedRaftaServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRaftaServer struct{}
+
+func (UnimplementedRaftaServer) GetAllTasks(context.Context, *emptypb.Empty) (*TaskList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllTasks not implemented")
+}
+func (UnimplementedRaftaServer) GetTask(context.Context, *UUID) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTask not implemented")
+}
+func (UnimplementedRaftaServer) GetUserInfo(context.Context, *emptypb.Empty) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserInfo not implemented")
+}
+func (UnimplementedRaftaServer) DeleteUser(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+}
+func (UnimplementedRaftaServer) UpdateCredentials(context.Context, *PasswdMessage) (*timestamppb.Timestamp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCredentials not implemented")
+}
+func (UnimplementedRaftaServer) UpdateUserInfo(context.Context, *UserData) (*timestamppb.Timestamp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserInfo not implemented")
+}
+func (UnimplementedRaftaServer) NewTask(context.Context, *TaskData) (*NewTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewTask not implemented")
+}
+func (UnimplementedRaftaServer) DeleteTask(context.Context, *UUID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTask not implemented")
+}
+func (UnimplementedRaftaServer) UpdateTask(context.Context, *TaskUpdateRequest) (*TaskUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTask not implemented")
+}
+func (UnimplementedRaftaServer) GetAssignedTasks(context.Context, *emptypb.Empty) (*TaskList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAssignedTasks not implemented")
+}
+func (UnimplementedRaftaServer) GetTaskAssignments(context.Context, *UUID) (*TaskAssignmentList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaskAssignments not implemented")
+}
+func (UnimplementedRaftaServer) ToggleChecklistItem(context.Context, *ChecklistToggleRequest) (*ChecklistToggleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ToggleChecklistItem not implemented")
+}
+func (UnimplementedRaftaServer) QuickAddTask(context.Context, *QuickAddRequest) (*QuickAddResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuickAddTask not implemented")
+}
+func (UnimplementedRaftaServer) ParseQuickAdd(context.Context, *QuickAddRequest) (*QuickAddResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ParseQuickAdd not implemented")
+}
+func (UnimplementedRaftaServer) StartTimer(context.Context, *StartTimerRequest) (*StartTimerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartTimer not implemented")
+}
+func (UnimplementedRaftaServer) StopTimer(context.Context, *emptypb.Empty) (*TimeEntry, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopTimer not implemented")
+}
+func (UnimplementedRaftaServer) NewTimeEntry(context.Context, *TimeEntryData) (*TimeEntry, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewTimeEntry not implemented")
+}
+func (UnimplementedRaftaServer) UpdateTimeEntry(context.Context, *TimeEntry) (*TimeEntry, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTimeEntry not implemented")
+}
+func (UnimplementedRaftaServer) DeleteTimeEntry(context.Context, *UUID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTimeEntry not implemented")
+}
+func (UnimplementedRaftaServer) GetTimeEntries(context.Context, *TimeEntryQuery) (*TimeEntryList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTimeEntries not implemented")
+}
+func (UnimplementedRaftaServer) GetTimeTotals(context.Context, *TimeRange) (*TimeTotals, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTimeTotals not implemented")
+}
+func (UnimplementedRaftaServer) NewFilter(context.Context, *SavedFilterData) (*SavedFilter, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewFilter not implemented")
+}
+func (UnimplementedRaftaServer) GetFilters(context.Context, *emptypb.Empty) (*SavedFilterList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFilters not implemented")
+}
+func (UnimplementedRaftaServer) UpdateFilter(context.Context, *SavedFilter) (*SavedFilter, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFilter not implemented")
+}
+func (UnimplementedRaftaServer) DeleteFilter(context.Context, *UUID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFilter not implemented")
+}
+func (UnimplementedRaftaServer) EvaluateFilter(context.Context, *TaskSource) (*TaskList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EvaluateFilter not implemented")
+}
+func (UnimplementedRaftaServer) NewTemplate(context.Context, *TemplateData) (*Template, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewTemplate not implemented")
+}
+func (UnimplementedRaftaServer) GetTemplates(context.Context, *emptypb.Empty) (*TemplateList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTemplates not implemented")
+}
+func (UnimplementedRaftaServer) UpdateTemplate(context.Context, *Template) (*Template, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTemplate not implemented")
+}
+func (UnimplementedRaftaServer) DeleteTemplate(context.Context, *UUID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTemplate not implemented")
+}
+func (UnimplementedRaftaServer) InstantiateTemplate(context.Context, *InstantiateTemplateRequest) (*TaskList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InstantiateTemplate not implemented")
+}
+func (UnimplementedRaftaServer) ExportTemplate(context.Context, *UUID) (*TemplateDocument, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportTemplate not implemented")
+}
+func (UnimplementedRaftaServer) ImportTemplate(context.Context, *TemplateDocument) (*Template, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportTemplate not implemented")
+}
+func (UnimplementedRaftaServer) SnoozeTask(context.Context, *SnoozeRequest) (*SnoozeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnoozeTask not implemented")
+}
+func (UnimplementedRaftaServer) NewCustomField(context.Context, *CustomFieldDefinition) (*CustomField, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewCustomField not implemented")
+}
+func (UnimplementedRaftaServer) GetCustomFields(context.Context, *emptypb.Empty) (*CustomFieldList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCustomFields not implemented")
+}
+func (UnimplementedRaftaServer) UpdateCustomField(context.Context, *CustomField) (*CustomField, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCustomField not implemented")
+}
+func (UnimplementedRaftaServer) DeleteCustomField(context.Context, *UUID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCustomField not implemented")
+}
+func (UnimplementedRaftaServer) MoveTask(context.Context, *MoveTaskRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveTask not implemented")
+}
+func (UnimplementedRaftaServer) GetPreferences(context.Context, *emptypb.Empty) (*Preferences, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPreferences not implemented")
+}
+func (UnimplementedRaftaServer) UpdatePreferences(context.Context, *Preferences) (*Preferences, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePreferences not implemented")
+}
+func (UnimplementedRaftaServer) GetAgenda(context.Context, *AgendaRequest) (*Agenda, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAgenda not implemented")
+}
+func (UnimplementedRaftaServer) GetSettings(context.Context, *SettingsRequest) (*SettingList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSettings not implemented")
+}
+func (UnimplementedRaftaServer) SetSettings(context.Context, *SettingList) (*SettingList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSettings not implemented")
+}
+func (UnimplementedRaftaServer) WatchSettings(*SettingsRequest, Rafta_WatchSettingsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchSettings not implemented")
+}
+func (UnimplementedRaftaServer) NewPushEndpoint(context.Context, *PushEndpointData) (*PushEndpoint, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewPushEndpoint not implemented")
+}
+func (UnimplementedRaftaServer) GetPushEndpoints(context.Context, *emptypb.Empty) (*PushEndpointList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPushEndpoints not implemented")
+}
+func (UnimplementedRaftaServer) UpdatePushEndpoint(context.Context, *PushEndpoint) (*PushEndpoint, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePushEndpoint not implemented")
+}
+func (UnimplementedRaftaServer) DeletePushEndpoint(context.Context, *UUID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePushEndpoint not implemented")
+}
+func (UnimplementedRaftaServer) TestPushEndpoint(context.Context, *UUID) (*PushEndpoint, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestPushEndpoint not implemented")
+}
+func (UnimplementedRaftaServer) NewClientCertificate(context.Context, *ClientCertificateData) (*ClientCertificate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewClientCertificate not implemented")
+}
+func (UnimplementedRaftaServer) GetClientCertificates(context.Context, *emptypb.Empty) (*ClientCertificateList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClientCertificates not implemented")
+}
+func (UnimplementedRaftaServer) DeleteClientCertificate(context.Context, *UUID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteClientCertificate not implemented")
+}
+func (UnimplementedRaftaServer) NewPersonalToken(context.Context, *PersonalTokenData) (*NewPersonalTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewPersonalToken not implemented")
+}
+func (UnimplementedRaftaServer) GetPersonalTokens(context.Context, *emptypb.Empty) (*PersonalTokenList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPersonalTokens not implemented")
+}
+func (UnimplementedRaftaServer) RevokePersonalToken(context.Context, *UUID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokePersonalToken not implemented")
+}
+func (UnimplementedRaftaServer) ListSessions(context.Context, *emptypb.Empty) (*SessionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedRaftaServer) RevokeSession(context.Context, *UUID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSession not implemented")
+}
+func (UnimplementedRaftaServer) RevokeAllOtherSessions(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAllOtherSessions not implemented")
+}
+func (UnimplementedRaftaServer) EnrollTOTP(context.Context, *emptypb.Empty) (*TOTPEnrollment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnrollTOTP not implemented")
+}
+func (UnimplementedRaftaServer) ConfirmTOTP(context.Context, *TOTPCode) (*RecoveryCodes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmTOTP not implemented")
+}
+func (UnimplementedRaftaServer) DisableTOTP(context.Context, *TOTPCode) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableTOTP not implemented")
+}
+func (UnimplementedRaftaServer) mustEmbedUnimplementedRaftaServer() {}
+func (UnimplementedRaftaServer) testEmbeddedByValue()               {}
+
+// UnsafeRaftaServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RaftaServer will
+// result in compilation errors.
+type UnsafeRaftaServer interface {
 	mustEmbedUnimplementedRaftaServer()
 }
 
-// UnimplementedRaftaServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedRaftaServer struct{}
+func RegisterRaftaServer(s grpc.ServiceRegistrar, srv RaftaServer) {
+	// If the following call pancis, it indicates UnimplementedRaftaServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Rafta_ServiceDesc, srv)
+}
+
+func _Rafta_GetAllTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).GetAllTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_GetAllTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).GetAllTasks(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_GetTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UUID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).GetTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_GetTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).GetTask(ctx, req.(*UUID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_GetUserInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).GetUserInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_GetUserInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).GetUserInfo(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).DeleteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_DeleteUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).DeleteUser(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_UpdateCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PasswdMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).UpdateCredentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_UpdateCredentials_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).UpdateCredentials(ctx, req.(*PasswdMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_UpdateUserInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserData)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).UpdateUserInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_UpdateUserInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).UpdateUserInfo(ctx, req.(*UserData))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_NewTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskData)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).NewTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_NewTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).NewTask(ctx, req.(*TaskData))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_DeleteTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UUID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).DeleteTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_DeleteTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).DeleteTask(ctx, req.(*UUID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_UpdateTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).UpdateTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_UpdateTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).UpdateTask(ctx, req.(*TaskUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_GetAssignedTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).GetAssignedTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_GetAssignedTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).GetAssignedTasks(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_GetTaskAssignments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UUID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).GetTaskAssignments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_GetTaskAssignments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).GetTaskAssignments(ctx, req.(*UUID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_ToggleChecklistItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChecklistToggleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).ToggleChecklistItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_ToggleChecklistItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).ToggleChecklistItem(ctx, req.(*ChecklistToggleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_QuickAddTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuickAddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).QuickAddTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_QuickAddTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).QuickAddTask(ctx, req.(*QuickAddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_ParseQuickAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuickAddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).ParseQuickAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_ParseQuickAdd_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).ParseQuickAdd(ctx, req.(*QuickAddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_StartTimer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartTimerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).StartTimer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_StartTimer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).StartTimer(ctx, req.(*StartTimerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_StopTimer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).StopTimer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_StopTimer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).StopTimer(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_NewTimeEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TimeEntryData)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).NewTimeEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_NewTimeEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).NewTimeEntry(ctx, req.(*TimeEntryData))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_UpdateTimeEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TimeEntry)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).UpdateTimeEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_UpdateTimeEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).UpdateTimeEntry(ctx, req.(*TimeEntry))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_DeleteTimeEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UUID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).DeleteTimeEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_DeleteTimeEntry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).DeleteTimeEntry(ctx, req.(*UUID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_GetTimeEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TimeEntryQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).GetTimeEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_GetTimeEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).GetTimeEntries(ctx, req.(*TimeEntryQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_GetTimeTotals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TimeRange)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).GetTimeTotals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_GetTimeTotals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).GetTimeTotals(ctx, req.(*TimeRange))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_NewFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SavedFilterData)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).NewFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_NewFilter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).NewFilter(ctx, req.(*SavedFilterData))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_GetFilters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).GetFilters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_GetFilters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).GetFilters(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_UpdateFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SavedFilter)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).UpdateFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_UpdateFilter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).UpdateFilter(ctx, req.(*SavedFilter))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_DeleteFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UUID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).DeleteFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_DeleteFilter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).DeleteFilter(ctx, req.(*UUID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_EvaluateFilter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskSource)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).EvaluateFilter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_EvaluateFilter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).EvaluateFilter(ctx, req.(*TaskSource))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_NewTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TemplateData)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).NewTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_NewTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).NewTemplate(ctx, req.(*TemplateData))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_GetTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).GetTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_GetTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).GetTemplates(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_UpdateTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Template)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).UpdateTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_UpdateTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).UpdateTemplate(ctx, req.(*Template))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_DeleteTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UUID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).DeleteTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_DeleteTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).DeleteTemplate(ctx, req.(*UUID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_InstantiateTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InstantiateTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).InstantiateTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_InstantiateTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).InstantiateTemplate(ctx, req.(*InstantiateTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_ExportTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UUID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).ExportTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_ExportTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).ExportTemplate(ctx, req.(*UUID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_ImportTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TemplateDocument)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).ImportTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_ImportTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).ImportTemplate(ctx, req.(*TemplateDocument))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_SnoozeTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnoozeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).SnoozeTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_SnoozeTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).SnoozeTask(ctx, req.(*SnoozeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_NewCustomField_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CustomFieldDefinition)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).NewCustomField(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_NewCustomField_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).NewCustomField(ctx, req.(*CustomFieldDefinition))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-func (UnimplementedRaftaServer) GetAllTasks(context.Context, *emptypb.Empty) (*TaskList, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAllTasks not implemented")
+func _Rafta_GetCustomFields_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).GetCustomFields(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_GetCustomFields_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).GetCustomFields(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedRaftaServer) GetTask(context.Context, *UUID) (*Task, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetTask not implemented")
+
+func _Rafta_UpdateCustomField_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CustomField)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).UpdateCustomField(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_UpdateCustomField_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).UpdateCustomField(ctx, req.(*CustomField))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedRaftaServer) GetUserInfo(context.Context, *emptypb.Empty) (*User, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUserInfo not implemented")
+
+func _Rafta_DeleteCustomField_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UUID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).DeleteCustomField(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_DeleteCustomField_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).DeleteCustomField(ctx, req.(*UUID))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedRaftaServer) DeleteUser(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+
+func _Rafta_MoveTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).MoveTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_MoveTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).MoveTask(ctx, req.(*MoveTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedRaftaServer) UpdateCredentials(context.Context, *PasswdMessage) (*timestamppb.Timestamp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateCredentials not implemented")
+
+func _Rafta_GetPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).GetPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_GetPreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).GetPreferences(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedRaftaServer) UpdateUserInfo(context.Context, *UserData) (*timestamppb.Timestamp, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserInfo not implemented")
+
+func _Rafta_UpdatePreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Preferences)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).UpdatePreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_UpdatePreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).UpdatePreferences(ctx, req.(*Preferences))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedRaftaServer) NewTask(context.Context, *TaskData) (*NewTaskResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method NewTask not implemented")
+
+func _Rafta_GetAgenda_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AgendaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).GetAgenda(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_GetAgenda_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).GetAgenda(ctx, req.(*AgendaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedRaftaServer) DeleteTask(context.Context, *UUID) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteTask not implemented")
+
+func _Rafta_GetSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).GetSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_GetSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).GetSettings(ctx, req.(*SettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedRaftaServer) UpdateTask(context.Context, *TaskUpdateRequest) (*TaskUpdateResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateTask not implemented")
+
+func _Rafta_SetSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SettingList)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).SetSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_SetSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).SetSettings(ctx, req.(*SettingList))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedRaftaServer) mustEmbedUnimplementedRaftaServer() {}
-func (UnimplementedRaftaServer) testEmbeddedByValue()               {}
 
-// UnsafeRaftaServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to RaftaServer will
-// result in compilation errors.
-type UnsafeRaftaServer interface {
-	mustEmbedUnimplementedRaftaServer()
+func _Rafta_WatchSettings_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SettingsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RaftaServer).WatchSettings(m, &raftaWatchSettingsServer{stream})
 }
 
-func RegisterRaftaServer(s grpc.ServiceRegistrar, srv RaftaServer) {
-	// If the following call pancis, it indicates UnimplementedRaftaServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+type Rafta_WatchSettingsServer interface {
+	Send(*Setting) error
+	grpc.ServerStream
+}
+
+type raftaWatchSettingsServer struct {
+	grpc.ServerStream
+}
+
+func (x *raftaWatchSettingsServer) Send(m *Setting) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Rafta_NewPushEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushEndpointData)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).NewPushEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_NewPushEndpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).NewPushEndpoint(ctx, req.(*PushEndpointData))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_GetPushEndpoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).GetPushEndpoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_GetPushEndpoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).GetPushEndpoints(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_UpdatePushEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushEndpoint)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).UpdatePushEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_UpdatePushEndpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).UpdatePushEndpoint(ctx, req.(*PushEndpoint))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_DeletePushEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UUID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).DeletePushEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_DeletePushEndpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).DeletePushEndpoint(ctx, req.(*UUID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_TestPushEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UUID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).TestPushEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_TestPushEndpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).TestPushEndpoint(ctx, req.(*UUID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_NewClientCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientCertificateData)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).NewClientCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_NewClientCertificate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).NewClientCertificate(ctx, req.(*ClientCertificateData))
 	}
-	s.RegisterService(&Rafta_ServiceDesc, srv)
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Rafta_GetAllTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Rafta_GetClientCertificates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RaftaServer).GetAllTasks(ctx, in)
+		return srv.(RaftaServer).GetClientCertificates(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Rafta_GetAllTasks_FullMethodName,
+		FullMethod: Rafta_GetClientCertificates_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RaftaServer).GetAllTasks(ctx, req.(*emptypb.Empty))
+		return srv.(RaftaServer).GetClientCertificates(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Rafta_GetTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Rafta_DeleteClientCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UUID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RaftaServer).GetTask(ctx, in)
+		return srv.(RaftaServer).DeleteClientCertificate(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Rafta_GetTask_FullMethodName,
+		FullMethod: Rafta_DeleteClientCertificate_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RaftaServer).GetTask(ctx, req.(*UUID))
+		return srv.(RaftaServer).DeleteClientCertificate(ctx, req.(*UUID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Rafta_GetUserInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Rafta_NewPersonalToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PersonalTokenData)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).NewPersonalToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_NewPersonalToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).NewPersonalToken(ctx, req.(*PersonalTokenData))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_GetPersonalTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RaftaServer).GetUserInfo(ctx, in)
+		return srv.(RaftaServer).GetPersonalTokens(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Rafta_GetUserInfo_FullMethodName,
+		FullMethod: Rafta_GetPersonalTokens_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RaftaServer).GetUserInfo(ctx, req.(*emptypb.Empty))
+		return srv.(RaftaServer).GetPersonalTokens(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Rafta_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Rafta_RevokePersonalToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UUID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RaftaServer).RevokePersonalToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rafta_RevokePersonalToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RaftaServer).RevokePersonalToken(ctx, req.(*UUID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rafta_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RaftaServer).DeleteUser(ctx, in)
+		return srv.(RaftaServer).ListSessions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Rafta_DeleteUser_FullMethodName,
+		FullMethod: Rafta_ListSessions_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RaftaServer).DeleteUser(ctx, req.(*emptypb.Empty))
+		return srv.(RaftaServer).ListSessions(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Rafta_UpdateCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PasswdMessage)
+func _Rafta_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UUID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RaftaServer).UpdateCredentials(ctx, in)
+		return srv.(RaftaServer).RevokeSession(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Rafta_UpdateCredentials_FullMethodName,
+		FullMethod: Rafta_RevokeSession_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RaftaServer).UpdateCredentials(ctx, req.(*PasswdMessage))
+		return srv.(RaftaServer).RevokeSession(ctx, req.(*UUID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Rafta_UpdateUserInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserData)
+func _Rafta_RevokeAllOtherSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RaftaServer).UpdateUserInfo(ctx, in)
+		return srv.(RaftaServer).RevokeAllOtherSessions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Rafta_UpdateUserInfo_FullMethodName,
+		FullMethod: Rafta_RevokeAllOtherSessions_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RaftaServer).UpdateUserInfo(ctx, req.(*UserData))
+		return srv.(RaftaServer).RevokeAllOtherSessions(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Rafta_NewTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TaskData)
+func _Rafta_EnrollTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RaftaServer).NewTask(ctx, in)
+		return srv.(RaftaServer).EnrollTOTP(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Rafta_NewTask_FullMethodName,
+		FullMethod: Rafta_EnrollTOTP_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RaftaServer).NewTask(ctx, req.(*TaskData))
+		return srv.(RaftaServer).EnrollTOTP(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Rafta_DeleteTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UUID)
+func _Rafta_ConfirmTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TOTPCode)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RaftaServer).DeleteTask(ctx, in)
+		return srv.(RaftaServer).ConfirmTOTP(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Rafta_DeleteTask_FullMethodName,
+		FullMethod: Rafta_ConfirmTOTP_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RaftaServer).DeleteTask(ctx, req.(*UUID))
+		return srv.(RaftaServer).ConfirmTOTP(ctx, req.(*TOTPCode))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Rafta_UpdateTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TaskUpdateRequest)
+func _Rafta_DisableTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TOTPCode)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RaftaServer).UpdateTask(ctx, in)
+		return srv.(RaftaServer).DisableTOTP(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Rafta_UpdateTask_FullMethodName,
+		FullMethod: Rafta_DisableTOTP_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RaftaServer).UpdateTask(ctx, req.(*TaskUpdateRequest))
+		return srv.(RaftaServer).DisableTOTP(ctx, req.(*TOTPCode))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -425,8 +2378,222 @@ var Rafta_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateTask",
 			Handler:    _Rafta_UpdateTask_Handler,
 		},
+		{
+			MethodName: "GetAssignedTasks",
+			Handler:    _Rafta_GetAssignedTasks_Handler,
+		},
+		{
+			MethodName: "GetTaskAssignments",
+			Handler:    _Rafta_GetTaskAssignments_Handler,
+		},
+		{
+			MethodName: "ToggleChecklistItem",
+			Handler:    _Rafta_ToggleChecklistItem_Handler,
+		},
+		{
+			MethodName: "QuickAddTask",
+			Handler:    _Rafta_QuickAddTask_Handler,
+		},
+		{
+			MethodName: "ParseQuickAdd",
+			Handler:    _Rafta_ParseQuickAdd_Handler,
+		},
+		{
+			MethodName: "StartTimer",
+			Handler:    _Rafta_StartTimer_Handler,
+		},
+		{
+			MethodName: "StopTimer",
+			Handler:    _Rafta_StopTimer_Handler,
+		},
+		{
+			MethodName: "NewTimeEntry",
+			Handler:    _Rafta_NewTimeEntry_Handler,
+		},
+		{
+			MethodName: "UpdateTimeEntry",
+			Handler:    _Rafta_UpdateTimeEntry_Handler,
+		},
+		{
+			MethodName: "DeleteTimeEntry",
+			Handler:    _Rafta_DeleteTimeEntry_Handler,
+		},
+		{
+			MethodName: "GetTimeEntries",
+			Handler:    _Rafta_GetTimeEntries_Handler,
+		},
+		{
+			MethodName: "GetTimeTotals",
+			Handler:    _Rafta_GetTimeTotals_Handler,
+		},
+		{
+			MethodName: "NewFilter",
+			Handler:    _Rafta_NewFilter_Handler,
+		},
+		{
+			MethodName: "GetFilters",
+			Handler:    _Rafta_GetFilters_Handler,
+		},
+		{
+			MethodName: "UpdateFilter",
+			Handler:    _Rafta_UpdateFilter_Handler,
+		},
+		{
+			MethodName: "DeleteFilter",
+			Handler:    _Rafta_DeleteFilter_Handler,
+		},
+		{
+			MethodName: "EvaluateFilter",
+			Handler:    _Rafta_EvaluateFilter_Handler,
+		},
+		{
+			MethodName: "NewTemplate",
+			Handler:    _Rafta_NewTemplate_Handler,
+		},
+		{
+			MethodName: "GetTemplates",
+			Handler:    _Rafta_GetTemplates_Handler,
+		},
+		{
+			MethodName: "UpdateTemplate",
+			Handler:    _Rafta_UpdateTemplate_Handler,
+		},
+		{
+			MethodName: "DeleteTemplate",
+			Handler:    _Rafta_DeleteTemplate_Handler,
+		},
+		{
+			MethodName: "InstantiateTemplate",
+			Handler:    _Rafta_InstantiateTemplate_Handler,
+		},
+		{
+			MethodName: "ExportTemplate",
+			Handler:    _Rafta_ExportTemplate_Handler,
+		},
+		{
+			MethodName: "ImportTemplate",
+			Handler:    _Rafta_ImportTemplate_Handler,
+		},
+		{
+			MethodName: "SnoozeTask",
+			Handler:    _Rafta_SnoozeTask_Handler,
+		},
+		{
+			MethodName: "NewCustomField",
+			Handler:    _Rafta_NewCustomField_Handler,
+		},
+		{
+			MethodName: "GetCustomFields",
+			Handler:    _Rafta_GetCustomFields_Handler,
+		},
+		{
+			MethodName: "UpdateCustomField",
+			Handler:    _Rafta_UpdateCustomField_Handler,
+		},
+		{
+			MethodName: "DeleteCustomField",
+			Handler:    _Rafta_DeleteCustomField_Handler,
+		},
+		{
+			MethodName: "MoveTask",
+			Handler:    _Rafta_MoveTask_Handler,
+		},
+		{
+			MethodName: "GetPreferences",
+			Handler:    _Rafta_GetPreferences_Handler,
+		},
+		{
+			MethodName: "UpdatePreferences",
+			Handler:    _Rafta_UpdatePreferences_Handler,
+		},
+		{
+			MethodName: "GetAgenda",
+			Handler:    _Rafta_GetAgenda_Handler,
+		},
+		{
+			MethodName: "GetSettings",
+			Handler:    _Rafta_GetSettings_Handler,
+		},
+		{
+			MethodName: "SetSettings",
+			Handler:    _Rafta_SetSettings_Handler,
+		},
+		{
+			MethodName: "NewPushEndpoint",
+			Handler:    _Rafta_NewPushEndpoint_Handler,
+		},
+		{
+			MethodName: "GetPushEndpoints",
+			Handler:    _Rafta_GetPushEndpoints_Handler,
+		},
+		{
+			MethodName: "UpdatePushEndpoint",
+			Handler:    _Rafta_UpdatePushEndpoint_Handler,
+		},
+		{
+			MethodName: "DeletePushEndpoint",
+			Handler:    _Rafta_DeletePushEndpoint_Handler,
+		},
+		{
+			MethodName: "TestPushEndpoint",
+			Handler:    _Rafta_TestPushEndpoint_Handler,
+		},
+		{
+			MethodName: "NewClientCertificate",
+			Handler:    _Rafta_NewClientCertificate_Handler,
+		},
+		{
+			MethodName: "GetClientCertificates",
+			Handler:    _Rafta_GetClientCertificates_Handler,
+		},
+		{
+			MethodName: "DeleteClientCertificate",
+			Handler:    _Rafta_DeleteClientCertificate_Handler,
+		},
+		{
+			MethodName: "NewPersonalToken",
+			Handler:    _Rafta_NewPersonalToken_Handler,
+		},
+		{
+			MethodName: "GetPersonalTokens",
+			Handler:    _Rafta_GetPersonalTokens_Handler,
+		},
+		{
+			MethodName: "RevokePersonalToken",
+			Handler:    _Rafta_RevokePersonalToken_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _Rafta_ListSessions_Handler,
+		},
+		{
+			MethodName: "RevokeSession",
+			Handler:    _Rafta_RevokeSession_Handler,
+		},
+		{
+			MethodName: "RevokeAllOtherSessions",
+			Handler:    _Rafta_RevokeAllOtherSessions_Handler,
+		},
+		{
+			MethodName: "EnrollTOTP",
+			Handler:    _Rafta_EnrollTOTP_Handler,
+		},
+		{
+			MethodName: "ConfirmTOTP",
+			Handler:    _Rafta_ConfirmTOTP_Handler,
+		},
+		{
+			MethodName: "DisableTOTP",
+			Handler:    _Rafta_DisableTOTP_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchSettings",
+			Handler:       _Rafta_WatchSettings_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "schema.proto",
 }
 
@@ -440,6 +2607,7 @@ const (
 	Admin_UpdateUser_FullMethodName        = "/Admin/UpdateUser"
 	Admin_GetUserRoles_FullMethodName      = "/Admin/GetUserRoles"
 	Admin_UpdateUserRoles_FullMethodName   = "/Admin/UpdateUserRoles"
+	Admin_ResetTOTP_FullMethodName         = "/Admin/ResetTOTP"
 )
 
 // AdminClient is the client API for Admin service.
@@ -467,6 +2635,7 @@ type AdminClient interface {
 	GetUserTasks(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*TaskList, error)
 	// This is allows the admin of the platform to change a user's passwords.
 	// This is meant as a tool to help users that have lost their password.
+	// Every session of the user ends.
 	//
 	// ***IF YOU'RE A SERVER ADMIN, PLZ BE NICE, DON'T LOCK PEOPLE OUT***
 	UpdateCredentials(ctx context.Context, in *ChangePasswdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
@@ -492,8 +2661,13 @@ type AdminClient interface {
 	GetUserRoles(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*UserRoles, error)
 	// Allows an admin to add other admins to the platform to ease server
 	// management The only moment this will refuse to work is when the only
-	// admin of a server tries to revoke his own role.
-	UpdateUserRoles(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// admin of a server tries to revoke his own role. The roles sent replace
+	// those of the user, whose access tokens get revoked so that the change
+	// applies as soon as they refresh.
+	UpdateUserRoles(ctx context.Context, in *UpdateUserRolesRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Disables two-factor authentication for users who lost both their
+	// authenticator app and their recovery codes.
+	ResetTOTP(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type adminClient struct {
@@ -584,7 +2758,7 @@ func (c *adminClient) GetUserRoles(ctx context.Context, in *UUID, opts ...grpc.C
 	return out, nil
 }
 
-func (c *adminClient) UpdateUserRoles(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *adminClient) UpdateUserRoles(ctx context.Context, in *UpdateUserRolesRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, Admin_UpdateUserRoles_FullMethodName, in, out, cOpts...)
@@ -594,6 +2768,16 @@ func (c *adminClient) UpdateUserRoles(ctx context.Context, in *UUID, opts ...grp
 	return out, nil
 }
 
+func (c *adminClient) ResetTOTP(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Admin_ResetTOTP_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AdminServer is the server API for Admin service.
 // All implementations must embed UnimplementedAdminServer
 // for forward compatibility.
@@ -619,6 +2803,7 @@ type AdminServer interface {
 	GetUserTasks(context.Context, *UUID) (*TaskList, error)
 	// This is allows the admin of the platform to change a user's passwords.
 	// This is meant as a tool to help users that have lost their password.
+	// Every session of the user ends.
 	//
 	// ***IF YOU'RE A SERVER ADMIN, PLZ BE NICE, DON'T LOCK PEOPLE OUT***
 	UpdateCredentials(context.Context, *ChangePasswdRequest) (*emptypb.Empty, error)
@@ -644,8 +2829,13 @@ type AdminServer interface {
 	GetUserRoles(context.Context, *UUID) (*UserRoles, error)
 	// Allows an admin to add other admins to the platform to ease server
 	// management The only moment this will refuse to work is when the only
-	// admin of a server tries to revoke his own role.
-	UpdateUserRoles(context.Context, *UUID) (*emptypb.Empty, error)
+	// admin of a server tries to revoke his own role. The roles sent replace
+	// those of the user, whose access tokens get revoked so that the change
+	// applies as soon as they refresh.
+	UpdateUserRoles(context.Context, *UpdateUserRolesRequest) (*emptypb.Empty, error)
+	// Disables two-factor authentication for users who lost both their
+	// authenticator app and their recovery codes.
+	ResetTOTP(context.Context, *UUID) (*emptypb.Empty, error)
 	mustEmbedUnimplementedAdminServer()
 }
 
@@ -680,9 +2870,12 @@ func (UnimplementedAdminServer) UpdateUser(context.Context, *User) (*emptypb.Emp
 func (UnimplementedAdminServer) GetUserRoles(context.Context, *UUID) (*UserRoles, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserRoles not implemented")
 }
-func (UnimplementedAdminServer) UpdateUserRoles(context.Context, *UUID) (*emptypb.Empty, error) {
+func (UnimplementedAdminServer) UpdateUserRoles(context.Context, *UpdateUserRolesRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserRoles not implemented")
 }
+func (UnimplementedAdminServer) ResetTOTP(context.Context, *UUID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetTOTP not implemented")
+}
 func (UnimplementedAdminServer) mustEmbedUnimplementedAdminServer() {}
 func (UnimplementedAdminServer) testEmbeddedByValue()               {}
 
@@ -849,7 +3042,7 @@ func _Admin_GetUserRoles_Handler(srv interface{}, ctx context.Context, dec func(
 }
 
 func _Admin_UpdateUserRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UUID)
+	in := new(UpdateUserRolesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -861,7 +3054,25 @@ func _Admin_UpdateUserRoles_Handler(srv interface{}, ctx context.Context, dec fu
 		FullMethod: Admin_UpdateUserRoles_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AdminServer).UpdateUserRoles(ctx, req.(*UUID))
+		return srv.(AdminServer).UpdateUserRoles(ctx, req.(*UpdateUserRolesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ResetTOTP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UUID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ResetTOTP(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ResetTOTP_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ResetTOTP(ctx, req.(*UUID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -909,15 +3120,21 @@ var Admin_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateUserRoles",
 			Handler:    _Admin_UpdateUserRoles_Handler,
 		},
+		{
+			MethodName: "ResetTOTP",
+			Handler:    _Admin_ResetTOTP_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "schema.proto",
 }
 
 const (
-	Auth_Signup_FullMethodName  = "/Auth/Signup"
-	Auth_Login_FullMethodName   = "/Auth/Login"
-	Auth_Refresh_FullMethodName = "/Auth/Refresh"
+	Auth_Signup_FullMethodName    = "/Auth/Signup"
+	Auth_Login_FullMethodName     = "/Auth/Login"
+	Auth_OIDCLogin_FullMethodName = "/Auth/OIDCLogin"
+	Auth_Refresh_FullMethodName   = "/Auth/Refresh"
+	Auth_Logout_FullMethodName    = "/Auth/Logout"
 )
 
 // AuthClient is the client API for Auth service.
@@ -929,11 +3146,24 @@ type AuthClient interface {
 	// Signs up a new user (no auth required)
 	Signup(ctx context.Context, in *UserSignupRequest, opts ...grpc.CallOption) (*LoginResponse, error)
 	// Logs in an existing user using Basic auth ( ex: base64(username:password) )
-	// providing the user with a pair of JWT (access + refresh)
+	// providing the user with a pair of JWT (access + refresh) tied to a new
+	// session. The device logging in can be named with "device-name" metadata.
+	// Users with two-factor authentication also send a code as "otp-code"
+	// metadata.
 	Login(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*LoginResponse, error)
+	// Logs in a user of the OpenID Connect provider of the server (single
+	// sign-on). Clients go through the authorization code flow with PKCE of the
+	// provider and send the ID token they get. Users are matched by account,
+	// then by verified email, and can be created on the fly if the server
+	// allows it. Two-factor authentication still applies like with Login.
+	OIDCLogin(ctx context.Context, in *OIDCLoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
 	// Provides a new pair of JWT and revokes the refresh token provided to
-	// make that request.
+	// make that request. Presenting a revoked refresh token again signs out
+	// its session as the token was likely stolen.
 	Refresh(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*JWT, error)
+	// Ends the session of the access token making the request, revoking its
+	// tokens.
+	Logout(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type authClient struct {
@@ -964,6 +3194,16 @@ func (c *authClient) Login(ctx context.Context, in *emptypb.Empty, opts ...grpc.
 	return out, nil
 }
 
+func (c *authClient) OIDCLogin(ctx context.Context, in *OIDCLoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, Auth_OIDCLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *authClient) Refresh(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*JWT, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(JWT)
@@ -974,6 +3214,16 @@ func (c *authClient) Refresh(ctx context.Context, in *emptypb.Empty, opts ...grp
 	return out, nil
 }
 
+func (c *authClient) Logout(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Auth_Logout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthServer is the server API for Auth service.
 // All implementations must embed UnimplementedAuthServer
 // for forward compatibility.
@@ -983,11 +3233,24 @@ type AuthServer interface {
 	// Signs up a new user (no auth required)
 	Signup(context.Context, *UserSignupRequest) (*LoginResponse, error)
 	// Logs in an existing user using Basic auth ( ex: base64(username:password) )
-	// providing the user with a pair of JWT (access + refresh)
+	// providing the user with a pair of JWT (access + refresh) tied to a new
+	// session. The device logging in can be named with "device-name" metadata.
+	// Users with two-factor authentication also send a code as "otp-code"
+	// metadata.
 	Login(context.Context, *emptypb.Empty) (*LoginResponse, error)
+	// Logs in a user of the OpenID Connect provider of the server (single
+	// sign-on). Clients go through the authorization code flow with PKCE of the
+	// provider and send the ID token they get. Users are matched by account,
+	// then by verified email, and can be created on the fly if the server
+	// allows it. Two-factor authentication still applies like with Login.
+	OIDCLogin(context.Context, *OIDCLoginRequest) (*LoginResponse, error)
 	// Provides a new pair of JWT and revokes the refresh token provided to
-	// make that request.
+	// make that request. Presenting a revoked refresh token again signs out
+	// its session as the token was likely stolen.
 	Refresh(context.Context, *emptypb.Empty) (*JWT, error)
+	// Ends the session of the access token making the request, revoking its
+	// tokens.
+	Logout(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
 	mustEmbedUnimplementedAuthServer()
 }
 
@@ -1004,9 +3267,15 @@ func (UnimplementedAuthServer) Signup(context.Context, *UserSignupRequest) (*Log
 func (UnimplementedAuthServer) Login(context.Context, *emptypb.Empty) (*LoginResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
 }
+func (UnimplementedAuthServer) OIDCLogin(context.Context, *OIDCLoginRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OIDCLogin not implemented")
+}
 func (UnimplementedAuthServer) Refresh(context.Context, *emptypb.Empty) (*JWT, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Refresh not implemented")
 }
+func (UnimplementedAuthServer) Logout(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
 func (UnimplementedAuthServer) mustEmbedUnimplementedAuthServer() {}
 func (UnimplementedAuthServer) testEmbeddedByValue()              {}
 
@@ -1064,6 +3333,24 @@ func _Auth_Login_Handler(srv interface{}, ctx context.Context, dec func(interfac
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Auth_OIDCLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OIDCLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).OIDCLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_OIDCLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).OIDCLogin(ctx, req.(*OIDCLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Auth_Refresh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -1082,6 +3369,24 @@ func _Auth_Refresh_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Auth_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Auth_Logout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).Logout(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Auth_ServiceDesc is the grpc.ServiceDesc for Auth service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1097,10 +3402,18 @@ var Auth_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Login",
 			Handler:    _Auth_Login_Handler,
 		},
+		{
+			MethodName: "OIDCLogin",
+			Handler:    _Auth_OIDCLogin_Handler,
+		},
 		{
 			MethodName: "Refresh",
 			Handler:    _Auth_Refresh_Handler,
 		},
+		{
+			MethodName: "Logout",
+			Handler:    _Auth_Logout_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "schema.proto",
@@ -0,0 +1,28 @@
+-- name: NewClientCertificate :one
+insert into client_certificates (user_id, name, fingerprint, subject, not_after)
+values (?, ?, ?, ?, ?)
+returning *;
+
+-- name: GetUserClientCertificates :many
+select *
+from client_certificates
+where user_id = ?
+order by name
+;
+
+-- name: GetClientCertificate :one
+select *
+from client_certificates
+where fingerprint = ?
+;
+
+-- name: TouchClientCertificate :exec
+update client_certificates
+set last_used = CURRENT_TIMESTAMP
+where cert_id = ?
+;
+
+-- name: DeleteUserClientCertificate :execrows
+delete from client_certificates
+where cert_id = ? and user_id = ?
+;
@@ -0,0 +1,55 @@
+-- name: NewCustomField :one
+insert into custom_fields (owner, name, kind, options)
+values (?, ?, ?, ?)
+returning *;
+
+-- name: GetUserCustomField :one
+select *
+from custom_fields
+where field_id = ? and owner = ?
+;
+
+-- name: GetUserCustomFields :many
+select *
+from custom_fields
+where owner = ?
+order by name
+;
+
+-- name: UpdateUserCustomField :one
+update custom_fields
+set name = ?, options = ?, updated_on = CURRENT_TIMESTAMP
+where field_id = ? and owner = ?
+returning *;
+
+-- name: DeleteUserCustomField :execrows
+delete from custom_fields
+where field_id = ? and owner = ?
+;
+
+-- name: GetTaskFieldValues :many
+select v.field_id, v.value, f.kind
+from task_field_values v
+inner join custom_fields f on f.field_id = v.field_id
+where v.task_id = ?
+order by f.name
+;
+
+-- name: GetTasksFieldValues :many
+select v.task_id, v.field_id, v.value, f.kind
+from task_field_values v
+inner join custom_fields f on f.field_id = v.field_id
+where v.task_id in (sqlc.slice('task_ids'))
+order by f.name
+;
+
+-- name: SetTaskFieldValue :exec
+insert into task_field_values (task_id, field_id, value)
+values (?, ?, ?)
+on conflict (task_id, field_id) do update set value = excluded.value
+;
+
+-- name: UnsetTaskFieldValue :exec
+delete from task_field_values
+where task_id = ? and field_id = ?
+;
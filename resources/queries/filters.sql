@@ -0,0 +1,28 @@
+-- name: NewFilter :one
+insert into saved_filters (owner, name, definition)
+values (?, ?, ?)
+returning *;
+
+-- name: GetUserFilter :one
+select *
+from saved_filters
+where filter_id = ? and owner = ?
+;
+
+-- name: GetUserFilters :many
+select *
+from saved_filters
+where owner = ?
+order by name
+;
+
+-- name: UpdateUserFilter :one
+update saved_filters
+set name = ?, definition = ?, updated_on = CURRENT_TIMESTAMP
+where filter_id = ? and owner = ?
+returning *;
+
+-- name: DeleteUserFilter :execrows
+delete from saved_filters
+where filter_id = ? and owner = ?
+;
@@ -0,0 +1,15 @@
+-- name: GetOIDCIdentity :one
+select *
+from oidc_identities
+where issuer = ? and subject = ?
+;
+
+-- name: NewOIDCIdentity :exec
+insert into oidc_identities (issuer, subject, user_id)
+values (?, ?, ?);
+
+-- name: TouchOIDCIdentity :exec
+update oidc_identities
+set last_used = CURRENT_TIMESTAMP
+where issuer = ? and subject = ?
+;
@@ -0,0 +1,28 @@
+-- name: NewPersonalToken :one
+insert into personal_tokens (user_id, name, hash, scopes, expires_on)
+values (?, ?, ?, ?, ?)
+returning *;
+
+-- name: GetUserPersonalTokens :many
+select *
+from personal_tokens
+where user_id = ?
+order by name
+;
+
+-- name: GetPersonalTokenFromHash :one
+select *
+from personal_tokens
+where hash = ?
+;
+
+-- name: TouchPersonalToken :exec
+update personal_tokens
+set last_used = CURRENT_TIMESTAMP
+where token_id = ?
+;
+
+-- name: DeleteUserPersonalToken :execrows
+delete from personal_tokens
+where token_id = ? and user_id = ?
+;
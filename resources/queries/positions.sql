@@ -0,0 +1,35 @@
+-- name: GetScopeTasks :many
+select t.task_id, p.sort_key
+from tasks t
+left join task_positions p on p.task_id = t.task_id and p.scope = sqlc.arg('scope')
+where t.owner = sqlc.arg('owner')
+  and (sqlc.arg('scope') = '' or exists (
+    select 1
+    from task_tags tt
+    inner join tags on tags.tag_id = tt.tag_id
+    where tt.task_id = t.task_id and tags.name = sqlc.arg('scope')
+  ))
+order by p.sort_key is null, p.sort_key, t.created_on, t.rowid
+;
+
+-- name: SetTaskPosition :exec
+insert into task_positions (task_id, scope, sort_key)
+values (?, ?, ?)
+on conflict (task_id, scope) do update set sort_key = excluded.sort_key
+;
+
+-- name: GetScopeEnd :one
+select
+  cast(coalesce(max(p.sort_key), '') as text) as last_key,
+  cast(coalesce(sum(p.sort_key is null), 0) as integer) as unordered
+from tasks t
+left join task_positions p on p.task_id = t.task_id and p.scope = sqlc.arg('scope')
+where t.owner = sqlc.arg('owner')
+  and t.task_id != sqlc.arg('task_id')
+  and (sqlc.arg('scope') = '' or exists (
+    select 1
+    from task_tags tt
+    inner join tags on tags.tag_id = tt.tag_id
+    where tt.task_id = t.task_id and tags.name = sqlc.arg('scope')
+  ))
+;
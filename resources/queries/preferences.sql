@@ -0,0 +1,12 @@
+-- name: GetUserPreferences :one
+select *
+from user_preferences
+where user_id = ?
+;
+
+-- name: SetUserPreferences :one
+insert into user_preferences (user_id, urgency)
+values (?, ?)
+on conflict (user_id) do update
+set urgency = excluded.urgency, updated_on = CURRENT_TIMESTAMP
+returning *;
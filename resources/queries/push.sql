@@ -0,0 +1,62 @@
+-- name: NewPushEndpoint :one
+insert into push_endpoints (user_id, name, kind, url, token, events)
+values (?, ?, ?, ?, ?, ?)
+returning *;
+
+-- name: GetUserPushEndpoint :one
+select *
+from push_endpoints
+where endpoint_id = ? and user_id = ?
+;
+
+-- name: GetUserPushEndpoints :many
+select *
+from push_endpoints
+where user_id = ?
+order by name
+;
+
+-- name: GetEnabledPushEndpoints :many
+select *
+from push_endpoints
+where user_id = ? and enabled
+;
+
+-- name: UpdateUserPushEndpoint :one
+update push_endpoints
+set name = ?, url = ?, token = ?, events = ?, enabled = ?,
+  failures = 0, last_error = NULL, updated_on = CURRENT_TIMESTAMP
+where endpoint_id = ? and user_id = ?
+returning *;
+
+-- name: DeleteUserPushEndpoint :execrows
+delete from push_endpoints
+where endpoint_id = ? and user_id = ?
+;
+
+-- name: RecordPushSuccess :exec
+update push_endpoints
+set failures = 0, last_error = NULL, last_delivery = CURRENT_TIMESTAMP
+where endpoint_id = ?
+;
+
+-- name: RecordPushFailure :one
+update push_endpoints
+set failures = failures + 1, last_error = sqlc.arg('error'),
+  enabled = enabled and not sqlc.arg('disable') and failures + 1 < sqlc.arg('max_failures')
+where endpoint_id = sqlc.arg('endpoint_id')
+returning enabled, failures;
+
+-- name: GetPushableTasks :many
+-- Pending tasks with a date whose owner or assignee can receive
+-- notifications. Hidden tasks are left alone until revealed.
+select tasks.*
+from tasks
+where state != 3
+  and (do_date is not null or due_date is not null)
+  and (hidden_until is null or hidden_until <= sqlc.arg('now'))
+  and exists (
+    select 1 from push_endpoints p
+    where p.enabled and (p.user_id = tasks.owner or p.user_id = tasks.assignee)
+  )
+;
@@ -0,0 +1,3 @@
+-- name: NewSecurityEvent :exec
+insert into security_events (user_id, kind, detail, ip)
+values (?, ?, ?, ?);
@@ -0,0 +1,54 @@
+-- name: NewSession :one
+insert into sessions (session_id, user_id, device, ip, expires_on)
+values (?, ?, ?, ?, ?)
+returning *;
+
+-- name: GetSession :one
+select *
+from sessions
+where session_id = ?
+;
+
+-- name: GetUserSessions :many
+select *
+from sessions
+where user_id = ? and expires_on > sqlc.arg('now')
+order by last_used desc
+;
+
+-- name: RenewSession :exec
+update sessions
+set expires_on = ?, ip = ?, last_used = CURRENT_TIMESTAMP
+where session_id = ?
+;
+
+-- name: SessionIsActive :one
+select count(*) > 0 as is_active
+from sessions
+where session_id = ? and expires_on > sqlc.arg('now')
+;
+
+-- name: DeleteSession :exec
+delete from sessions
+where session_id = ?
+;
+
+-- name: DeleteUserSession :execrows
+delete from sessions
+where session_id = ? and user_id = ?
+;
+
+-- name: DeleteOtherUserSessions :execrows
+delete from sessions
+where user_id = ? and session_id != sqlc.arg('current')
+;
+
+-- name: DeleteUserSessions :exec
+delete from sessions
+where user_id = ?
+;
+
+-- name: DeleteExpiredUserSessions :exec
+delete from sessions
+where user_id = ? and expires_on <= sqlc.arg('now')
+;
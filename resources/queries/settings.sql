@@ -0,0 +1,35 @@
+-- name: GetUserSettings :many
+select *
+from user_settings
+where user_id = sqlc.arg('user_id')
+  and (cast(sqlc.arg('namespace') as text) = '' or namespace = sqlc.arg('namespace'))
+order by namespace, key
+;
+
+-- name: SetUserSetting :one
+insert into user_settings (user_id, namespace, key, value)
+values (?, ?, ?, ?)
+on conflict (user_id, namespace, key) do update
+set value = excluded.value, updated_on = CURRENT_TIMESTAMP
+returning *;
+
+-- name: DeleteUserSetting :exec
+delete from user_settings
+where user_id = ? and namespace = ? and key = ?
+;
+
+-- name: GetUserSettingsUsage :one
+select
+  count(*) as count,
+  cast(coalesce(sum(
+    length(cast(namespace as blob)) + length(cast(key as blob)) + length(cast(value as blob))
+  ), 0) as integer) as size
+from user_settings
+where user_id = ?
+;
+
+-- name: GetSettingHolders :many
+select user_id, value
+from user_settings
+where namespace = ? and key = ?
+;
@@ -20,6 +20,13 @@ inner join task_tags tt on tags.tag_id = tt.tag_id
 where tt.task_id = ?
 ;
 
+-- name: GetTasksTags :many
+select tt.task_id, sqlc.embed(tags)
+from tags
+inner join task_tags tt on tags.tag_id = tt.tag_id
+where tt.task_id in (sqlc.slice('task_ids'))
+;
+
 -- name: AssignTag :exec
 insert into task_tags (task_id, tag_id) values (sqlc.arg('task'), sqlc.arg('tag'));
 
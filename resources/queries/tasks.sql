@@ -18,11 +18,73 @@ where owner = ?
 
 -- name: NewTask :one
 insert into tasks
-(title, state, priority, description, due_date, do_date, recurrence_pattern, recurrence_enabled, owner) values
-(?, ?, ?, ?, ?, ?, ?, ?, ?) returning *;
+(title, state, priority, description, checklist_done, checklist_total, due_date, do_date, hidden_until, recurrence_pattern, recurrence_enabled, owner) values
+(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) returning *;
+
+-- name: UpdateTaskDescription :one
+update tasks
+set description = ?, checklist_done = ?, checklist_total = ?, updated_on = CURRENT_TIMESTAMP
+where task_id = ? and owner = ?
+returning updated_on;
 
 -- name: DeleteUserTask :execrows
 delete from tasks
 where owner = sqlc.arg('owner') and task_id = sqlc.arg('task')
 ;
 
+
+-- name: GetVisibleUserTasks :many
+select tasks.*
+from tasks
+left join task_positions p on p.task_id = tasks.task_id and p.scope = ''
+where owner = sqlc.arg('owner')
+  and (hidden_until is null or hidden_until <= sqlc.arg('now'))
+order by p.sort_key is null, p.sort_key, created_on, tasks.rowid
+;
+
+-- name: GetAssignedTasks :many
+select *
+from tasks
+where assignee = sqlc.arg('assignee')
+  and (hidden_until is null or hidden_until <= sqlc.arg('now'))
+;
+
+-- name: SetTaskAssignee :exec
+update tasks
+set assignee = sqlc.narg('assignee'), updated_on = CURRENT_TIMESTAMP
+where task_id = sqlc.arg('task_id')
+;
+
+-- name: WorksOnTaskTags :one
+select count(*) > 0 as works_on_tags
+from task_tags mine
+inner join task_tags theirs on theirs.tag_id = mine.tag_id
+inner join tasks t on t.task_id = theirs.task_id
+where mine.task_id = sqlc.arg('task_id')
+  and t.task_id != sqlc.arg('task_id')
+  and t.owner = sqlc.arg('user_id')
+;
+
+-- name: NewTaskAssignment :one
+insert into task_assignments (task_id, assignee, assigned_by)
+values (sqlc.arg('task_id'), sqlc.narg('assignee'), sqlc.narg('assigned_by'))
+returning *;
+
+-- name: GetTaskAssignments :many
+select *
+from task_assignments
+where task_id = ?
+order by assigned_on, assignment_id
+;
+
+-- name: SnoozeUserTask :one
+update tasks
+set hidden_until = ?, updated_on = CURRENT_TIMESTAMP
+where task_id = ? and owner = ?
+returning updated_on;
+
+-- name: RevealTasks :many
+update tasks
+set hidden_until = null
+where hidden_until <= sqlc.arg('now')
+returning *;
@@ -0,0 +1,28 @@
+-- name: NewTemplate :one
+insert into templates (owner, name, definition)
+values (?, ?, ?)
+returning *;
+
+-- name: GetUserTemplate :one
+select *
+from templates
+where template_id = ? and owner = ?
+;
+
+-- name: GetUserTemplates :many
+select *
+from templates
+where owner = ?
+order by name
+;
+
+-- name: UpdateUserTemplate :one
+update templates
+set name = ?, definition = ?, updated_on = CURRENT_TIMESTAMP
+where template_id = ? and owner = ?
+returning *;
+
+-- name: DeleteUserTemplate :execrows
+delete from templates
+where template_id = ? and owner = ?
+;
@@ -0,0 +1,46 @@
+-- name: NewTimeEntry :one
+insert into time_entries (task_id, user_id, started_on, ended_on, note)
+values (?, ?, ?, ?, ?)
+returning *;
+
+-- name: GetUserTimeEntry :one
+select *
+from time_entries
+where entry_id = ? and user_id = ?
+;
+
+-- name: StopUserTimers :many
+update time_entries
+set ended_on = sqlc.arg('ended_on')
+where user_id = sqlc.arg('user_id') and ended_on is null
+returning *;
+
+-- name: UpdateUserTimeEntry :one
+update time_entries
+set task_id = ?, started_on = ?, ended_on = ?, note = ?
+where entry_id = ? and user_id = ?
+returning *;
+
+-- name: DeleteUserTimeEntry :execrows
+delete from time_entries
+where entry_id = ? and user_id = ?
+;
+
+-- name: GetUserTimeEntries :many
+select *
+from time_entries
+where user_id = sqlc.arg('user_id')
+  and started_on < sqlc.arg('until')
+  and (ended_on is null or ended_on > sqlc.arg('since'))
+order by started_on
+;
+
+-- name: GetUserTaskTimeEntries :many
+select *
+from time_entries
+where user_id = sqlc.arg('user_id')
+  and task_id = sqlc.arg('task_id')
+  and started_on < sqlc.arg('until')
+  and (ended_on is null or ended_on > sqlc.arg('since'))
+order by started_on
+;
@@ -1,25 +1,32 @@
--- name: RevokeToken :exec
-insert into revoked_tokens (token_id, expiry) values (?, ?);
+-- name: IssueToken :exec
+insert into issued_tokens (token_id, family_id, user_id, type, expiry)
+values (?, ?, ?, ?, ?);
 
--- name: TokenIsRevoked :one
-select count(*) > 0 as is_revoked
-from revoked_tokens
+-- name: GetIssuedToken :one
+select *
+from issued_tokens
 where token_id = ?
 ;
 
--- name: CleanRevokedToken :exec
-delete from revoked_tokens
-where token_id = ?
+-- name: RevokeToken :execrows
+update issued_tokens
+set revoked_on = CURRENT_TIMESTAMP
+where token_id = ? and revoked_on is null
 ;
 
--- name: GetAllRevokedTokens :many
-select *
-from revoked_tokens
+-- name: RevokeTokenFamily :execrows
+update issued_tokens
+set revoked_on = CURRENT_TIMESTAMP
+where family_id = ? and revoked_on is null
 ;
 
--- name: GetRevokedToken :one
-select *
-from revoked_tokens
-where token_id = ?
+-- name: RevokeUserAccessTokens :exec
+update issued_tokens
+set revoked_on = CURRENT_TIMESTAMP
+where user_id = ? and type = 'access' and revoked_on is null
 ;
 
+-- name: DeleteExpiredTokens :execrows
+delete from issued_tokens
+where expiry < ?
+;
@@ -0,0 +1,49 @@
+-- name: SetUserTOTP :exec
+insert into user_totp (user_id, secret)
+values (?, ?)
+on conflict(user_id) do update
+set secret = excluded.secret, confirmed = FALSE, last_step = 0, created_on = CURRENT_TIMESTAMP
+;
+
+-- name: GetUserTOTP :one
+select *
+from user_totp
+where user_id = ?
+;
+
+-- name: ConfirmUserTOTP :exec
+update user_totp
+set confirmed = TRUE, last_step = ?
+where user_id = ?
+;
+
+-- name: UseTOTPStep :execrows
+update user_totp
+set last_step = sqlc.arg('step')
+where user_id = sqlc.arg('user_id') and last_step < sqlc.arg('step')
+;
+
+-- name: DeleteUserTOTP :execrows
+delete from user_totp
+where user_id = ?
+;
+
+-- name: NewRecoveryCode :exec
+insert into recovery_codes (user_id, salt, hash)
+values (?, ?, ?);
+
+-- name: GetRecoveryCodes :many
+select *
+from recovery_codes
+where user_id = ?
+;
+
+-- name: DeleteRecoveryCode :execrows
+delete from recovery_codes
+where code_id = ?
+;
+
+-- name: DeleteRecoveryCodes :exec
+delete from recovery_codes
+where user_id = ?
+;
@@ -15,6 +15,12 @@ where user_id = ?
 limit 1
 ;
 
+-- name: GetUserFromEmail :one
+select *
+from users
+where email = ?
+;
+
 -- name: GetUserSecretsFromEmail :one
 select user_secrets.*
 from users
@@ -64,6 +70,11 @@ delete from user_roles
 where user_id = ? and role = ?
 ;
 
+-- name: ClearUserRoles :exec
+delete from user_roles
+where user_id = ?
+;
+
 -- name: RevokeUserRoleFromEmail :exec
 delete from user_roles
 where user_id = (select user_id from users where email = ?) and role = ?
@@ -105,6 +116,12 @@ insert into users (name, email) values (?, ?) returning *;
 -- name: NewUserSecret :exec
 insert into user_secrets (user_id, salt, hash) values (sqlc.arg('user_id'), sqlc.arg('salt'), sqlc.arg('hash'));
 
+-- name: RoleExists :one
+select count(*) > 0 as role_exists
+from roles
+where role = ?
+;
+
 -- name: NewAdminRole :exec
 insert into roles (role) values (?)
 on conflict(role) do nothing;